@@ -0,0 +1,167 @@
+package bandit
+
+import "sync"
+
+// AdaptiveGreedy represents an epsilon greedy algorithm that switches to UCB1
+// once every arm has at least WarmupPulls pulls, carrying over the counts and
+// rewards accumulated during the warmup
+type AdaptiveGreedy struct {
+	sync.RWMutex
+	Epsilon     float64
+	WarmupPulls int
+	Counts      []int
+	Rewards     []float64
+
+	// SelectionCount is the number of selections made, which is the time step
+	// of the exploration bonus
+	SelectionCount int
+
+	// Rand is used for exploration, and defaults to the math/rand source
+	Rand Rand
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *AdaptiveGreedy) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.SelectionCount = 0
+	return nil
+}
+
+// SelectArm chooses an arm that exploits if the value is more than the epsilon
+// threshold, and explore if the value is less than epsilon. After the warmup
+// the arm with the highest upper confidence bound is chosen, regardless of the
+// probability.
+func (b *AdaptiveGreedy) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	arm := b.selectArm(probability)
+	b.SelectionCount++
+	return arm, nil
+}
+
+func (b *AdaptiveGreedy) selectArm(probability float64) int {
+	nArms := len(b.Rewards)
+	if nArms == 1 {
+		return 0
+	}
+
+	if b.warmedUp() {
+		totalCounts := timeStep(b.SelectionCount, b.Counts)
+		ucbValues := make([]float64, nArms)
+		for i := 0; i < nArms; i++ {
+			ucbValues[i] = ucbValue(b.Rewards[i], b.Counts[i], totalCounts)
+		}
+		return max(ucbValues...)
+	}
+
+	// Exploit
+	if b.Epsilon == 0 || probability > b.Epsilon {
+		return max(b.Rewards...)
+	}
+
+	// Explore
+	return randIntn(b.Rand, nArms)
+}
+
+// EffectiveEpsilon returns the exploration rate the next selection uses, which
+// is zero once the warmup is over and UCB1 selects the arms
+func (b *AdaptiveGreedy) EffectiveEpsilon() float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.warmedUp() {
+		return 0
+	}
+	return b.Epsilon
+}
+
+// IsUCB returns whether the warmup is over and the arms are selected by UCB1
+func (b *AdaptiveGreedy) IsUCB() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.warmedUp()
+}
+
+func (b *AdaptiveGreedy) warmedUp() bool {
+	if len(b.Counts) == 0 {
+		return false
+	}
+	for _, count := range b.Counts {
+		// NOTE: UCB1 needs every arm to be pulled at least once
+		if count < b.WarmupPulls || count == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Update will update an arm with some reward value,
+// e.g. click = 1, no click = 0
+func (b *AdaptiveGreedy) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+
+	oldRewards := b.Rewards[chosenArm]
+	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
+
+	return nil
+}
+
+// GetCounts returns the counts
+func (b *AdaptiveGreedy) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *AdaptiveGreedy) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewAdaptiveGreedy returns a pointer to the AdaptiveGreedy struct
+func NewAdaptiveGreedy(epsilon float64, warmupPulls int, counts []int, rewards []float64) (*AdaptiveGreedy, error) {
+	if epsilon < 0 || epsilon > 1 {
+		return nil, ErrInvalidEpsilon
+	}
+	if warmupPulls < 1 {
+		return nil, ErrInvalidPulls
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &AdaptiveGreedy{
+		Epsilon:     epsilon,
+		WarmupPulls: warmupPulls,
+		Counts:      counts,
+		Rewards:     rewards,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveGreedy_New(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		epsilon float64
+		warmup  int
+		counts  []int
+		rewards []float64
+		err     error
+	}{
+		{0.1, 1, nil, nil, nil},
+		{0.5, 10, make([]int, 3), make([]float64, 3), nil},
+		{-0.1, 1, nil, nil, ErrInvalidEpsilon},
+		{1.1, 1, nil, nil, ErrInvalidEpsilon},
+		{0.1, 0, nil, nil, ErrInvalidPulls},
+		{0.1, 1, make([]int, 3), nil, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		b, err := NewAdaptiveGreedy(tt.epsilon, tt.warmup, tt.counts, tt.rewards)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.warmup, b.WarmupPulls, "warmup should be equal")
+		}
+	}
+}
+
+func TestAdaptiveGreedy_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewAdaptiveGreedy(0.5, 3, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	b.Rand = rand.New(rand.NewSource(1))
+	assert.False(b.IsUCB(), "should start as epsilon greedy")
+
+	payout := []float64{0.2, 0.9, 0.5}
+	for arm := 0; arm < 3; arm++ {
+		for i := 0; i < 3; i++ {
+			assert.False(b.IsUCB(), "should not switch before every arm is warmed up")
+			assert.Nil(b.Update(arm, payout[arm]))
+		}
+	}
+	assert.True(b.IsUCB(), "should switch once every arm has the warmup pulls")
+
+	ucb, _ := NewUCB(b.GetCounts(), b.GetRewards())
+	for _, probability := range []float64{0.0, 0.3, 0.99} {
+		arm, err := b.SelectArm(probability)
+		assert.Nil(err)
+		expected, _ := ucb.SelectArm(probability)
+		assert.Equal(expected, arm, "should select deterministically like UCB")
+	}
+}
+
+func TestAdaptiveGreedy_SelectArmDuringWarmup(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewAdaptiveGreedy(0.5, 3, []int{5, 5, 1}, []float64{0.1, 0.9, 0.5})
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	arm, err := b.SelectArm(0.9)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should exploit the best arm")
+
+	arm, err = b.SelectArm(0.1)
+	assert.Nil(err)
+	assert.Equal(2, arm, "should explore with the random source")
+}
+
+func TestAdaptiveGreedy_EffectiveEpsilon(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewAdaptiveGreedy(0.2, 2, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+	assert.Equal(0.2, b.EffectiveEpsilon(), "should explore during the warmup")
+
+	for i := 0; i < 2; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0))
+	}
+	assert.Equal(0.0, b.EffectiveEpsilon(), "should not explore at random after the warmup")
+}
@@ -0,0 +1,86 @@
+package bandit
+
+// AliasArm pools the statistics of the arm from into the arm to, e.g. when two
+// indices turn out to serve the same creative. The counts and rewards of from
+// are merged into to, which is the canonical index, and later updates of
+// either index accumulate into it. Selection only returns the canonical index,
+// and the aliased arm keeps zero counts and rewards. Aliasing to an alias
+// resolves to its canonical index, and an alias cannot be aliased again.
+func (b *EpsilonGreedy) AliasArm(from, to int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.initialized() {
+		return ErrNotInitialized
+	}
+	for _, arm := range []int{from, to} {
+		if arm < 0 || arm >= len(b.Rewards) {
+			return &ArmIndexError{Index: arm, NumArms: len(b.Rewards)}
+		}
+	}
+	to = b.canonical(to)
+	if from == to || b.isAlias(from) {
+		return ErrInvalidAlias
+	}
+
+	b.ensureObservations()
+	b.ensureM2()
+
+	// NOTE: The means and squared deviations are combined with the parallel
+	// form of Welford's algorithm
+	na, nb := float64(b.Observations[to]), float64(b.Observations[from])
+	if b.hasWeights() {
+		na, nb = b.Weights[to], b.Weights[from]
+		b.Weights[to] += b.Weights[from]
+		b.SquaredWeights[to] += b.SquaredWeights[from]
+		b.Weights[from], b.SquaredWeights[from] = 0, 0
+	}
+	if n := na + nb; n > 0 {
+		delta := b.Rewards[from] - b.Rewards[to]
+		b.Rewards[to] += delta * nb / n
+		b.M2[to] += b.M2[from] + delta*delta*na*nb/n
+	}
+	b.Counts[to] += b.Counts[from]
+	b.Observations[to] += b.Observations[from]
+	b.M2Counts[to] += b.M2Counts[from]
+	b.M2Counts[from] = 0
+	if len(b.Precisions) == len(b.Rewards) && len(b.PrecisionCounts) == len(b.Rewards) {
+		b.Precisions[to] += b.Precisions[from]
+		b.PrecisionCounts[to] += b.PrecisionCounts[from]
+		b.Precisions[from], b.PrecisionCounts[from] = 0, 0
+	}
+	b.Counts[from], b.Observations[from], b.Rewards[from], b.M2[from] = 0, 0, 0, 0
+	b.invalidateBest()
+
+	if b.Aliases == nil {
+		b.Aliases = make(map[int]int)
+	}
+	for alias, canonical := range b.Aliases {
+		if canonical == from {
+			b.Aliases[alias] = to
+		}
+	}
+	b.Aliases[from] = to
+	return nil
+}
+
+// CanonicalArm returns the index that the statistics of an arm accumulate
+// into, which is the arm itself unless it is aliased
+func (b *EpsilonGreedy) CanonicalArm(arm int) int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.canonical(arm)
+}
+
+func (b *EpsilonGreedy) canonical(arm int) int {
+	if to, ok := b.Aliases[arm]; ok {
+		return to
+	}
+	return arm
+}
+
+func (b *EpsilonGreedy) isAlias(arm int) bool {
+	_, ok := b.Aliases[arm]
+	return ok
+}
@@ -0,0 +1,82 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_AliasArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.0, nil, nil)
+	assert.Nil(b.Init(3))
+	b.StableMean = true
+	assert.Nil(b.Update(0, 1.0))
+	assert.Nil(b.Update(0, 0.0))
+	assert.Nil(b.Update(2, 1.0))
+	assert.Nil(b.Update(2, 1.0))
+
+	assert.Nil(b.AliasArm(2, 0))
+	assert.Equal([]int{4, 0, 0}, b.GetCounts())
+	assert.InDelta(0.75, b.GetRewards()[0], 1e-12, "should pool the existing stats")
+
+	assert.Nil(b.Update(2, 1.0))
+	assert.Nil(b.Update(0, 0.0))
+	assert.Equal([]int{6, 0, 0}, b.GetCounts())
+	assert.InDelta(4.0/6.0, b.GetRewards()[0], 1e-12, "should pool updates of either index")
+
+	variances, err := b.GetVariances()
+	assert.Nil(err)
+	assert.InDelta((4*(1.0/3)*(1.0/3)+2*(2.0/3)*(2.0/3))/5, variances[0], 1e-12)
+
+	for i := 0; i < 10; i++ {
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.NotEqual(2, arm, "should only select the canonical index")
+	}
+	assert.Equal([]int{0, 1}, b.EnabledArms())
+	assert.Equal(0, b.CanonicalArm(2))
+	assert.Equal(1, b.CanonicalArm(1))
+}
+
+func TestEpsilonGreedy_AliasArmChain(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.0, nil, nil)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.AliasArm(2, 1))
+	assert.Nil(b.AliasArm(1, 0))
+	assert.Equal(0, b.CanonicalArm(2), "should repoint the aliases of the aliased arm")
+
+	assert.Nil(b.Update(2, 1.0))
+	assert.Equal([]int{1, 0, 0}, b.GetCounts())
+
+	b, _ = NewEpsilonGreedy(0.0, nil, nil)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.AliasArm(2, 1))
+	assert.Nil(b.AliasArm(0, 2), "should resolve the target to its canonical index")
+	assert.Equal(1, b.CanonicalArm(0))
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm)
+}
+
+func TestEpsilonGreedy_AliasArmErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &EpsilonGreedy{}
+	assert.Equal(ErrNotInitialized, b.AliasArm(1, 0))
+
+	assert.Nil(b.Init(3))
+	assert.True(errors.Is(b.AliasArm(3, 0), ErrArmsIndexOutOfRange))
+	assert.True(errors.Is(b.AliasArm(0, -1), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidAlias, b.AliasArm(1, 1))
+	assert.Nil(b.AliasArm(1, 0))
+	assert.Equal(ErrInvalidAlias, b.AliasArm(1, 2), "should not alias an alias again")
+	assert.Equal(ErrInvalidAlias, b.AliasArm(0, 1), "should not alias an arm to its own alias")
+
+	assert.Nil(b.Reset(3))
+	assert.Equal(1, b.CanonicalArm(1), "should reset the aliases")
+}
@@ -10,6 +10,13 @@ type AnnealingSoftmax struct {
 	sync.RWMutex
 	Counts  []int
 	Rewards []float64
+
+	// SelectionCount is the number of selections made, which is the time step
+	// of the temperature
+	SelectionCount int
+
+	// Guard, when set, warns about rewards outside the expected range
+	Guard *RewardGuard
 }
 
 // Init will initialise the counts and rewards with the provided number of arms
@@ -22,17 +29,27 @@ func (b *AnnealingSoftmax) Init(nArms int) error {
 	}
 	b.Counts = make([]int, nArms)
 	b.Rewards = make([]float64, nArms)
+	b.SelectionCount = 0
 	return nil
 }
 
 // SelectArm chooses an arm that exploits if the value is more than the epsilon
 // threshold, and explore if the value is less than epsilon
-func (b *AnnealingSoftmax) SelectArm(probability float64) int {
-	b.RLock()
-	defer b.RUnlock()
+func (b *AnnealingSoftmax) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	arm := b.selectArm(probability)
+	b.SelectionCount++
+	return arm, nil
+}
 
+func (b *AnnealingSoftmax) selectArm(probability float64) int {
 	nArms := len(b.Rewards)
-	t := sum(b.Counts...) + 1
+	if nArms == 1 {
+		return 0
+	}
+	t := timeStep(b.SelectionCount, b.Counts) + 1
 
 	temperature := 1.0 / math.Log(float64(t)+1e-7)
 
@@ -50,17 +67,33 @@ func (b *AnnealingSoftmax) SelectArm(probability float64) int {
 	return categoricalProb(probability, probs...)
 }
 
+// GetSelectionCount returns the number of selections made
+func (b *AnnealingSoftmax) GetSelectionCount() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.SelectionCount
+}
+
 // Update will update an arm with some reward value,
 // e.g. click = 1, no click = 0
 func (b *AnnealingSoftmax) Update(chosenArm int, reward float64) error {
 	b.Lock()
-	defer b.Unlock()
+	warn, err := b.update(chosenArm, reward)
+	b.Unlock()
+
+	if warn != nil {
+		warn()
+	}
+	return err
+}
 
+func (b *AnnealingSoftmax) update(chosenArm int, reward float64) (func(), error) {
 	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
-		return ErrArmsIndexOutOfRange
+		return nil, &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
 	}
 	if reward < 0 {
-		return ErrInvalidReward
+		return nil, ErrInvalidReward
 	}
 
 	b.Counts[chosenArm]++
@@ -69,7 +102,16 @@ func (b *AnnealingSoftmax) Update(chosenArm int, reward float64) error {
 	oldRewards := b.Rewards[chosenArm]
 	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
 
-	return nil
+	return b.Guard.observe(chosenArm, reward), nil
+}
+
+// ObservedRewardRange returns the smallest and largest reward observed by the
+// guard, or an empty range of +Inf to -Inf without a guard or observations
+func (b *AnnealingSoftmax) ObservedRewardRange() (min, max float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Guard.observedRange()
 }
 
 // GetCounts returns the counts
@@ -92,6 +134,30 @@ func (b *AnnealingSoftmax) GetRewards() []float64 {
 	return sCopy
 }
 
+// CopyCounts copies the counts into dst without allocating, and returns the
+// number of arms copied
+func (b *AnnealingSoftmax) CopyCounts(dst []int) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Counts) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Counts), nil
+}
+
+// CopyRewards copies the rewards into dst without allocating, and returns the
+// number of arms copied
+func (b *AnnealingSoftmax) CopyRewards(dst []float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Rewards) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Rewards), nil
+}
+
 // NewAnnealingSoftmax returns a pointer to the AnnealingSoftmax struct
 func NewAnnealingSoftmax(counts []int, rewards []float64) (*AnnealingSoftmax, error) {
 	if len(counts) != len(rewards) {
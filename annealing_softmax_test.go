@@ -1,6 +1,7 @@
 package bandit
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,7 +69,8 @@ func TestAnnealingSoftmax_SelectArm(t *testing.T) {
 	assert.Nil(err)
 	b.Init(3)
 
-	arm := b.SelectArm(0.1)
+	arm, err := b.SelectArm(0.1)
+	assert.Nil(err)
 	assert.Equal(arm, 0, "should select the unplayed arm")
 	err = b.Update(arm, 1.0)
 	assert.Nil(err)
@@ -100,7 +102,7 @@ func TestAnnealingSoftmax_UpdateWithValidParams(t *testing.T) {
 		b.Init(tt.arms)
 		err := b.Update(tt.chosenArm, tt.reward)
 		if tt.err != nil {
-			assert.Equal(tt.err, err, "should throw error for invalid params")
+			assert.True(errors.Is(err, tt.err), "should throw error for invalid params")
 		} else {
 			assert.Nil(err)
 		}
@@ -0,0 +1,90 @@
+package bandit
+
+import (
+	"encoding/gob"
+	"encoding/json"
+)
+
+// AuditedDecision is a logged selection together with the state it was made
+// from, so that an audit can re-derive it with VerifyDecision
+type AuditedDecision struct {
+	// State is the persisted state of the bandit right before the selection
+	State json.RawMessage `json:"state"`
+
+	// RandState is the serialized state of the random source right before
+	// the selection
+	RandState []byte `json:"rand_state"`
+
+	// InstanceID is the InstanceID of the bandit, which is mixed into the
+	// exploration draws but left out of the state
+	InstanceID string `json:"instance_id,omitempty"`
+
+	Probability float64 `json:"probability"`
+	Arm         int     `json:"arm"`
+}
+
+// SelectArmLogged chooses an arm like SelectArm, and captures the state and
+// the random source it was chosen from under the same lock. The source must be
+// serializable, e.g. a ReplayableRand. The InstanceID, which shifts the
+// exploration draws, is captured along with the state. The Schedule and
+// ExplorePrior are not, so decisions depending on them cannot be verified.
+func (b *EpsilonGreedy) SelectArmLogged(probability float64) (AuditedDecision, error) {
+	b.Lock()
+	d, logged, err := b.selectArmLogged(probability)
+	if err == nil {
+		b.record(d)
+	}
+	logger := b.logger
+	b.Unlock()
+
+	if _, err := selected(logger, d, err); err != nil {
+		return AuditedDecision{}, err
+	}
+	return logged, nil
+}
+
+func (b *EpsilonGreedy) selectArmLogged(probability float64) (decision, AuditedDecision, error) {
+	encoder, ok := b.Rand.(gob.GobEncoder)
+	if !ok {
+		return decision{}, AuditedDecision{}, ErrRandNotSerializable
+	}
+	randState, err := encoder.GobEncode()
+	if err != nil {
+		return decision{}, AuditedDecision{}, err
+	}
+	state, err := b.marshalState()
+	if err != nil {
+		return decision{}, AuditedDecision{}, err
+	}
+
+	d, err := b.selectArm(probability)
+	if err != nil {
+		return decision{}, AuditedDecision{}, err
+	}
+	return d, AuditedDecision{State: state, RandState: randState, InstanceID: b.InstanceID, Probability: probability, Arm: d.arm}, nil
+}
+
+// VerifyDecision restores the captured state and InstanceID into a fresh
+// bandit with a ReplayableRand, replays the selection, and returns
+// ErrDecisionMismatch when it does not choose the logged arm
+func VerifyDecision(logged AuditedDecision) error {
+	b := &EpsilonGreedy{}
+	if err := json.Unmarshal(logged.State, b); err != nil {
+		return err
+	}
+	r := &ReplayableRand{}
+	if err := r.GobDecode(logged.RandState); err != nil {
+		return err
+	}
+	b.Rand = r
+	b.InstanceID = logged.InstanceID
+
+	d, err := b.selectArm(logged.Probability)
+	if err != nil {
+		return err
+	}
+	if d.arm != logged.Arm {
+		return ErrDecisionMismatch
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package bandit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyDecision(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.5, nil, nil)
+	assert.Nil(b.Init(5))
+	b.Rand = NewReplayableRand(7)
+	for i := 0; i < 20; i++ {
+		arm, err := b.SelectArm(float64(i%10) / 10)
+		assert.Nil(err)
+		assert.Nil(b.Update(arm, float64(arm)/5))
+	}
+
+	var logged []AuditedDecision
+	for i := 0; i < 20; i++ {
+		d, err := b.SelectArmLogged(0.2)
+		assert.Nil(err)
+		logged = append(logged, d)
+		assert.Nil(b.Update(d.Arm, float64(d.Arm)/5))
+	}
+
+	for i, d := range logged {
+		data, err := json.Marshal(d)
+		assert.Nil(err)
+		var restored AuditedDecision
+		assert.Nil(json.Unmarshal(data, &restored))
+		assert.Nil(VerifyDecision(restored), "should reproduce the logged decision %d", i+1)
+	}
+
+	tampered := logged[0]
+	tampered.Arm = (tampered.Arm + 1) % 5
+	assert.Equal(ErrDecisionMismatch, VerifyDecision(tampered))
+}
+
+func TestEpsilonGreedy_SelectArmLoggedNotSerializable(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.5, nil, nil)
+	assert.Nil(b.Init(2))
+	_, err := b.SelectArmLogged(0.5)
+	assert.Equal(ErrRandNotSerializable, err)
+	assert.Equal(0, b.GetSelectionCount(), "should not select without a capture")
+}
+
+func TestVerifyDecisionInstanceID(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.5, nil, nil)
+	assert.Nil(b.Init(5))
+	b.Rand = NewReplayableRand(7)
+	b.InstanceID = "pod-a"
+
+	for i := 0; i < 50; i++ {
+		d, err := b.SelectArmLogged(0.2)
+		assert.Nil(err)
+		assert.Equal("pod-a", d.InstanceID)
+		assert.Nil(VerifyDecision(d), "should replay the decision %d with the instance", i+1)
+		assert.Nil(b.Update(d.Arm, float64(d.Arm)/5))
+	}
+}
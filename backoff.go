@@ -0,0 +1,137 @@
+package bandit
+
+import "math"
+
+// SetBackoff sets the factor that scales down the exploration of an arm for
+// every consecutive zero reward it received, so exploration concentrates on
+// the arms that show any promise. The factor must be in range 0 to 1, where
+// zero disables the backoff and one never backs off.
+func (b *EpsilonGreedy) SetBackoff(factor float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if factor < 0 || factor > 1 || math.IsNaN(factor) {
+		return ErrInvalidFraction
+	}
+	b.BackoffFactor = factor
+	return nil
+}
+
+// BackoffLevels returns the factor scaling the exploration of each arm, which
+// is one for arms without a backoff
+func (b *EpsilonGreedy) BackoffLevels() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	levels := make([]float64, len(b.Rewards))
+	for i := range levels {
+		levels[i] = b.backoffWeight(i)
+	}
+	return levels
+}
+
+// observeStreak counts the consecutive zero rewards of the arm
+func (b *EpsilonGreedy) observeStreak(arm int, reward float64) {
+	if len(b.ZeroStreaks) != len(b.Rewards) {
+		b.ZeroStreaks = make([]int, len(b.Rewards))
+	}
+	if reward == 0 {
+		b.ZeroStreaks[arm]++
+	} else {
+		b.ZeroStreaks[arm] = 0
+	}
+}
+
+// exploreWeight returns the relative weight of the arm in exploration
+func (b *EpsilonGreedy) exploreWeight(arm int) float64 {
+	if len(b.prior) == len(b.Rewards) {
+		return b.backoffWeight(arm) * b.prior[arm]
+	}
+	return b.backoffWeight(arm)
+}
+
+// weighted returns whether exploration draws the arms by their weights
+func (b *EpsilonGreedy) weighted() bool {
+	return b.BackoffFactor != 0 || len(b.prior) == len(b.Rewards)
+}
+
+// backoffWeight returns the factor scaling the exploration of the arm for its
+// consecutive zero rewards
+func (b *EpsilonGreedy) backoffWeight(arm int) float64 {
+	if b.BackoffFactor == 0 || len(b.ZeroStreaks) != len(b.Rewards) {
+		return 1
+	}
+	return math.Pow(b.BackoffFactor, float64(b.ZeroStreaks[arm]))
+}
+
+// exploreTotal returns the total weight of the arms in exploration, where nil
+// arms stand for all the arms
+func (b *EpsilonGreedy) exploreTotal(arms []int) float64 {
+	if arms == nil {
+		var total float64
+		for i := range b.Rewards {
+			total += b.exploreWeight(i)
+		}
+		return total
+	}
+
+	var total float64
+	for _, i := range arms {
+		total += b.exploreWeight(i)
+	}
+	return total
+}
+
+// exploreShare returns the probability of exploration landing on the arm out
+// of the arms, where nil arms stand for all the arms
+func (b *EpsilonGreedy) exploreShare(arm int, arms []int) float64 {
+	n := len(arms)
+	if arms == nil {
+		n = len(b.Rewards)
+	}
+	if !b.weighted() {
+		return 1 / float64(n)
+	}
+	total := b.exploreTotal(arms)
+	if total == 0 {
+		return 1 / float64(n)
+	}
+	return b.exploreWeight(arm) / total
+}
+
+// explore draws the arm to explore out of the arms, where nil arms stand for
+// all the arms. Arms are drawn uniformly without a backoff or prior.
+func (b *EpsilonGreedy) explore(arms []int) int {
+	n := len(arms)
+	if arms == nil {
+		n = len(b.Rewards)
+	}
+	at := func(i int) int {
+		if arms == nil {
+			return i
+		}
+		return arms[i]
+	}
+
+	// NOTE: The weights underflow to zero after long streaks, or the prior
+	// scores none of the arms, in which case the arms are drawn uniformly
+	total := 0.0
+	if b.weighted() {
+		total = b.exploreTotal(arms)
+	}
+	if total == 0 {
+		if b.InstanceID != "" {
+			return at(min(int(b.instanceDraw()*float64(n)), n-1))
+		}
+		return at(b.intn(n))
+	}
+
+	target := b.instanceDraw() * total
+	for i := 0; i < n; i++ {
+		target -= b.exploreWeight(at(i))
+		if target < 0 {
+			return at(i)
+		}
+	}
+	return at(n - 1)
+}
@@ -0,0 +1,74 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SetBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	assert.Equal(ErrInvalidFraction, b.SetBackoff(-0.1))
+	assert.Equal(ErrInvalidFraction, b.SetBackoff(1.1))
+	assert.Nil(b.SetBackoff(0.5))
+	assert.Equal(0.5, b.BackoffFactor)
+}
+
+func TestEpsilonGreedy_BackoffLevels(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	assert.Nil(b.Update(0, 0))
+	assert.Equal([]float64{1, 1}, b.BackoffLevels(), "should not back off while disabled")
+
+	assert.Nil(b.SetBackoff(0.5))
+	assert.Nil(b.Update(0, 0))
+	assert.Nil(b.Update(1, 0))
+	assert.Equal([]float64{0.25, 0.5}, b.BackoffLevels(), "should back off per consecutive zero reward")
+
+	assert.Nil(b.Update(0, 0.1))
+	assert.Equal([]float64{1, 0.5}, b.BackoffLevels(), "should reset on a positive reward")
+}
+
+func TestEpsilonGreedy_Backoff(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(1.0, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.SetBackoff(0.5))
+	b.Rand = rand.New(rand.NewSource(1))
+
+	previous := 1.0
+	for round := 0; round < 4; round++ {
+		explored := 0
+		for i := 0; i < 3000; i++ {
+			arm, err := b.SelectArm(0.0)
+			assert.Nil(err)
+			if arm == 2 {
+				explored++
+			}
+		}
+		share := float64(explored) / 3000
+		assert.Less(share, previous, "should explore the failing arm progressively less")
+		previous = share
+
+		assert.Nil(b.Update(0, 1.0))
+		assert.Nil(b.Update(1, 1.0))
+		assert.Nil(b.Update(2, 0.0))
+	}
+
+	assert.InDelta(0.0625/2.0625, b.exploreShare(2, nil), 1e-9, "should scale the share by the backoff level")
+	d, err := b.selectArm(0.0)
+	assert.Nil(err)
+	assert.InDelta(b.exploreShare(d.arm, nil), d.propensity, 1e-9, "propensity should follow the backoff")
+}
@@ -1,6 +1,9 @@
 package bandit
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrInvalidEpsilon      = errors.New("epsilon must be in range 0 to 1")
@@ -9,13 +12,88 @@ var (
 	ErrInvalidArms         = errors.New("arms must be greater than zero")
 	ErrArmsIndexOutOfRange = errors.New("arms index is out of range")
 	ErrInvalidReward       = errors.New("reward must be greater than zero")
+	ErrInvalidJitter       = errors.New("jitter must be in range 0 to 1")
+	ErrInvalidStrength     = errors.New("prior strength must not be negative")
+	ErrInvalidFraction     = errors.New("fraction must be in range 0 to 1")
+	ErrInvalidWeight       = errors.New("weight must not be negative")
+	ErrInvalidConfidence   = errors.New("confidence must be greater than zero")
+	ErrInvalidCost         = errors.New("cost must be greater than zero")
+	ErrInvalidSize         = errors.New("size must be greater than zero")
+	ErrInvalidDuration     = errors.New("duration must be greater than zero")
+	ErrInvalidRange        = errors.New("min must be less than max")
+	ErrActionOutOfRange    = errors.New("action is out of range")
+	ErrBufferTooSmall      = errors.New("buffer is smaller than the number of arms")
+	ErrNoEligibleArms      = errors.New("no arms are eligible for selection")
+	ErrFrozen              = errors.New("bandit is frozen")
+	ErrArmsMismatch        = errors.New("bandits must have the same number of arms")
+	ErrInvalidPulls        = errors.New("pulls must be greater than zero")
+	ErrInvalidCount        = errors.New("count must not be negative")
+	ErrInvalidScale        = errors.New("scale must be greater than zero")
+	ErrNotCloneable        = errors.New("bandit cannot be cloned")
+	ErrVarianceDisabled    = errors.New("variance requires the stable mean")
+	ErrInvalidProbability  = errors.New("probability must be in range 0 to 1")
+	ErrInvalidDeviation    = errors.New("standard deviation must not be negative")
+	ErrNotInitialized      = errors.New("bandit has no arms, call Init first")
+	ErrDuplicateUpdate     = errors.New("update was already applied")
+	ErrInvalidAlias        = errors.New("arm cannot be aliased to itself or aliased twice")
+	ErrInvalidCooldown     = errors.New("cooldown must not be negative")
+	ErrInvalidMinPulls     = errors.New("min pulls must not be negative")
+	ErrRandNotSerializable = errors.New("random source cannot be serialized")
+	ErrInvalidEta          = errors.New("learning rate must be greater than zero")
+	ErrInvalidStrategy     = errors.New("strategy must not be nil")
+	ErrMemberOutOfRange    = errors.New("member index is out of range")
+	ErrInvalidTarget       = errors.New("target must not be negative")
+	ErrInvalidGroup        = errors.New("group is out of range or has no arms")
+	ErrNoSelection         = errors.New("no arm has been selected yet")
+	ErrUnsupportedVersion  = errors.New("state version is not supported")
+	ErrPendingFull         = errors.New("too many selections are pending")
+	ErrUnknownPending      = errors.New("selection is not pending")
+	ErrInvalidPolicy       = errors.New("overflow policy is not supported")
+	ErrInvalidDiscount     = errors.New("discount must be in range 0 to 1")
+	ErrInvalidPrior        = errors.New("prior scores must not be negative and must sum to more than zero")
+	ErrDecisionMismatch    = errors.New("replayed decision does not match the logged arm")
+	ErrInvalidObjectives   = errors.New("objectives must be greater than zero")
+	ErrInvalidCheckpoint   = errors.New("checkpoint was not taken by CheckpointState")
+	ErrInvalidEncoding     = errors.New("binary state is malformed")
+	ErrStateTooLarge       = errors.New("state has too many arms to export")
+	ErrInvalidSimilarity   = errors.New("similarity must be in range 0 to 1 and symmetric")
+	ErrInvalidSchedule     = errors.New("schedule decay is not supported")
+	ErrInsufficientData    = errors.New("arm has no rewards")
+	ErrAlreadyInitialized  = errors.New("bandit is already initialized with another number of arms")
+	ErrInvalidRate         = errors.New("rate must not be negative")
+	ErrInvalidVariance     = errors.New("variance must not be negative")
+	ErrPositionOutOfRange  = errors.New("position is out of range of the position bias")
 )
 
+// ArmIndexError is returned for an arm index outside the range 0 to NumArms
+// exclusive, and matches ErrArmsIndexOutOfRange with errors.Is
+type ArmIndexError struct {
+	Index   int
+	NumArms int
+}
+
+func (e *ArmIndexError) Error() string {
+	return fmt.Sprintf("%v: %d not in range 0 to %d", ErrArmsIndexOutOfRange, e.Index, e.NumArms)
+}
+
+// Is reports whether target is ErrArmsIndexOutOfRange
+func (e *ArmIndexError) Is(target error) bool {
+	return target == ErrArmsIndexOutOfRange
+}
+
 // Bandit represents the bandit interface
 type Bandit interface {
 	Init(nArms int) error
-	SelectArm(probability float64) int
+	SelectArm(probability float64) (int, error)
 	Update(chosenArm int, reward float64) error
 	GetCounts() []int
 	GetRewards() []float64
 }
+
+// Rand represents a source of random numbers, e.g. *rand.Rand. Float64 is
+// expected in range 0 to 1 exclusive, and Intn(n) in range 0 to n exclusive.
+// Values outside the ranges, e.g. from a buggy source, are clamped into them.
+type Rand interface {
+	Float64() float64
+	Intn(n int) int
+}
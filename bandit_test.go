@@ -1,19 +1,68 @@
 package bandit
 
 import (
+	"errors"
 	"log"
 	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
-type BernoulliArm struct {
-	p float64
+// countingRand counts the number of random numbers drawn from it
+type countingRand struct {
+	Rand
+	calls int
+}
+
+func (r *countingRand) Float64() float64 {
+	r.calls++
+	return r.Rand.Float64()
 }
 
-func (b *BernoulliArm) Pull() bool {
-	return rand.Float64() > b.p
+func (r *countingRand) Intn(n int) int {
+	r.calls++
+	return r.Rand.Intn(n)
 }
 
-func Simulate(b Bandit, pulls int, arms []BernoulliArm) (index, chosenArms []int, rewards, cumulativeRewards []float64) {
+func TestSingleArm(t *testing.T) {
+	assert := assert.New(t)
+
+	rnd := &countingRand{Rand: rand.New(rand.NewSource(1))}
+	epsilonGreedy, _ := NewEpsilonGreedy(1.0, nil, nil)
+	epsilonGreedy.Rand = rnd
+	ucb, _ := NewUCB(nil, nil)
+	softmax, _ := NewSoftmax(0.1, nil, nil)
+	annealingSoftmax, _ := NewAnnealingSoftmax(nil, nil)
+
+	tests := []struct {
+		name   string
+		bandit Bandit
+	}{
+		{"epsilon greedy", epsilonGreedy},
+		{"ucb", ucb},
+		{"softmax", softmax},
+		{"annealing softmax", annealingSoftmax},
+	}
+
+	for _, tt := range tests {
+		err := tt.bandit.Init(1)
+		assert.Nil(err)
+
+		for i, probability := range []float64{0.0, 0.5, 1.0} {
+			arm, err := tt.bandit.SelectArm(probability)
+			assert.Nil(err)
+			assert.Equal(0, arm, "%s should always select the only arm", tt.name)
+
+			err = tt.bandit.Update(arm, float64(i%2))
+			assert.Nil(err)
+		}
+		assert.Equal([]int{3}, tt.bandit.GetCounts(), "%s should update the only arm", tt.name)
+	}
+	assert.Equal(0, rnd.calls, "should not draw random numbers")
+}
+
+func Simulate(b Bandit, pulls int, env Environment) (index, chosenArms []int, rewards, cumulativeRewards []float64) {
 
 	index = make([]int, pulls)
 	chosenArms = make([]int, pulls)
@@ -21,12 +70,12 @@ func Simulate(b Bandit, pulls int, arms []BernoulliArm) (index, chosenArms []int
 	cumulativeRewards = make([]float64, pulls)
 
 	for i := 0; i < pulls; i++ {
-		arm := b.SelectArm(rand.Float64())
-		chosenArm := arms[arm]
-		reward := 0.0
-		if chosenArm.Pull() == true {
-			reward = 1.0
+		arm, err := b.SelectArm(rand.Float64())
+		if err != nil {
+			log.Println(err)
+			break
 		}
+		reward := env.Pull(arm)
 		b.Update(arm, reward)
 
 		index[i] = i
@@ -41,3 +90,129 @@ func Simulate(b Bandit, pulls int, arms []BernoulliArm) (index, chosenArms []int
 	log.Println(b)
 	return
 }
+
+// delayedReward is a reward waiting to be delivered to the bandit
+type delayedReward struct {
+	arm    int
+	reward float64
+}
+
+// SimulateDelayed is like Simulate, but delivers each reward after a number
+// of rounds drawn from delay, so rewards can arrive out of order. Rewards
+// still pending at the end are delivered once all pulls are made.
+func SimulateDelayed(b Bandit, pulls int, env Environment, delay func() int) (index, chosenArms []int, rewards, cumulativeRewards []float64) {
+	index = make([]int, pulls)
+	chosenArms = make([]int, pulls)
+	rewards = make([]float64, pulls)
+	cumulativeRewards = make([]float64, pulls)
+
+	pending := make(map[int][]delayedReward)
+	deliver := func(round int) {
+		for _, p := range pending[round] {
+			b.Update(p.arm, p.reward)
+		}
+		delete(pending, round)
+	}
+
+	for i := 0; i < pulls; i++ {
+		arm, err := b.SelectArm(rand.Float64())
+		if err != nil {
+			log.Println(err)
+			break
+		}
+		reward := env.Pull(arm)
+
+		due := i + delay()
+		pending[due] = append(pending[due], delayedReward{arm, reward})
+		deliver(i)
+
+		index[i] = i
+		chosenArms[i] = arm
+		rewards[i] = reward
+		if i == 0 {
+			cumulativeRewards[i] = reward
+		} else {
+			cumulativeRewards[i] = cumulativeRewards[i-1] + reward
+		}
+	}
+
+	for round := range pending {
+		deliver(round)
+	}
+	return
+}
+
+func TestSimulateDelayed(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(3)
+	assert.Nil(err)
+
+	pulls := 1000
+	env, err := NewBernoulliEnv([]float64{0.1, 0.5, 0.9}, rand.New(rand.NewSource(2)))
+	assert.Nil(err)
+	delays := rand.New(rand.NewSource(1))
+	_, chosenArms, rewards, cumulativeRewards := SimulateDelayed(b, pulls, env, func() int {
+		return delays.Intn(20)
+	})
+
+	counts := b.GetCounts()
+	means := b.GetRewards()
+	assert.Equal(pulls, sum(counts...), "should deliver every reward")
+
+	var total float64
+	expectedCounts := make([]int, env.NumArms())
+	expectedTotals := make([]float64, env.NumArms())
+	for i, arm := range chosenArms {
+		expectedCounts[arm]++
+		expectedTotals[arm] += rewards[i]
+	}
+	for i := 0; i < env.NumArms(); i++ {
+		total += means[i] * float64(counts[i])
+		assert.Equal(expectedCounts[i], counts[i], "should credit arm %d with its pulls", i)
+		assert.InDelta(expectedTotals[i], means[i]*float64(counts[i]), 1e-6, "should credit arm %d with its rewards", i)
+	}
+	assert.InDelta(cumulativeRewards[pulls-1], total, 1e-6, "should account for the total reward")
+}
+
+func TestSimulate(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewUCB(nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+
+	pulls := 1000
+	env, err := NewBernoulliEnv([]float64{0.1, 0.2, 0.9}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	_, chosenArms, _, cumulativeRewards := Simulate(b, pulls, env)
+
+	assert.Equal(pulls, len(chosenArms))
+	assert.Equal(pulls, sum(b.GetCounts()...), "should update every pull")
+	regret := env.OptimalMean()*float64(pulls) - cumulativeRewards[pulls-1]
+	assert.Less(regret, 0.2*float64(pulls), "should play the optimal arm most of the time")
+}
+
+func TestArmIndexError(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(3))
+
+	for _, err := range []error{b.Update(5, 1), b.RemoveArm(-1), b.Disable(3)} {
+		assert.True(errors.Is(err, ErrArmsIndexOutOfRange), "should match the sentinel error")
+
+		var indexErr *ArmIndexError
+		assert.True(errors.As(err, &indexErr))
+		assert.Equal(3, indexErr.NumArms, "should carry the number of arms")
+	}
+
+	var indexErr *ArmIndexError
+	assert.True(errors.As(b.Update(5, 1), &indexErr))
+	assert.Equal(5, indexErr.Index, "should carry the offending index")
+	assert.Equal("arms index is out of range: 5 not in range 0 to 3", indexErr.Error())
+	assert.False(errors.Is(indexErr, ErrInvalidReward))
+}
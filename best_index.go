@@ -0,0 +1,124 @@
+package bandit
+
+import (
+	"container/heap"
+	"math"
+)
+
+// bestIndex is a max-heap of the arms by their mean, so that with IndexBest
+// the exploit arm and the runner-up of Explain are found in constant time and
+// every update moves one arm in logarithmic time. Ties go to the lower index,
+// and unplayed arms rank last unless raw, as with maxMean, so both heaps order
+// the played arms alike. It is guarded by the lock of the bandit, and rebuilt
+// on the next selection once invalidated.
+type bestIndex struct {
+	raw     bool
+	counts  []int
+	rewards []float64
+	arms    []int
+	pos     []int
+	valid   bool
+}
+
+func (h *bestIndex) score(arm int) float64 {
+	if !h.raw && h.counts[arm] == 0 || math.IsNaN(h.rewards[arm]) {
+		return math.Inf(-1)
+	}
+	return h.rewards[arm]
+}
+
+func (h *bestIndex) Len() int {
+	return len(h.arms)
+}
+
+func (h *bestIndex) Less(i, j int) bool {
+	a, c := h.arms[i], h.arms[j]
+	if sa, sc := h.score(a), h.score(c); sa != sc {
+		return sa > sc
+	}
+	return a < c
+}
+
+func (h *bestIndex) Swap(i, j int) {
+	h.arms[i], h.arms[j] = h.arms[j], h.arms[i]
+	h.pos[h.arms[i]] = i
+	h.pos[h.arms[j]] = j
+}
+
+// Push and Pop are never called, since the heap is rebuilt whenever the arms
+// change
+func (h *bestIndex) Push(x any) {}
+
+func (h *bestIndex) Pop() any {
+	return nil
+}
+
+// build rebuilds the heap over the arms of the bandit when it is stale
+func (h *bestIndex) build(b *EpsilonGreedy, raw bool) {
+	if !h.stale(b) {
+		return
+	}
+	h.raw = raw
+	h.counts, h.rewards = b.Counts, b.Rewards
+	h.arms = make([]int, len(b.Rewards))
+	h.pos = make([]int, len(b.Rewards))
+	for i := range h.arms {
+		h.arms[i], h.pos[i] = i, i
+	}
+	heap.Init(h)
+	h.valid = true
+}
+
+// stale returns whether the heap was invalidated, or built over other counts
+// and rewards than those of the bandit
+func (h *bestIndex) stale(b *EpsilonGreedy) bool {
+	if !h.valid || len(h.arms) != len(b.Rewards) || len(h.arms) == 0 {
+		return true
+	}
+	return &h.counts[0] != &b.Counts[0] || &h.rewards[0] != &b.Rewards[0]
+}
+
+// cachedBest returns the arm maxMean would return, rebuilding the heap when
+// it was invalidated
+func (b *EpsilonGreedy) cachedBest() int {
+	b.bestIndex.build(b, false)
+	return b.bestIndex.arms[0]
+}
+
+// cachedRunnerUp returns the arm with the highest mean other than the arm,
+// or -1 with a single arm, rebuilding the heap when it was invalidated
+func (b *EpsilonGreedy) cachedRunnerUp(arm int) int {
+	h := &b.meanIndex
+	h.build(b, true)
+	if h.arms[0] != arm {
+		return h.arms[0]
+	}
+
+	// NOTE: The second highest arm is one of the children of the root
+	switch len(h.arms) {
+	case 1:
+		return -1
+	case 2:
+		return h.arms[1]
+	}
+	if h.Less(2, 1) {
+		return h.arms[2]
+	}
+	return h.arms[1]
+}
+
+// fixBest moves the arm within the heaps after its count or mean changed
+func (b *EpsilonGreedy) fixBest(arm int) {
+	for _, h := range []*bestIndex{&b.bestIndex, &b.meanIndex} {
+		if !h.stale(b) {
+			heap.Fix(h, h.pos[arm])
+		}
+	}
+}
+
+// invalidateBest forces the heaps to be rebuilt after the counts or means of
+// several arms changed
+func (b *EpsilonGreedy) invalidateBest() {
+	b.bestIndex.valid = false
+	b.meanIndex.valid = false
+}
@@ -0,0 +1,129 @@
+package bandit
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_IndexBest(t *testing.T) {
+	assert := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	b, _ := NewEpsilonGreedy(0, nil, nil)
+	assert.Nil(b.Init(20))
+	b.IndexBest = true
+
+	for i := 0; i < 2000; i++ {
+		nArms := len(b.Rewards)
+		switch op := r.Intn(100); {
+		case op < 80:
+			// NOTE: Coarse rewards make ties between the arms common
+			assert.Nil(b.Update(r.Intn(nArms), float64(r.Intn(3))/2))
+		case op < 85:
+			assert.Nil(b.RecordPull(r.Intn(nArms)))
+		case op < 90:
+			_, err := b.AddArm()
+			assert.Nil(err)
+		case op < 93:
+			if nArms > 2 {
+				assert.Nil(b.RemoveArm(r.Intn(nArms)))
+			}
+		case op < 96:
+			rewards := make([]float64, nArms)
+			for i := range rewards {
+				rewards[i] = float64(r.Intn(3)) / 2
+			}
+			assert.Nil(b.SetRewards(rewards))
+		default:
+			assert.Nil(b.Reset(1 + r.Intn(20)))
+		}
+
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		if !assert.Equal(maxMean(b.Counts, b.Rewards), arm, "should match the scan after step %d", i+1) {
+			return
+		}
+		explanation, err := b.Explain()
+		assert.Nil(err)
+		assert.Equal(b.scanRunnerUp(arm), explanation.RunnerUp, "should match the runner-up of the scan after step %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_IndexBestConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(50))
+	b.IndexBest = true
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < 250; i++ {
+				arm, err := b.SelectArm(r.Float64())
+				assert.Nil(err)
+				assert.Nil(b.Update(arm, r.Float64()))
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+
+	b.Lock()
+	defer b.Unlock()
+	assert.Equal(maxMean(b.Counts, b.Rewards), b.cachedBest(), "should stay consistent under concurrent updates")
+}
+
+// BenchmarkEpsilonGreedy_SelectArmManyArms measures an exploit and an update
+// over 10000 arms, where IndexBest typically takes about a hundredth of the
+// time of the scan
+func BenchmarkEpsilonGreedy_SelectArmManyArms(b *testing.B) {
+	for _, indexBest := range []bool{false, true} {
+		name := "scan"
+		if indexBest {
+			name = "indexed"
+		}
+		b.Run(name, func(b *testing.B) {
+			bandit, _ := NewEpsilonGreedy(0, nil, nil)
+			bandit.Init(10000)
+			bandit.IndexBest = indexBest
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 10000; i++ {
+				bandit.Update(i, r.Float64())
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				arm, _ := bandit.SelectArm(0.5)
+				bandit.Update(arm, r.Float64())
+			}
+		})
+	}
+}
+
+func TestEpsilonGreedy_IndexBestUnequalCounts(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0, []int{100, 1, 10}, []float64{0.9, 0.1, 0.5})
+	assert.Nil(err)
+	b.IndexBest = true
+
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should exploit the highest mean rather than a rarely pulled arm")
+	explanation, err := b.Explain()
+	assert.Nil(err)
+	assert.Equal(2, explanation.RunnerUp, "should agree with the runner-up of the means")
+
+	assert.Nil(b.Update(1, 1))
+	assert.Nil(b.Update(1, 1))
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(maxMean(b.Counts, b.Rewards), arm)
+}
@@ -0,0 +1,119 @@
+package bandit
+
+// BestArmWindow tracks the arm with the highest mean reward over the last
+// Window rewards of all the arms, and calls OnChange once whenever a full
+// window flips the best arm to another one, e.g. when the environment shifted
+// enough to change the winner. Ties keep the current best arm. A Window below
+// one tracks nothing. It is guarded by the lock of the bandit it belongs to.
+type BestArmWindow struct {
+	Window   int
+	OnChange func(oldArm, newArm int, evidence WindowEvidence)
+
+	arms    []int
+	rewards []float64
+	next    int
+	counts  []int
+	sums    []float64
+	best    int
+}
+
+// WindowEvidence holds the number of rewards and the mean reward of each arm
+// within the window
+type WindowEvidence struct {
+	Counts []int
+	Means  []float64
+}
+
+// observe records the reward of an arm, and returns the callback to run when
+// the best arm changes
+func (w *BestArmWindow) observe(arm, nArms int, reward float64) func() {
+	if w.Window < 1 {
+		return nil
+	}
+	if len(w.counts) != nArms {
+		w.arms = w.arms[:0]
+		w.rewards = w.rewards[:0]
+		w.next = 0
+		w.counts = make([]int, nArms)
+		w.sums = make([]float64, nArms)
+		w.best = -1
+	}
+
+	if len(w.arms) < w.Window {
+		w.arms = append(w.arms, arm)
+		w.rewards = append(w.rewards, reward)
+	} else {
+		w.counts[w.arms[w.next]]--
+		w.sums[w.arms[w.next]] -= w.rewards[w.next]
+		w.arms[w.next] = arm
+		w.rewards[w.next] = reward
+	}
+	w.next = (w.next + 1) % w.Window
+	w.counts[arm]++
+	w.sums[arm] += reward
+	if len(w.arms) < w.Window {
+		return nil
+	}
+
+	evidence := w.evidence()
+	best := w.best
+	for i, count := range evidence.Counts {
+		if count == 0 {
+			continue
+		}
+		if best < 0 || evidence.Counts[best] == 0 || evidence.Means[i] > evidence.Means[best] {
+			best = i
+		}
+	}
+	old := w.best
+	w.best = best
+	if old < 0 || old == best || w.OnChange == nil {
+		return nil
+	}
+
+	onChange := w.OnChange
+	return func() {
+		onChange(old, best, evidence)
+	}
+}
+
+// evidence returns the counts and means of the arms within the window
+func (w *BestArmWindow) evidence() WindowEvidence {
+	evidence := WindowEvidence{
+		Counts: make([]int, len(w.counts)),
+		Means:  make([]float64, len(w.counts)),
+	}
+	copy(evidence.Counts, w.counts)
+	for i, count := range w.counts {
+		if count > 0 {
+			evidence.Means[i] = w.sums[i] / float64(count)
+		}
+	}
+	return evidence
+}
+
+// WindowBestArm returns the arm with the highest mean reward over the window
+// of BestWindow, or -1 without a BestWindow, without a window or before its
+// window fills
+func (b *EpsilonGreedy) WindowBestArm() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.BestWindow == nil || b.BestWindow.Window < 1 || len(b.BestWindow.counts) != len(b.Rewards) {
+		return -1
+	}
+	return b.BestWindow.best
+}
+
+// NewBestArmWindow returns a pointer to the BestArmWindow struct
+func NewBestArmWindow(window int, onChange func(oldArm, newArm int, evidence WindowEvidence)) (*BestArmWindow, error) {
+	if window < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &BestArmWindow{
+		Window:   window,
+		OnChange: onChange,
+		best:     -1,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBestArmWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewBestArmWindow(0, nil)
+	assert.Equal(ErrInvalidSize, err)
+	w, err := NewBestArmWindow(10, nil)
+	assert.Nil(err)
+	assert.Equal(10, w.Window)
+}
+
+func TestEpsilonGreedy_UpdateWithBestWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	assert.Equal(-1, b.WindowBestArm(), "should have no best arm without a window")
+
+	type change struct {
+		oldArm, newArm int
+		evidence       WindowEvidence
+	}
+	var changes []change
+	var err error
+	b.BestWindow, err = NewBestArmWindow(10, func(oldArm, newArm int, evidence WindowEvidence) {
+		assert.Equal(1, b.WindowBestArm(), "should call back without the lock")
+		changes = append(changes, change{oldArm, newArm, evidence})
+	})
+	assert.Nil(err)
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(b.Update(0, 0.9))
+		assert.Nil(b.Update(1, 0.1))
+	}
+	assert.Equal(0, b.WindowBestArm())
+	assert.Empty(changes, "should not call back for the first best arm")
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(b.Update(0, 0.1))
+		assert.Nil(b.Update(1, 0.9))
+	}
+	assert.Equal(1, b.WindowBestArm(), "should flip the best arm with the rewards")
+	assert.Len(changes, 1, "should call back once")
+	assert.Equal(0, changes[0].oldArm)
+	assert.Equal(1, changes[0].newArm)
+	assert.Equal([]int{5, 5}, changes[0].evidence.Counts)
+	assert.Greater(changes[0].evidence.Means[1], changes[0].evidence.Means[0], "should carry the evidence")
+}
+
+func TestEpsilonGreedy_BestWindowZeroWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	var changes int
+	b.BestWindow = &BestArmWindow{OnChange: func(int, int, WindowEvidence) { changes++ }}
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(b.Update(i%2, float64(i%2)))
+	}
+	assert.Equal(-1, b.WindowBestArm(), "should track nothing without a window")
+	assert.Equal(0, changes)
+}
@@ -0,0 +1,144 @@
+package bandit
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+)
+
+// binaryVersion is the version of the compact binary state, written as its
+// first byte
+const binaryVersion = 1
+
+// MaxExportArms is the maximum number of arms of the state exported by
+// ExportString. Each arm takes 18 to 26 bytes, or at most 35 characters once
+// base64 encoded, so the limit keeps the string under 36KiB, well within the
+// 128KiB limit of a single environment variable on Linux.
+const MaxExportArms = 1024
+
+// MarshalBinary encodes the epsilon and the counts, means, observations and
+// variances of the arms in a compact binary form under the read lock. The
+// other fields, e.g. the confidence weights or the disabled arms, are left
+// out, and are kept by MarshalJSON.
+func (b *EpsilonGreedy) MarshalBinary() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.marshalBinary(), nil
+}
+
+// marshalBinary encodes the state under the lock held by the caller
+func (b *EpsilonGreedy) marshalBinary() []byte {
+	data := make([]byte, 0, 1+8+binary.MaxVarintLen64+len(b.Rewards)*20)
+	data = append(data, binaryVersion)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(b.Epsilon))
+	data = binary.AppendUvarint(data, uint64(len(b.Rewards)))
+	for i := range b.Rewards {
+		var m2 float64
+		if len(b.M2) == len(b.Rewards) {
+			m2 = b.M2[i]
+		}
+		data = binary.AppendUvarint(data, uint64(b.Counts[i]))
+		data = binary.AppendUvarint(data, uint64(b.observations(i)))
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(b.Rewards[i]))
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(m2))
+	}
+	return data
+}
+
+// UnmarshalBinary decodes a state encoded by MarshalBinary, replacing the
+// epsilon and the arms, and starting the rest of the per-arm state over like
+// Reset. Truncated or corrupt data is rejected with ErrInvalidEncoding, and the
+// bandit is left unchanged.
+func (b *EpsilonGreedy) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidEncoding
+	}
+	if data[0] != binaryVersion {
+		return ErrUnsupportedVersion
+	}
+	data = data[1:]
+
+	readFloat := func() (float64, bool) {
+		if len(data) < 8 {
+			return 0, false
+		}
+		value := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		return value, true
+	}
+	readInt := func() (int, bool) {
+		value, n := binary.Uvarint(data)
+		if n <= 0 || value > math.MaxInt32 {
+			return 0, false
+		}
+		data = data[n:]
+		return int(value), true
+	}
+
+	epsilon, ok := readFloat()
+	if !ok || !(epsilon >= 0 && epsilon <= 1) {
+		return ErrInvalidEncoding
+	}
+	nArms, ok := readInt()
+	// NOTE: Each arm takes at least 18 bytes, which bounds the allocations
+	// for a corrupt number of arms
+	if !ok || nArms > len(data)/18 {
+		return ErrInvalidEncoding
+	}
+
+	counts := make([]int, nArms)
+	observations := make([]int, nArms)
+	rewards := make([]float64, nArms)
+	m2 := make([]float64, nArms)
+	for i := 0; i < nArms; i++ {
+		var okCount, okObservations, okReward, okM2 bool
+		counts[i], okCount = readInt()
+		observations[i], okObservations = readInt()
+		rewards[i], okReward = readFloat()
+		m2[i], okM2 = readFloat()
+		if !okCount || !okObservations || !okReward || !okM2 {
+			return ErrInvalidEncoding
+		}
+	}
+	if len(data) != 0 {
+		return ErrInvalidEncoding
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	// NOTE: The per-arm state the format does not carry, e.g. the aliases and
+	// the confidence weights, would not match the decoded arms
+	if err := b.reset(len(counts)); err != nil {
+		return err
+	}
+	b.Epsilon = epsilon
+	b.Counts = counts
+	b.Observations = observations
+	b.Rewards = rewards
+	b.M2 = m2
+	return nil
+}
+
+// ExportString returns the binary state of MarshalBinary encoded as base64,
+// e.g. to pass a small state through an environment variable. Bandits with
+// more than MaxExportArms arms are rejected with ErrStateTooLarge.
+func (b *EpsilonGreedy) ExportString() (string, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(b.Rewards) > MaxExportArms {
+		return "", ErrStateTooLarge
+	}
+	return base64.StdEncoding.EncodeToString(b.marshalBinary()), nil
+}
+
+// ImportString restores a state returned by ExportString
+func (b *EpsilonGreedy) ImportString(s string) error {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return ErrInvalidEncoding
+	}
+	return b.UnmarshalBinary(data)
+}
@@ -0,0 +1,91 @@
+package bandit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ExportString(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.2, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	b.StableMean = true
+	for i, reward := range []float64{0.5, 1, 0.25, 0.75, 0} {
+		assert.Nil(b.Update(i%3, reward))
+	}
+	assert.Nil(b.RecordPull(2))
+
+	s, err := b.ExportString()
+	assert.Nil(err)
+	assert.False(strings.ContainsAny(s, " \n"), "should fit a single environment variable")
+
+	restored := &EpsilonGreedy{StableMean: true}
+	assert.Nil(restored.ImportString(s))
+	assert.Equal(0.2, restored.Epsilon)
+	assert.Equal(b.GetCounts(), restored.GetCounts(), "should restore the counts")
+	assert.Equal(b.GetRewards(), restored.GetRewards(), "should restore the means")
+	assert.Equal(b.Observations, restored.Observations, "should restore the observations")
+	variances, _ := b.GetVariances()
+	restoredVariances, _ := restored.GetVariances()
+	assert.Equal(variances, restoredVariances, "should restore the variances")
+
+	large, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(large.Init(MaxExportArms + 1))
+	_, err = large.ExportString()
+	assert.Equal(ErrStateTooLarge, err)
+}
+
+func TestEpsilonGreedy_UnmarshalBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, []int{1, 2}, []float64{0.5, 0.25})
+	data, err := b.MarshalBinary()
+	assert.Nil(err)
+
+	tests := []struct {
+		data []byte
+		err  error
+	}{
+		{data, nil},
+		{nil, ErrInvalidEncoding},
+		{data[:len(data)-1], ErrInvalidEncoding},
+		{append(append([]byte(nil), data...), 0), ErrInvalidEncoding},
+		{append([]byte{2}, data[1:]...), ErrUnsupportedVersion},
+	}
+
+	for i, tt := range tests {
+		restored := &EpsilonGreedy{}
+		assert.Equal(tt.err, restored.UnmarshalBinary(tt.data), "should throw the correct error for test %d", i+1)
+	}
+
+	restored := &EpsilonGreedy{}
+	assert.Equal(ErrInvalidEncoding, restored.ImportString("not base64!"))
+	assert.Empty(restored.GetCounts(), "should leave the bandit unchanged")
+}
+
+func TestEpsilonGreedy_UnmarshalBinaryResets(t *testing.T) {
+	assert := assert.New(t)
+
+	fresh, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(fresh.Init(2))
+	data, err := fresh.MarshalBinary()
+	assert.Nil(err)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(5))
+	assert.Nil(b.AliasArm(0, 4))
+	for i := 0; i < 50; i++ {
+		assert.Nil(b.UpdateWithConfidence(1, 0, 1))
+	}
+
+	assert.Nil(b.UnmarshalBinary(data))
+	assert.Nil(b.Update(0, 1), "should drop the aliases of the old arms")
+	assert.Nil(b.Update(1, 1))
+	assert.Equal([]float64{1, 1}, b.GetRewards(), "should drop the weights of the old arms")
+}
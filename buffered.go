@@ -0,0 +1,336 @@
+package bandit
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// bufferedUpdate is an update held by a BufferedUpdater until it is flushed
+type bufferedUpdate struct {
+	arm    int
+	reward float64
+}
+
+// batchBandit is a bandit that applies many updates under a single lock,
+// e.g. EpsilonGreedy
+type batchBandit interface {
+	applyUpdates(updates []bufferedUpdate) error
+}
+
+// updateValidator is a bandit that validates an update without applying it,
+// e.g. EpsilonGreedy
+type updateValidator interface {
+	validateUpdate(arm int, reward float64) error
+}
+
+// defaultBufferSize is the number of buffered updates after which a
+// BufferedUpdater created without NewBufferedUpdater flushes them
+const defaultBufferSize = 64
+
+// minShardUpdates is the smallest share of the updates a shard buffers, so
+// that small buffers use fewer shards rather than flushing on every update
+const minShardUpdates = 4
+
+// BufferedUpdater wraps a bandit, and buffers the updates in shards, up to
+// one per CPU, each with its own lock, instead of locking the bandit for each
+// of them, e.g. for write-heavy services that accept slightly stale estimates
+// for throughput. A shard is flushed into the bandit in bulk once it holds its
+// share of Size updates, and every shard every Interval while Run is running,
+// and on Flush. SelectArm, GetCounts and GetRewards see the bandit as of the
+// last flush, so they lag behind by at most Size updates, or by Interval when
+// the updates are fewer. A BufferedUpdater created without NewBufferedUpdater
+// flushes every 64 updates.
+type BufferedUpdater struct {
+	Bandit   Bandit
+	Interval time.Duration
+
+	// ticks replaces the ticker of the interval in tests
+	ticks <-chan time.Time
+
+	once   sync.Once
+	size   int
+	shards []updateShard
+}
+
+// updateShard holds a share of the buffered updates
+type updateShard struct {
+	sync.Mutex
+	updates []bufferedUpdate
+
+	// NOTE: The padding keeps the shards on separate cache lines, so that
+	// goroutines updating on different CPUs do not contend
+	_ [64]byte
+}
+
+// Init will initialise the bandit with the provided number of arms, starting it
+// from scratch when it can start over, e.g. EpsilonGreedy, and dropping the
+// buffered updates
+func (u *BufferedUpdater) Init(nArms int) error {
+	u.ensureShards()
+	for i := range u.shards {
+		u.shards[i].take()
+	}
+	return resetBandit(u.Bandit, nArms)
+}
+
+// SelectArm selects an arm with the bandit as of the last flush
+func (u *BufferedUpdater) SelectArm(probability float64) (int, error) {
+	return u.Bandit.SelectArm(probability)
+}
+
+// Update validates the update against the bandit, buffers it in a random
+// shard, and flushes the shard when it is full. It returns only the error of
+// its own update: an update that the bandit rejects once it is flushed, e.g.
+// after the bandit froze, is dropped, and reported by Flush alone.
+func (u *BufferedUpdater) Update(chosenArm int, reward float64) error {
+	if err := u.validate(chosenArm, reward); err != nil {
+		return err
+	}
+
+	u.ensureShards()
+	// NOTE: The top-level source of math/rand does not lock
+	shard := &u.shards[rand.Intn(len(u.shards))]
+
+	shard.Lock()
+	shard.updates = append(shard.updates, bufferedUpdate{arm: chosenArm, reward: reward})
+	var updates []bufferedUpdate
+	if len(shard.updates) >= u.shardSize() {
+		updates = shard.updates
+		shard.updates = make([]bufferedUpdate, 0, u.shardSize())
+	}
+	shard.Unlock()
+
+	// NOTE: The updates flushed along with this one were validated when they
+	// were buffered, so their late errors do not belong to this caller
+	_ = u.apply(updates)
+	return nil
+}
+
+// validate returns the error the bandit would return for the update, as far
+// as it can be told before the update is applied
+func (u *BufferedUpdater) validate(chosenArm int, reward float64) error {
+	return validateBanditUpdate(u.Bandit, chosenArm, reward)
+}
+
+// validateBanditUpdate returns the error the bandit would return for the
+// update, as far as it can be told before the update is applied
+func validateBanditUpdate(b Bandit, chosenArm int, reward float64) error {
+	if v, ok := b.(updateValidator); ok {
+		return v.validateUpdate(chosenArm, reward)
+	}
+	nArms := len(b.GetCounts())
+	if nArms == 0 {
+		return ErrNotInitialized
+	}
+	if chosenArm < 0 || chosenArm >= nArms {
+		return &ArmIndexError{Index: chosenArm, NumArms: nArms}
+	}
+	return validateReward(reward, true)
+}
+
+// ensureShards creates the shards of a BufferedUpdater created without
+// NewBufferedUpdater
+func (u *BufferedUpdater) ensureShards() {
+	u.once.Do(func() {
+		if u.shards != nil {
+			return
+		}
+		if u.size < 1 {
+			u.size = defaultBufferSize
+		}
+		u.shards = make([]updateShard, shardCount(u.size))
+	})
+}
+
+// shardCount returns the number of shards buffering size updates, one per
+// CPU, but few enough that each buffers at least minShardUpdates of them
+func shardCount(size int) int {
+	shards := runtime.GOMAXPROCS(0)
+	if limit := size / minShardUpdates; shards > limit {
+		shards = limit
+	}
+	if shards < 1 {
+		return 1
+	}
+	return shards
+}
+
+// shardSize returns the number of updates flushing a shard
+func (u *BufferedUpdater) shardSize() int {
+	return (u.size + len(u.shards) - 1) / len(u.shards)
+}
+
+// Flush applies every buffered update to the bandit, under a single lock when
+// the bandit supports it, e.g. EpsilonGreedy, and returns the errors of the
+// invalid ones joined
+func (u *BufferedUpdater) Flush() error {
+	u.ensureShards()
+	var updates []bufferedUpdate
+	for i := range u.shards {
+		updates = append(updates, u.shards[i].take()...)
+	}
+	return u.apply(updates)
+}
+
+// take takes the updates out of the shard
+func (s *updateShard) take() []bufferedUpdate {
+	s.Lock()
+	defer s.Unlock()
+
+	updates := s.updates
+	s.updates = nil
+	return updates
+}
+
+// apply applies the updates to the bandit
+func (u *BufferedUpdater) apply(updates []bufferedUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if b, ok := u.Bandit.(batchBandit); ok {
+		return b.applyUpdates(updates)
+	}
+	var errs []error
+	for _, update := range updates {
+		if err := u.Bandit.Update(update.arm, update.reward); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Pending returns the number of buffered updates
+func (u *BufferedUpdater) Pending() int {
+	u.ensureShards()
+	n := 0
+	for i := range u.shards {
+		u.shards[i].Lock()
+		n += len(u.shards[i].updates)
+		u.shards[i].Unlock()
+	}
+	return n
+}
+
+// Size returns the number of buffered updates after which they are flushed
+func (u *BufferedUpdater) Size() int {
+	u.ensureShards()
+	return u.size
+}
+
+// Run flushes the buffer every interval until the context is done, and then
+// flushes it a final time. The errors of the periodic flushes are dropped,
+// since their updates were already accepted. The Interval must be greater
+// than zero.
+func (u *BufferedUpdater) Run(ctx context.Context) error {
+	ticks := u.ticks
+	if ticks == nil {
+		if u.Interval <= 0 {
+			return ErrInvalidDuration
+		}
+		ticker := time.NewTicker(u.Interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-ticks:
+			_ = u.Flush()
+		case <-ctx.Done():
+			return u.Flush()
+		}
+	}
+}
+
+// GetCounts returns the counts of the bandit as of the last flush
+func (u *BufferedUpdater) GetCounts() []int {
+	return u.Bandit.GetCounts()
+}
+
+// GetRewards returns the rewards of the bandit as of the last flush
+func (u *BufferedUpdater) GetRewards() []float64 {
+	return u.Bandit.GetRewards()
+}
+
+// validateUpdate returns the error Update would return for the update under
+// the current state, without applying it
+func (b *EpsilonGreedy) validateUpdate(chosenArm int, reward float64) error {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.initialized() {
+		return ErrNotInitialized
+	}
+	if b.Frozen {
+		return ErrFrozen
+	}
+	if b.RewardTransform != nil {
+		reward = b.RewardTransform(reward)
+	}
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	return validateReward(reward, b.SignedRewards)
+}
+
+// applyUpdates applies the updates under a single lock, and logs them and
+// runs their callbacks once it is released
+func (b *EpsilonGreedy) applyUpdates(updates []bufferedUpdate) error {
+	var callbacks []func()
+	var errs []error
+	var applied []bufferedUpdate
+
+	b.Lock()
+	logger := b.logger
+	for _, update := range updates {
+		updateCallbacks, err := b.update(update.arm, update.reward)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		callbacks = append(callbacks, updateCallbacks...)
+		if logger != nil {
+			applied = append(applied, update)
+		}
+	}
+	b.Unlock()
+
+	for _, update := range applied {
+		logger.Debug("bandit: update", "arm", update.arm, "reward", update.reward)
+	}
+	// NOTE: Callbacks run without the lock so they can call back into the
+	// bandit
+	for _, callback := range callbacks {
+		callback()
+	}
+	return errors.Join(errs...)
+}
+
+// NewBufferedUpdater returns a pointer to the BufferedUpdater struct, flushing
+// its updates into b once size of them are buffered, or every interval while
+// Run is running
+func NewBufferedUpdater(b Bandit, size int, interval time.Duration) (*BufferedUpdater, error) {
+	if size < 1 {
+		return nil, ErrInvalidSize
+	}
+	if interval <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	return newBufferedUpdater(b, size, interval, shardCount(size)), nil
+}
+
+// newBufferedUpdater returns a BufferedUpdater with the number of shards, e.g.
+// to test it independently of the number of CPUs
+func newBufferedUpdater(b Bandit, size int, interval time.Duration, shards int) *BufferedUpdater {
+	return &BufferedUpdater{
+		Bandit:   b,
+		Interval: interval,
+		size:     size,
+		shards:   make([]updateShard, shards),
+	}
+}
@@ -0,0 +1,158 @@
+package bandit
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBufferedUpdater(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		size     int
+		interval time.Duration
+		err      error
+	}{
+		{10, time.Second, nil},
+		{0, time.Second, ErrInvalidSize},
+		{10, 0, ErrInvalidDuration},
+	}
+
+	for i, tt := range tests {
+		b, _ := NewEpsilonGreedy(0.1, nil, nil)
+		_, err := NewBufferedUpdater(b, tt.size, tt.interval)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestBufferedUpdater_Flush(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	u := newBufferedUpdater(b, 4, time.Hour, 1)
+	assert.Nil(u.Init(2))
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(u.Update(0, 1))
+	}
+	assert.Equal(3, u.Pending())
+	assert.Equal([]int{0, 0}, u.GetCounts(), "should not apply the buffered updates yet")
+
+	assert.Nil(u.Update(1, 0), "should flush the full buffer")
+	assert.Equal([]int{3, 1}, u.GetCounts(), "should apply the flushed updates")
+	assert.Equal(0, u.Pending())
+
+	err := u.Update(2, 1)
+	assert.True(errors.Is(err, ErrArmsIndexOutOfRange), "should reject an invalid update when buffered")
+	assert.Equal(ErrInvalidReward, u.Update(0, -1))
+	assert.Equal(0, u.Pending(), "should not buffer the invalid updates")
+
+	assert.Nil(u.Update(1, 1))
+	assert.Nil(u.Flush())
+	assert.Equal([]int{3, 2}, u.GetCounts())
+	assert.Equal([]float64{1, 0.5}, u.GetRewards())
+}
+
+func TestBufferedUpdater_LateErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	u := newBufferedUpdater(b, 2, time.Hour, 1)
+
+	assert.Nil(u.Update(0, 1))
+	b.Frozen = true
+	assert.Equal(ErrFrozen, u.Update(0, 1), "should return the error of its own update")
+	b.Frozen = false
+	assert.Nil(u.Update(1, 1))
+	assert.Equal([]int{1, 1}, b.GetCounts())
+
+	assert.Nil(u.Update(0, 1))
+	b.Frozen = true
+	assert.True(errors.Is(u.Flush(), ErrFrozen), "should report the late error on Flush")
+}
+
+func TestBufferedUpdater_Shards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	u, err := NewBufferedUpdater(b, 4, time.Hour)
+	assert.Nil(err)
+	for i := 0; i < 3; i++ {
+		assert.Nil(u.Update(0, 1))
+	}
+	assert.Equal(3, u.Pending(), "should buffer a small size on any number of CPUs")
+
+	tests := []struct {
+		size     int
+		expected int
+	}{
+		{1, 1},
+		{4, 1},
+		{7, 1},
+		{1000000, runtime.GOMAXPROCS(0)},
+	}
+
+	for i, tt := range tests {
+		assert.Equal(tt.expected, shardCount(tt.size), "should count the shards for test %d", i+1)
+	}
+}
+
+func TestBufferedUpdater_ZeroValue(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	u := &BufferedUpdater{Bandit: b}
+
+	assert.Nil(u.Update(0, 1))
+	assert.Equal(defaultBufferSize, u.Size(), "should default the size")
+	assert.Equal(1, u.Pending())
+	assert.Nil(u.Flush())
+	assert.Equal([]int{1, 0}, b.GetCounts())
+	assert.Equal(ErrInvalidDuration, u.Run(context.Background()))
+}
+
+func TestBufferedUpdater_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(4))
+	u, err := NewBufferedUpdater(b, 16, time.Hour)
+	assert.Nil(err)
+	ticks := make(chan time.Time)
+	u.ticks = ticks
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- u.Run(ctx) }()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				assert.Nil(u.Update((g+i)%4, 1))
+				if i%100 == 0 {
+					_, err := u.SelectArm(0.5)
+					assert.Nil(err)
+				}
+			}
+		}(g)
+	}
+	ticks <- time.Now()
+	wg.Wait()
+	cancel()
+	assert.Nil(<-done)
+
+	assert.Equal(0, u.Pending())
+	assert.Equal([]int{2000, 2000, 2000, 2000}, b.GetCounts(), "should eventually apply every update")
+}
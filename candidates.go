@@ -0,0 +1,59 @@
+package bandit
+
+import "slices"
+
+// SelectArmFrom chooses an arm out of the candidates, e.g. the arms in stock
+// for a request, and never selects outside them. Exploitation selects the
+// candidate with the best mean, and exploration draws among the candidates.
+// Disabled candidates are skipped and aliased candidates stand for their
+// canonical index, while the cooldown is ignored since the caller picked the
+// candidates.
+func (b *EpsilonGreedy) SelectArmFrom(candidates []int) (int, error) {
+	b.Lock()
+	d, err := b.selectArmFrom(candidates)
+	if err == nil {
+		b.record(d)
+	}
+	logger := b.logger
+	b.Unlock()
+
+	return selected(logger, d, err)
+}
+
+func (b *EpsilonGreedy) selectArmFrom(candidates []int) (decision, error) {
+	if !b.initialized() {
+		return decision{}, ErrNotInitialized
+	}
+	if len(candidates) == 0 {
+		return decision{}, ErrNoEligibleArms
+	}
+
+	arms := make([]int, 0, len(candidates))
+	for _, arm := range candidates {
+		if arm < 0 || arm >= len(b.Rewards) {
+			return decision{}, &ArmIndexError{Index: arm, NumArms: len(b.Rewards)}
+		}
+		arm = b.canonical(arm)
+		if len(b.Disabled) == len(b.Rewards) && b.Disabled[arm] || slices.Contains(arms, arm) {
+			continue
+		}
+		arms = append(arms, arm)
+	}
+	if len(arms) == 0 {
+		return decision{}, ErrNoEligibleArms
+	}
+
+	if err := b.loadPrior(); err != nil {
+		return decision{}, err
+	}
+	epsilon := b.epsilon()
+	best := b.bestArm(arms)
+	ties := b.ties(best, arms)
+	if epsilon == 0 || b.float64() > epsilon {
+		arm := b.exploitArm(best, ties)
+		return decision{arm: arm, epsilon: epsilon, propensity: propensity(exploitShare(arm, best, ties), b.exploreShare(arm, arms), epsilon)}, nil
+	}
+
+	arm := b.explore(arms)
+	return decision{arm: arm, explored: true, epsilon: epsilon, propensity: propensity(exploitShare(arm, best, ties), b.exploreShare(arm, arms), epsilon)}, nil
+}
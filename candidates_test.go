@@ -0,0 +1,73 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SelectArmFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name       string
+		epsilon    float64
+		rand       Rand
+		candidates []int
+		arm        int
+		err        error
+	}{
+		{"exploit all", 0.0, nil, []int{0, 1, 2, 3}, 3, nil},
+		{"exploit subset", 0.0, nil, []int{0, 2}, 2, nil},
+		{"exploit single", 0.0, nil, []int{1}, 1, nil},
+		{"exploit duplicates", 0.0, nil, []int{1, 1, 0}, 1, nil},
+		{"explore first", 1.0, fixedRand{0}, []int{2, 0}, 2, nil},
+		{"explore second", 1.0, fixedRand{1}, []int{2, 0}, 0, nil},
+		{"empty", 0.0, nil, []int{}, -1, ErrNoEligibleArms},
+		{"nil", 0.0, nil, nil, -1, ErrNoEligibleArms},
+		{"out of range", 0.0, nil, []int{0, 4}, -1, ErrArmsIndexOutOfRange},
+		{"negative", 0.0, nil, []int{-1}, -1, ErrArmsIndexOutOfRange},
+	}
+
+	for _, test := range tests {
+		b, _ := NewEpsilonGreedy(test.epsilon, []int{1, 1, 1, 1}, []float64{0.1, 0.2, 0.3, 0.4})
+		b.Rand = test.rand
+		arm, err := b.SelectArmFrom(test.candidates)
+		assert.True(errors.Is(err, test.err), test.name)
+		assert.Equal(test.arm, arm, test.name)
+	}
+}
+
+func TestEpsilonGreedy_SelectArmFromStaysInCandidates(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.5, []int{1, 1, 1, 1}, []float64{0.1, 0.2, 0.3, 0.4})
+	for i := 0; i < 200; i++ {
+		arm, err := b.SelectArmFrom([]int{0, 1})
+		assert.Nil(err)
+		assert.Contains([]int{0, 1}, arm)
+	}
+	assert.Equal(200, b.GetSelectionCount())
+}
+
+func TestEpsilonGreedy_SelectArmFromDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.0, []int{1, 1, 1, 1}, []float64{0.1, 0.2, 0.3, 0.4})
+	assert.Nil(b.Disable(3))
+	arm, err := b.SelectArmFrom([]int{1, 3})
+	assert.Nil(err)
+	assert.Equal(1, arm, "should skip disabled candidates")
+
+	_, err = b.SelectArmFrom([]int{3})
+	assert.Equal(ErrNoEligibleArms, err)
+
+	assert.Nil(b.AliasArm(0, 2))
+	arm, err = b.SelectArmFrom([]int{0})
+	assert.Nil(err)
+	assert.Equal(2, arm, "should select the canonical index of an aliased candidate")
+
+	_, err = (&EpsilonGreedy{}).SelectArmFrom([]int{0})
+	assert.Equal(ErrNotInitialized, err)
+}
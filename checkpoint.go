@@ -0,0 +1,78 @@
+package bandit
+
+// Checkpoint is an immutable deep copy of the state of an EpsilonGreedy,
+// taken by CheckpointState, e.g. before a backfill that may need to be rolled
+// back
+type Checkpoint struct {
+	state *EpsilonGreedy
+}
+
+// CheckpointState returns a checkpoint of the state, taken under the read
+// lock. Later updates do not change the checkpoint.
+func (b *EpsilonGreedy) CheckpointState() Checkpoint {
+	return Checkpoint{state: b.Clone()}
+}
+
+// RestoreCheckpoint rolls the state back to the checkpoint under the write
+// lock. The hooks, Schedule, Rand and InstanceID in use are kept, as are the
+// Reservoir, Drift detector, best arm window, realized reward quantiles, feed
+// Watchdog, distinct rewards, Winsorizer, Credit windows and Trace, which are
+// left out of the checkpoint like with Clone. The event IDs seen by UpdateOnce
+// are rolled back too, so that rolled back updates can be applied again. The
+// checkpoint can be restored again.
+func (b *EpsilonGreedy) RestoreCheckpoint(c Checkpoint) error {
+	if c.state == nil {
+		return ErrInvalidCheckpoint
+	}
+	s := c.state.Clone()
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.Epsilon = s.Epsilon
+	b.Counts = s.Counts
+	b.Rewards = s.Rewards
+	b.Observations = s.Observations
+	b.M2 = s.M2
+	b.M2Counts = s.M2Counts
+	b.Weights = s.Weights
+	b.SquaredWeights = s.SquaredWeights
+	b.Precisions = s.Precisions
+	b.PrecisionCounts = s.PrecisionCounts
+	b.StableMean = s.StableMean
+	b.AnchorWeight = s.AnchorWeight
+	b.IndexBest = s.IndexBest
+	b.TieBreak = s.TieBreak
+	b.SignedRewards = s.SignedRewards
+	b.Disabled = s.Disabled
+	b.Aliases = s.Aliases
+	b.JitterFraction = s.JitterFraction
+	b.Frozen = s.Frozen
+	b.MinPulls = s.MinPulls
+	b.SelectionCount = s.SelectionCount
+	b.ObjectiveWeights = s.ObjectiveWeights
+	b.ObjectiveMeans = s.ObjectiveMeans
+	b.ObjectiveCounts = s.ObjectiveCounts
+	b.Cooldown = s.Cooldown
+	b.CooldownUntil = s.CooldownUntil
+	b.Costs = s.Costs
+	b.PositionBias = s.PositionBias
+	b.SimilarityMatrix = s.SimilarityMatrix
+	b.Smoothing = s.Smoothing
+	b.Smoothed = s.Smoothed
+	b.BackoffFactor = s.BackoffFactor
+	b.Laplace = s.Laplace
+	b.ExploreFloor = s.ExploreFloor
+	b.ExploitBonus = s.ExploitBonus
+	b.ZeroStreaks = s.ZeroStreaks
+	b.BestArmSamples = s.BestArmSamples
+	b.DedupWindow = s.DedupWindow
+	b.ReportEvery = s.ReportEvery
+	b.Targets = s.Targets
+	b.Earned = s.Earned
+	b.DefaultArm = s.DefaultArm
+	b.UseDefaultArm = s.UseDefaultArm
+	b.seen = s.seen
+	b.invalidateBest()
+	return nil
+}
@@ -0,0 +1,42 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_RestoreCheckpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(3))
+	b.StableMean = true
+	for i := 0; i < 30; i++ {
+		arm, err := b.SelectArm(float64(i%10) / 10)
+		assert.Nil(err)
+		assert.Nil(b.Update(arm, float64(arm)/2))
+	}
+	assert.Nil(b.Disable(1))
+	before := b.Clone()
+	checkpoint := b.CheckpointState()
+
+	// A bad backfill
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(i%3, 5))
+	}
+	_, err := b.AddArm()
+	assert.Nil(err)
+	assert.Nil(b.Enable(1))
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+
+	assert.Nil(b.RestoreCheckpoint(checkpoint))
+	assert.Equal(before, b.Clone(), "should restore the exact state")
+
+	assert.Nil(b.Update(0, 1))
+	assert.Nil(b.RestoreCheckpoint(checkpoint))
+	assert.Equal(before, b.Clone(), "should restore the checkpoint again")
+
+	assert.Equal(ErrInvalidCheckpoint, b.RestoreCheckpoint(Checkpoint{}))
+}
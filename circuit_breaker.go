@@ -0,0 +1,115 @@
+package bandit
+
+import "sync"
+
+// CircuitBreaker wraps a bandit, and falls back to selecting the arms
+// uniformly at random while the mean of the last Window rewards is below the
+// Floor, e.g. when the policy has gone haywire or the environment collapsed.
+// It returns to the bandit once the recent rewards recover, and never trips
+// with a Window below one. The bandit keeps learning from every reward.
+type CircuitBreaker struct {
+	sync.RWMutex
+	Bandit Bandit
+	Floor  float64
+	Window int
+
+	// Rand is used for the fallback, and defaults to the math/rand source
+	Rand Rand
+
+	recent   []float64
+	next     int
+	fallback bool
+}
+
+// Init will initialise the bandit with the provided number of arms, starting it
+// from scratch when it can start over, e.g. EpsilonGreedy, and close the
+// circuit
+func (c *CircuitBreaker) Init(nArms int) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := resetBandit(c.Bandit, nArms); err != nil {
+		return err
+	}
+	c.recent = nil
+	c.next = 0
+	c.fallback = false
+	return nil
+}
+
+// SelectArm chooses an arm with the bandit, or uniformly at random during the
+// fallback
+func (c *CircuitBreaker) SelectArm(probability float64) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.fallback {
+		return c.Bandit.SelectArm(probability)
+	}
+
+	nArms := len(c.Bandit.GetCounts())
+	if nArms == 0 {
+		return -1, ErrNotInitialized
+	}
+	return randIntn(c.Rand, nArms), nil
+}
+
+// Update will update the bandit with some reward value, and trip or reset the
+// circuit from the recent rewards
+func (c *CircuitBreaker) Update(chosenArm int, reward float64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if err := c.Bandit.Update(chosenArm, reward); err != nil {
+		return err
+	}
+	if c.Window < 1 {
+		return nil
+	}
+
+	if len(c.recent) < c.Window {
+		c.recent = append(c.recent, reward)
+	} else {
+		c.recent[c.next] = reward
+	}
+	c.next = (c.next + 1) % c.Window
+	if len(c.recent) == c.Window {
+		c.fallback = sumFloat64(c.recent...)/float64(c.Window) < c.Floor
+	}
+	return nil
+}
+
+// IsFallback returns whether the arms are selected uniformly at random
+func (c *CircuitBreaker) IsFallback() bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.fallback
+}
+
+// GetCounts returns the counts of the bandit
+func (c *CircuitBreaker) GetCounts() []int {
+	return c.Bandit.GetCounts()
+}
+
+// GetRewards returns the rewards of the bandit
+func (c *CircuitBreaker) GetRewards() []float64 {
+	return c.Bandit.GetRewards()
+}
+
+// NewCircuitBreaker returns a pointer to the CircuitBreaker struct, falling
+// back when the mean of the last window rewards of b is below the floor
+func NewCircuitBreaker(b Bandit, floor float64, window int) (*CircuitBreaker, error) {
+	if floor < 0 {
+		return nil, ErrInvalidReward
+	}
+	if window < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &CircuitBreaker{
+		Bandit: b,
+		Floor:  floor,
+		Window: window,
+	}, nil
+}
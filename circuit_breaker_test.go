@@ -0,0 +1,80 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	_, err := NewCircuitBreaker(b, 0.1, 10)
+	assert.Nil(err)
+	_, err = NewCircuitBreaker(b, -0.1, 10)
+	assert.Equal(ErrInvalidReward, err, "should throw error for invalid floor")
+	_, err = NewCircuitBreaker(b, 0.1, 0)
+	assert.Equal(ErrInvalidSize, err, "should throw error for invalid window")
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.0, nil, nil)
+	c, err := NewCircuitBreaker(b, 0.5, 10)
+	assert.Nil(err)
+	assert.Nil(c.Init(3))
+	c.Rand = rand.New(rand.NewSource(1))
+
+	// The greedy policy settles on the first arm once it pays
+	for i := 0; i < 20; i++ {
+		arm, err := c.SelectArm(0.5)
+		assert.Nil(err)
+		assert.Equal(0, arm)
+		assert.Nil(c.Update(arm, 1.0))
+	}
+	assert.False(c.IsFallback(), "should not fall back while rewards are above the floor")
+
+	for i := 0; i < 5; i++ {
+		assert.Nil(c.Update(0, 0.0))
+		assert.False(c.IsFallback(), "should wait for a sustained drop")
+	}
+	assert.Nil(c.Update(0, 0.0))
+	assert.True(c.IsFallback(), "should fall back once the window drops below the floor")
+	for i := 0; i < 4; i++ {
+		assert.Nil(c.Update(0, 0.0))
+	}
+
+	seen := make(map[int]bool)
+	for i := 0; i < 50; i++ {
+		arm, err := c.SelectArm(0.5)
+		assert.Nil(err)
+		seen[arm] = true
+	}
+	assert.Equal(3, len(seen), "should select uniformly during the fallback")
+
+	for i := 0; i < 4; i++ {
+		assert.Nil(c.Update(1, 1.0))
+	}
+	assert.True(c.IsFallback(), "should stay in the fallback until rewards recover")
+	assert.Nil(c.Update(1, 1.0))
+	assert.False(c.IsFallback(), "should return to the bandit once rewards recover")
+	assert.True(errors.Is(c.Update(5, 1.0), ErrArmsIndexOutOfRange))
+}
+
+func TestCircuitBreaker_ZeroWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	c := &CircuitBreaker{Bandit: b, Floor: 0.5}
+	assert.Nil(c.Init(2))
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(c.Update(i%2, 0))
+	}
+	assert.False(c.IsFallback(), "should never trip without a window")
+	assert.Equal([]int{5, 5}, c.GetCounts(), "should keep updating the bandit")
+}
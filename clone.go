@@ -0,0 +1,291 @@
+package bandit
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of the state, taken under the read lock. The
+// hooks are shared with the clone, while the Reservoir, Drift detector, best
+// arm window, realized reward quantiles, feed Watchdog, distinct rewards,
+// Winsorizer, Credit windows and Trace, which are guarded by the lock of this
+// bandit, are left out.
+func (b *EpsilonGreedy) Clone() *EpsilonGreedy {
+	b.RLock()
+	defer b.RUnlock()
+
+	var objectiveMeans map[string][]float64
+	if b.ObjectiveMeans != nil {
+		objectiveMeans = make(map[string][]float64, len(b.ObjectiveMeans))
+		for objective, means := range b.ObjectiveMeans {
+			objectiveMeans[objective] = slices.Clone(means)
+		}
+	}
+
+	return &EpsilonGreedy{
+		Epsilon:          b.Epsilon,
+		Counts:           slices.Clone(b.Counts),
+		Rewards:          slices.Clone(b.Rewards),
+		Observations:     slices.Clone(b.Observations),
+		M2:               slices.Clone(b.M2),
+		M2Counts:         slices.Clone(b.M2Counts),
+		Weights:          slices.Clone(b.Weights),
+		SquaredWeights:   slices.Clone(b.SquaredWeights),
+		Precisions:       slices.Clone(b.Precisions),
+		PrecisionCounts:  slices.Clone(b.PrecisionCounts),
+		StableMean:       b.StableMean,
+		AnchorWeight:     b.AnchorWeight,
+		IndexBest:        b.IndexBest,
+		TieBreak:         b.TieBreak,
+		SignedRewards:    b.SignedRewards,
+		RewardTransform:  b.RewardTransform,
+		Disabled:         slices.Clone(b.Disabled),
+		Aliases:          maps.Clone(b.Aliases),
+		JitterFraction:   b.JitterFraction,
+		Frozen:           b.Frozen,
+		MinPulls:         b.MinPulls,
+		SelectionCount:   b.SelectionCount,
+		ObjectiveWeights: maps.Clone(b.ObjectiveWeights),
+		ObjectiveMeans:   objectiveMeans,
+		ObjectiveCounts:  slices.Clone(b.ObjectiveCounts),
+		Cooldown:         b.Cooldown,
+		CooldownUntil:    slices.Clone(b.CooldownUntil),
+		Costs:            slices.Clone(b.Costs),
+		PositionBias:     slices.Clone(b.PositionBias),
+		SimilarityMatrix: cloneMatrix(b.SimilarityMatrix),
+		Smoothing:        b.Smoothing,
+		Smoothed:         slices.Clone(b.Smoothed),
+		Schedule:         b.Schedule,
+		Rand:             b.Rand,
+		BackoffFactor:    b.BackoffFactor,
+		Laplace:          b.Laplace,
+		ExploreFloor:     b.ExploreFloor,
+		ExploitBonus:     b.ExploitBonus,
+		ZeroStreaks:      slices.Clone(b.ZeroStreaks),
+		BestArmSamples:   b.BestArmSamples,
+		DedupWindow:      b.DedupWindow,
+		ReportEvery:      b.ReportEvery,
+		OnReport:         b.OnReport,
+		OnAddArm:         b.OnAddArm,
+		OnRemoveArm:      b.OnRemoveArm,
+		Targets:          slices.Clone(b.Targets),
+		Earned:           slices.Clone(b.Earned),
+		OnTargetReached:  b.OnTargetReached,
+		DefaultArm:       b.DefaultArm,
+		UseDefaultArm:    b.UseDefaultArm,
+		ExplorePrior:     b.ExplorePrior,
+		InstanceID:       b.InstanceID,
+		seen:             b.seen.clone(),
+		logger:           b.logger,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock. The
+// BonusFunc is shared with the clone, while the Guard is left out.
+func (b *UCB) Clone() *UCB {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &UCB{
+		Counts:         slices.Clone(b.Counts),
+		Rewards:        slices.Clone(b.Rewards),
+		Costs:          slices.Clone(b.Costs),
+		SelectionCount: b.SelectionCount,
+		Normalize:      b.Normalize,
+		MaxReward:      b.MaxReward,
+		SignedRewards:  b.SignedRewards,
+		MinReward:      b.MinReward,
+		BonusFunc:      b.BonusFunc,
+		Temperature:    b.Temperature,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock. The
+// Guard and Tuner are left out.
+func (b *Softmax) Clone() *Softmax {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &Softmax{
+		Temperature:   b.Temperature,
+		Counts:        slices.Clone(b.Counts),
+		Rewards:       slices.Clone(b.Rewards),
+		SignedRewards: b.SignedRewards,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock. The
+// Guard is left out.
+func (b *AnnealingSoftmax) Clone() *AnnealingSoftmax {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &AnnealingSoftmax{
+		Counts:         slices.Clone(b.Counts),
+		Rewards:        slices.Clone(b.Rewards),
+		SelectionCount: b.SelectionCount,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock
+func (b *ExploreThenCommit) Clone() *ExploreThenCommit {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &ExploreThenCommit{
+		Pulls:          b.Pulls,
+		Counts:         slices.Clone(b.Counts),
+		Rewards:        slices.Clone(b.Rewards),
+		SelectionCount: b.SelectionCount,
+		Committed:      b.Committed,
+		CommittedArm:   b.CommittedArm,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock
+func (b *ProbabilityMatching) Clone() *ProbabilityMatching {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &ProbabilityMatching{
+		Scale:   b.Scale,
+		Counts:  slices.Clone(b.Counts),
+		Rewards: slices.Clone(b.Rewards),
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock. The
+// random source is shared with the clone.
+func (b *AdaptiveGreedy) Clone() *AdaptiveGreedy {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &AdaptiveGreedy{
+		Epsilon:        b.Epsilon,
+		WarmupPulls:    b.WarmupPulls,
+		Counts:         slices.Clone(b.Counts),
+		Rewards:        slices.Clone(b.Rewards),
+		SelectionCount: b.SelectionCount,
+		Rand:           b.Rand,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock
+func (b *MOSS) Clone() *MOSS {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &MOSS{
+		Counts:         slices.Clone(b.Counts),
+		Rewards:        slices.Clone(b.Rewards),
+		SelectionCount: b.SelectionCount,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock. The
+// random source is shared with the clone.
+func (b *QuantileBandit) Clone() *QuantileBandit {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &QuantileBandit{
+		Quantile:   b.Quantile,
+		Epsilon:    b.Epsilon,
+		Counts:     slices.Clone(b.Counts),
+		Rewards:    slices.Clone(b.Rewards),
+		Rand:       b.Rand,
+		estimators: slices.Clone(b.estimators),
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock
+func (b *Hedge) Clone() *Hedge {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &Hedge{
+		Eta:        b.Eta,
+		Counts:     slices.Clone(b.Counts),
+		Rewards:    slices.Clone(b.Rewards),
+		LogWeights: slices.Clone(b.LogWeights),
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock
+func (b *LUCB) Clone() *LUCB {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &LUCB{
+		Delta:      b.Delta,
+		Counts:     slices.Clone(b.Counts),
+		Rewards:    slices.Clone(b.Rewards),
+		M2:         slices.Clone(b.M2),
+		challenger: b.challenger,
+		pending:    b.pending,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock. The
+// strategy and the Rand are shared with the clone.
+func (b *StrategyBandit) Clone() *StrategyBandit {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &StrategyBandit{
+		ArmStats: ArmStats{
+			Counts:  slices.Clone(b.Counts),
+			Rewards: slices.Clone(b.Rewards),
+			M2:      slices.Clone(b.M2),
+		},
+		Strategy: b.Strategy,
+		Rand:     b.Rand,
+	}
+}
+
+// Clone returns a deep copy of the state, taken under the read lock
+func (b *Gittins) Clone() *Gittins {
+	b.RLock()
+	defer b.RUnlock()
+
+	return &Gittins{
+		Discount: b.Discount,
+		Counts:   slices.Clone(b.Counts),
+		Rewards:  slices.Clone(b.Rewards),
+		M2:       slices.Clone(b.M2),
+	}
+}
+
+// clone returns a deep copy of any of the bandits of this package
+func clone(b Bandit) (Bandit, error) {
+	switch b := b.(type) {
+	case *EpsilonGreedy:
+		return b.Clone(), nil
+	case *UCB:
+		return b.Clone(), nil
+	case *Softmax:
+		return b.Clone(), nil
+	case *AnnealingSoftmax:
+		return b.Clone(), nil
+	case *ExploreThenCommit:
+		return b.Clone(), nil
+	case *ProbabilityMatching:
+		return b.Clone(), nil
+	case *AdaptiveGreedy:
+		return b.Clone(), nil
+	case *MOSS:
+		return b.Clone(), nil
+	case *QuantileBandit:
+		return b.Clone(), nil
+	case *Hedge:
+		return b.Clone(), nil
+	case *LUCB:
+		return b.Clone(), nil
+	case *StrategyBandit:
+		return b.Clone(), nil
+	case *Gittins:
+		return b.Clone(), nil
+	default:
+		return nil, ErrNotCloneable
+	}
+}
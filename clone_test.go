@@ -0,0 +1,60 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_Clone(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+	assert.Nil(b.SetObjectiveWeights(map[string]float64{"click": 1}))
+	assert.Nil(b.UpdateMulti(1, map[string]float64{"click": 1}))
+
+	c := b.Clone()
+	assert.Equal(b.GetCounts(), c.GetCounts(), "counts should be equal")
+	assert.Equal(b.GetRewards(), c.GetRewards(), "rewards should be equal")
+	assert.Equal(b.GetObjectiveMeans(), c.GetObjectiveMeans(), "objective means should be equal")
+
+	assert.Nil(b.UpdateMulti(0, map[string]float64{"click": 1}))
+	assert.Equal([]int{0, 1}, c.GetCounts(), "clone should not share the counts")
+	assert.Equal([]float64{0, 1}, c.GetObjectiveMeans()["click"], "clone should not share the objective means")
+}
+
+func TestClone(t *testing.T) {
+	assert := assert.New(t)
+
+	epsilonGreedy, _ := NewEpsilonGreedy(0.1, nil, nil)
+	ucb, _ := NewUCB(nil, nil)
+	softmax, _ := NewSoftmax(0.1, nil, nil)
+	annealingSoftmax, _ := NewAnnealingSoftmax(nil, nil)
+	explore, _ := NewExploreThenCommit(1, nil, nil)
+	matching, _ := NewProbabilityMatching(1, nil, nil)
+	adaptive, _ := NewAdaptiveGreedy(0.1, 1, nil, nil)
+	moss, _ := NewMOSS(nil, nil)
+	quantile, _ := NewQuantileBandit(0.9, 0.1, nil, nil)
+	hedge, _ := NewHedge(0.1, nil, nil)
+	lucb, _ := NewLUCB(0.05, nil, nil)
+	strategy, _ := NewStrategyBandit(UCB1Strategy{}, nil, nil)
+	gittins, _ := NewGittins(0.9, nil, nil)
+
+	for _, b := range []Bandit{epsilonGreedy, ucb, softmax, annealingSoftmax, explore, matching, adaptive, moss, quantile, hedge, lucb, strategy, gittins} {
+		assert.Nil(b.Init(2))
+		assert.Nil(b.Update(1, 0.5))
+
+		c, err := clone(b)
+		assert.Nil(err)
+		assert.Equal(b.GetCounts(), c.GetCounts(), "counts should be equal")
+		assert.Equal(b.GetRewards(), c.GetRewards(), "rewards should be equal")
+
+		assert.Nil(b.Update(0, 1.0))
+		assert.Equal([]int{0, 1}, c.GetCounts(), "clone should not share the counts")
+	}
+
+	_, err := clone(nil)
+	assert.Equal(ErrNotCloneable, err)
+}
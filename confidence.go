@@ -0,0 +1,77 @@
+package bandit
+
+import (
+	"math"
+	"time"
+)
+
+// UpdateWithConfidence will update an arm with some reward value like Update,
+// weighted by the confidence in it, e.g. 1 for measured and 0.2 for imputed
+// rewards. The mean and the variance are weighted with reliability weights,
+// so that the confidence intervals and standard errors shrink with the
+// effective number of rewards rather than their count. The confidence must be
+// greater than zero.
+func (b *EpsilonGreedy) UpdateWithConfidence(chosenArm int, reward float64, confidence float64) error {
+	if !(confidence > 0) || math.IsInf(confidence, 1) {
+		return ErrInvalidConfidence
+	}
+
+	b.Lock()
+	callbacks, err := b.updateAt(chosenArm, reward, confidence, time.Time{})
+	logger := b.logger
+	b.Unlock()
+
+	return b.updated(logger, chosenArm, reward, callbacks, err)
+}
+
+// addWeight adds the weight of a reward to the arm, and returns its total
+// weight. The weights start from the observations, each weighing one.
+func (b *EpsilonGreedy) addWeight(arm int, weight float64) float64 {
+	b.ensureWeights(arm)
+	b.Weights[arm] += weight
+	b.SquaredWeights[arm] += weight * weight
+	return b.Weights[arm]
+}
+
+// ensureWeights starts the weights from the observations when they are
+// missing, leaving out the reward being added to the pending arm, or to no arm
+// when pending is -1
+func (b *EpsilonGreedy) ensureWeights(pending int) {
+	if b.hasWeights() {
+		return
+	}
+	b.Weights = make([]float64, len(b.Rewards))
+	b.SquaredWeights = make([]float64, len(b.Rewards))
+	for i := range b.Weights {
+		// NOTE: The observations already count the reward being added
+		n := float64(b.observations(i))
+		if i == pending {
+			n--
+		}
+		b.Weights[i], b.SquaredWeights[i] = n, n
+	}
+}
+
+// hasWeights returns whether the rewards were weighted
+func (b *EpsilonGreedy) hasWeights() bool {
+	return len(b.Weights) == len(b.Rewards) && len(b.SquaredWeights) == len(b.Rewards)
+}
+
+// varianceDenominator returns the denominator of the unbiased variance of the
+// rewards of the arm, which is n-1 for n unweighted rewards, and W-W2/W for
+// rewards of total weight W and total squared weight W2
+func (b *EpsilonGreedy) varianceDenominator(arm int) float64 {
+	if !b.hasWeights() || b.Weights[arm] == 0 {
+		return float64(b.observations(arm) - 1)
+	}
+	return b.Weights[arm] - b.SquaredWeights[arm]/b.Weights[arm]
+}
+
+// effectiveObservations returns the effective number of rewards of the arm,
+// which is W²/W2 for weighted ones
+func (b *EpsilonGreedy) effectiveObservations(arm int) float64 {
+	if !b.hasWeights() || b.SquaredWeights[arm] == 0 {
+		return float64(b.observations(arm))
+	}
+	return b.Weights[arm] * b.Weights[arm] / b.SquaredWeights[arm]
+}
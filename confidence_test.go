@@ -0,0 +1,77 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_UpdateWithConfidence(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	for _, confidence := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		assert.Equal(ErrInvalidConfidence, b.UpdateWithConfidence(0, 1, confidence))
+	}
+
+	for _, arm := range []int{0, 1} {
+		assert.Nil(b.Update(arm, 0))
+		assert.Nil(b.Update(arm, 0))
+	}
+	assert.Nil(b.UpdateWithConfidence(0, 1, 4))
+	assert.Nil(b.UpdateWithConfidence(1, 1, 0.25))
+
+	rewards := b.GetRewards()
+	assert.InDelta(4.0/6, rewards[0], 1e-9, "should weigh the measured reward")
+	assert.InDelta(0.25/2.25, rewards[1], 1e-9, "should weigh the imputed reward")
+	assert.Greater(rewards[0], rewards[1], "should move the estimate more with a high confidence")
+	assert.Equal([]int{3, 3}, b.GetCounts())
+	assert.Equal([]float64{6, 2.25}, b.Weights)
+	assert.Equal([]float64{18, 2.0625}, b.SquaredWeights)
+}
+
+func TestEpsilonGreedy_UpdateWithConfidenceVariance(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(1))
+	b.StableMean = true
+
+	rewards := []float64{0.2, 0.9, 0.4, 0.7}
+	weights := []float64{1, 3, 0.5, 2}
+	var w, w2, mean float64
+	for i, reward := range rewards {
+		assert.Nil(b.UpdateWithConfidence(0, reward, weights[i]))
+		w += weights[i]
+		w2 += weights[i] * weights[i]
+		mean += weights[i] * reward
+	}
+	mean /= w
+	var m2 float64
+	for i, reward := range rewards {
+		m2 += weights[i] * (reward - mean) * (reward - mean)
+	}
+
+	assert.InDelta(mean, b.GetRewards()[0], 1e-9, "should keep the weighted mean")
+	variances, err := b.GetVariances()
+	assert.Nil(err)
+	assert.InDelta(m2/(w-w2/w), variances[0], 1e-9, "should keep the reliability weighted variance")
+	assert.InDelta(math.Sqrt(variances[0]*w2/(w*w)), b.standardError(0), 1e-9, "should use the effective number of rewards")
+
+	unweighted, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(unweighted.Init(1))
+	unweighted.StableMean = true
+	for _, reward := range rewards {
+		assert.Nil(unweighted.UpdateWithConfidence(0, reward, 1))
+	}
+	plain, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(plain.Init(1))
+	plain.StableMean = true
+	for _, reward := range rewards {
+		assert.Nil(plain.Update(0, reward))
+	}
+	assert.InDelta(plain.GetRewards()[0], unweighted.GetRewards()[0], 1e-12, "should match Update with unit confidence")
+	assert.InDelta(plain.standardError(0), unweighted.standardError(0), 1e-12)
+}
@@ -0,0 +1,76 @@
+package bandit
+
+import "math"
+
+// Config holds the tunable parameters of epsilon greedy, e.g. loaded from a
+// config file at runtime. A nil Costs disables cost-aware selection.
+type Config struct {
+	Epsilon       float64   `json:"epsilon"`
+	Cooldown      int       `json:"cooldown"`
+	MinPulls      int       `json:"min_pulls"`
+	Smoothing     float64   `json:"smoothing"`
+	BackoffFactor float64   `json:"backoff_factor"`
+	Costs         []float64 `json:"costs,omitempty"`
+}
+
+// ApplyConfig replaces all the tunable parameters at once under the lock. The
+// config is validated first, and nothing is applied when any field is
+// invalid.
+func (b *EpsilonGreedy) ApplyConfig(cfg Config) error {
+	b.Lock()
+	defer b.Unlock()
+
+	costs, err := cfg.validate(len(b.Rewards))
+	if err != nil {
+		return err
+	}
+
+	b.Epsilon = cfg.Epsilon
+	b.Cooldown = cfg.Cooldown
+	b.MinPulls = cfg.MinPulls
+	b.Smoothing = cfg.Smoothing
+	b.BackoffFactor = cfg.BackoffFactor
+	b.Costs = costs
+	return nil
+}
+
+// GetConfig returns the current tunable parameters
+func (b *EpsilonGreedy) GetConfig() Config {
+	b.RLock()
+	defer b.RUnlock()
+
+	var costs []float64
+	if b.Costs != nil {
+		costs = make([]float64, len(b.Costs))
+		copy(costs, b.Costs)
+	}
+	return Config{
+		Epsilon:       b.Epsilon,
+		Cooldown:      b.Cooldown,
+		MinPulls:      b.MinPulls,
+		Smoothing:     b.Smoothing,
+		BackoffFactor: b.BackoffFactor,
+		Costs:         costs,
+	}
+}
+
+// validate checks every field against the number of arms, and returns a copy
+// of the costs
+func (cfg Config) validate(nArms int) ([]float64, error) {
+	if cfg.Epsilon < 0 || cfg.Epsilon > 1 || math.IsNaN(cfg.Epsilon) {
+		return nil, ErrInvalidEpsilon
+	}
+	if cfg.Cooldown < 0 {
+		return nil, ErrInvalidCooldown
+	}
+	if cfg.MinPulls < 0 {
+		return nil, ErrInvalidMinPulls
+	}
+	if cfg.Smoothing < 0 || cfg.Smoothing > 1 || math.IsNaN(cfg.Smoothing) {
+		return nil, ErrInvalidFraction
+	}
+	if cfg.BackoffFactor < 0 || cfg.BackoffFactor > 1 || math.IsNaN(cfg.BackoffFactor) {
+		return nil, ErrInvalidFraction
+	}
+	return validateCosts(cfg.Costs, nArms)
+}
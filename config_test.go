@@ -0,0 +1,54 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ApplyConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, []int{0, 0, 0}, []float64{0, 0, 0})
+	cfg := Config{
+		Epsilon:       0.2,
+		Cooldown:      2,
+		MinPulls:      10,
+		Smoothing:     0.5,
+		BackoffFactor: 0.9,
+		Costs:         []float64{1, 2, 3},
+	}
+	assert.Nil(b.ApplyConfig(cfg))
+	assert.Equal(cfg, b.GetConfig())
+	assert.Equal(2, b.Cooldown)
+
+	cfg.Costs[0] = 5
+	assert.Equal(1.0, b.GetConfig().Costs[0], "should copy the costs")
+
+	valid := b.GetConfig()
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    error
+	}{
+		{"epsilon", func(cfg *Config) { cfg.Epsilon = 1.1 }, ErrInvalidEpsilon},
+		{"epsilon NaN", func(cfg *Config) { cfg.Epsilon = math.NaN() }, ErrInvalidEpsilon},
+		{"cooldown", func(cfg *Config) { cfg.Cooldown = -1 }, ErrInvalidCooldown},
+		{"min pulls", func(cfg *Config) { cfg.MinPulls = -1 }, ErrInvalidMinPulls},
+		{"smoothing", func(cfg *Config) { cfg.Smoothing = 2 }, ErrInvalidFraction},
+		{"backoff", func(cfg *Config) { cfg.BackoffFactor = -0.5 }, ErrInvalidFraction},
+		{"costs length", func(cfg *Config) { cfg.Costs = []float64{1} }, ErrInvalidLength},
+		{"costs", func(cfg *Config) { cfg.Costs = []float64{1, 0, 1} }, ErrInvalidCost},
+	}
+	for _, test := range tests {
+		// Every field but the invalid one would change the config
+		cfg := Config{Epsilon: 0.3, Cooldown: 1, MinPulls: 1, Smoothing: 0.1, BackoffFactor: 0.5}
+		test.modify(&cfg)
+		assert.Equal(test.err, b.ApplyConfig(cfg), test.name)
+		assert.Equal(valid, b.GetConfig(), test.name, "should not partially apply the config")
+	}
+
+	assert.Nil(b.ApplyConfig(Config{Epsilon: 0.1}))
+	assert.Nil(b.GetConfig().Costs, "should disable the costs")
+}
@@ -0,0 +1,101 @@
+package bandit
+
+import "math"
+
+// minConvergencePulls is the number of pulls below which the variance of an
+// arm is undefined and the arm is never considered converged
+const minConvergencePulls = 2
+
+// z95 is the z-score of the 95% confidence interval
+const z95 = 1.96
+
+// IsConverged returns true once the width of the 95% confidence interval of
+// the arm's mean reward is below ciWidth. Arms with too few pulls, too few
+// rewards since their M2 began tracking them, or an out of range index are
+// never converged.
+func (b *EpsilonGreedy) IsConverged(arm int, ciWidth float64) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	if arm < 0 || arm >= len(b.Counts) {
+		return false
+	}
+	n := b.observations(arm)
+	if n < minConvergencePulls || len(b.M2) != len(b.Counts) || len(b.M2Counts) != len(b.Counts) {
+		return false
+	}
+	// NOTE: A zero-filled M2 reports a variance of zero until it tracked
+	// enough rewards of its own
+	if b.M2Counts[arm] < minConvergencePulls {
+		return false
+	}
+
+	variance := b.M2[arm] / b.varianceDenominator(arm)
+	width := 2 * z95 * math.Sqrt(variance/b.effectiveObservations(arm))
+	return width < ciWidth
+}
+
+// ensureM2 zero-fills M2 and M2Counts when they are missing, e.g. when the
+// bandit was created from counts and rewards alone or from a persisted state
+func (b *EpsilonGreedy) ensureM2() {
+	if len(b.M2) != len(b.Rewards) {
+		b.M2 = make([]float64, len(b.Rewards))
+		b.M2Counts = nil
+	}
+	if len(b.M2Counts) != len(b.Rewards) {
+		b.M2Counts = make([]int, len(b.Rewards))
+	}
+}
+
+// ConvergenceEstimate returns a heuristic of how close the bandit is to
+// telling the best enabled arm apart from the runner-up, for dashboards. The
+// progress is the gap between their means over the width of the 95%
+// confidence interval of the gap, capped at 1, and eta is the number of pulls
+// left until the interval clears the gap, assuming the pulls are split evenly
+// between the two arms and their means and variances hold. Arms with fewer
+// than two rewards assume the variance of 0.25. It is an estimate, not a
+// guarantee, and eta is -1 when the top two arms are tied.
+func (b *EpsilonGreedy) ConvergenceEstimate() (progress float64, eta int) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.initialized() {
+		return 0, -1
+	}
+	arms := b.enabledArms()
+	if len(arms) == 0 {
+		return 0, -1
+	}
+	if len(arms) == 1 {
+		return 1, 0
+	}
+
+	best, second := arms[0], arms[1]
+	if b.Rewards[second] > b.Rewards[best] {
+		best, second = second, best
+	}
+	for _, i := range arms[2:] {
+		switch {
+		case b.Rewards[i] > b.Rewards[best]:
+			best, second = i, best
+		case b.Rewards[i] > b.Rewards[second]:
+			second = i
+		}
+	}
+
+	gap := b.Rewards[best] - b.Rewards[second]
+	if !(gap > 0) {
+		return 0, -1
+	}
+	se := math.Hypot(b.standardError(best), b.standardError(second))
+	progress = math.Min(gap/(z95*se), 1)
+
+	// Pulls of each arm for the interval to clear the gap
+	required := math.Ceil(z95 * z95 * (b.rewardVariance(best) + b.rewardVariance(second)) / (gap * gap))
+	left := math.Max(required-float64(b.observations(best)), 0) +
+		math.Max(required-float64(b.observations(second)), 0)
+	if left >= math.MaxInt {
+		return progress, math.MaxInt
+	}
+	return progress, int(left)
+}
@@ -0,0 +1,116 @@
+package bandit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_IsConverged(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		pulls    int
+		ciWidth  float64
+		expected bool
+	}{
+		{0, 0.1, false},
+		{1, 0.1, false},
+		{2, 0.1, false},
+		{10, 0.1, false},
+		{10000, 0.1, true},
+		{10000, 0.01, false},
+	}
+
+	for i, tt := range tests {
+		b, err := NewEpsilonGreedy(0.1, nil, nil)
+		assert.Nil(err)
+
+		err = b.Init(2)
+		assert.Nil(err)
+
+		// Alternate between 0 and 1 so the reward variance is 0.25
+		for j := 0; j < tt.pulls; j++ {
+			err = b.Update(0, float64(j%2))
+			assert.Nil(err)
+		}
+		assert.Equal(tt.expected, b.IsConverged(0, tt.ciWidth), "should report convergence for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_IsConvergedWithInvalidArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{100, 100}, []float64{0.5, 0.5})
+	assert.Nil(err)
+
+	assert.False(b.IsConverged(-1, 1.0), "should not converge for negative arm")
+	assert.False(b.IsConverged(2, 1.0), "should not converge for out of range arm")
+	assert.False(b.IsConverged(0, 1.0), "should not converge without tracked variance")
+}
+
+func TestEpsilonGreedy_IsConvergedZeroFilledM2(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{100, 100}, []float64{0.5, 0.5})
+	assert.Nil(err)
+	assert.Nil(b.Update(0, 0.5))
+	assert.False(b.IsConverged(0, 1.0), "should not converge on a lazily zero-filled M2")
+	assert.Nil(b.Update(0, 0.5))
+	assert.True(b.IsConverged(0, 1.0), "should converge once M2 tracked two rewards")
+
+	old := []byte(`{"epsilon":0.1,"counts":[100,100],"values":[0.5,0.5]}`)
+	migrated := &EpsilonGreedy{}
+	assert.Nil(json.Unmarshal(old, migrated))
+	assert.Nil(migrated.Update(1, 0.5))
+	assert.False(migrated.IsConverged(0, 1.0), "should not converge on a migrated M2")
+	assert.False(migrated.IsConverged(1, 1.0), "should not converge on a migrated M2")
+}
+
+func TestEpsilonGreedy_ConvergenceEstimate(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	progress, eta := b.ConvergenceEstimate()
+	assert.Equal(0.0, progress, "should report no progress without arms")
+	assert.Equal(-1, eta)
+
+	// Alternate the rewards so the variance of each arm is about 0.25
+	separated, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(separated.Init(3))
+	tied, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(tied.Init(3))
+	for i := 0; i < 200; i++ {
+		assert.Nil(separated.Update(0, 0.1+0.1*float64(i%2)))
+		assert.Nil(separated.Update(1, 0.8+0.1*float64(i%2)))
+		assert.Nil(separated.Update(2, 0.1*float64(i%2)))
+		assert.Nil(tied.Update(0, float64(i%2)))
+		assert.Nil(tied.Update(1, float64((i+1)%2)))
+	}
+
+	progress, eta = separated.ConvergenceEstimate()
+	assert.Equal(1.0, progress, "should report full progress for separated arms")
+	assert.Equal(0, eta, "should need no more pulls for separated arms")
+
+	progress, eta = tied.ConvergenceEstimate()
+	// NOTE: Rounding of the means may leave a tiny gap between tied arms
+	assert.Less(progress, 0.01, "should report no progress for tied arms")
+	assert.True(eta == -1 || eta > 1e9, "should not expect convergence for tied arms")
+
+	close, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(close.Init(2))
+	for i := 0; i < 100; i++ {
+		assert.Nil(close.Update(0, float64(i%2)))
+		assert.Nil(close.Update(1, float64(i%2)*0.9))
+	}
+	progress, eta = close.ConvergenceEstimate()
+	assert.Greater(progress, 0.0)
+	assert.Less(progress, 0.5, "should report low progress for close arms")
+	assert.Greater(eta, 1000, "should need many pulls for close arms")
+
+	single, _ := NewEpsilonGreedy(0.1, []int{1}, []float64{0.5})
+	progress, eta = single.ConvergenceEstimate()
+	assert.Equal(1.0, progress, "should converge with a single arm")
+	assert.Equal(0, eta)
+}
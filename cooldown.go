@@ -0,0 +1,67 @@
+package bandit
+
+// CoolingArms returns the indices of the arms that are cooling down
+func (b *EpsilonGreedy) CoolingArms() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	var arms []int
+	for i := range b.Rewards {
+		if b.isCooling(i) {
+			arms = append(arms, i)
+		}
+	}
+	return arms
+}
+
+// eligibleArms returns the enabled arms that are not cooling down. When every
+// enabled arm is cooling, the least recently selected one is eligible. The
+// result reuses the scratch buffer, so it is only valid under the lock until
+// the next call.
+func (b *EpsilonGreedy) eligibleArms() []int {
+	enabled := b.appendEnabled(b.scratch[:0])
+	b.scratch = enabled
+	if b.Cooldown == 0 || len(enabled) == 0 {
+		return enabled
+	}
+
+	// NOTE: Filtering in place leaves enabled untouched when no arm is
+	// eligible, since nothing is written until an eligible arm is found
+	eligible := enabled[:0]
+	for _, i := range enabled {
+		if !b.isCooling(i) {
+			eligible = append(eligible, i)
+		}
+	}
+	if len(eligible) > 0 {
+		return eligible
+	}
+
+	oldest := enabled[0]
+	for _, i := range enabled {
+		if b.CooldownUntil[i] < b.CooldownUntil[oldest] {
+			oldest = i
+		}
+	}
+	enabled[0] = oldest
+	return enabled[:1]
+}
+
+func (b *EpsilonGreedy) isCooling(arm int) bool {
+	if len(b.CooldownUntil) != len(b.Rewards) {
+		return false
+	}
+	return b.SelectionCount < b.CooldownUntil[arm]
+}
+
+// startCooldown makes the selected arm ineligible for the next Cooldown
+// selections
+func (b *EpsilonGreedy) startCooldown(arm int) {
+	if b.Cooldown == 0 {
+		return
+	}
+	if len(b.CooldownUntil) != len(b.Rewards) {
+		b.CooldownUntil = make([]int, len(b.Rewards))
+	}
+	b.CooldownUntil[arm] = b.SelectionCount + b.Cooldown + 1
+}
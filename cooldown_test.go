@@ -0,0 +1,48 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SelectArmWithCooldown(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1, 1}, []float64{0.9, 0.5, 0.1})
+	assert.Nil(err)
+	b.Cooldown = 2
+
+	tests := []struct {
+		expected int
+		cooling  []int
+	}{
+		{0, []int{0}},
+		{1, []int{0, 1}},
+		{2, []int{1, 2}},
+		{0, []int{2, 0}},
+		{1, []int{0, 1}},
+	}
+
+	for i, tt := range tests {
+		arm, err := b.SelectArm(1.0)
+		assert.Nil(err)
+		assert.Equal(tt.expected, arm, "should select the best arm that is not cooling for test %d", i+1)
+		assert.ElementsMatch(tt.cooling, b.CoolingArms(), "should report the cooling arms for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_SelectArmWithAllArmsCooling(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1}, []float64{0.9, 0.1})
+	assert.Nil(err)
+	b.Cooldown = 5
+
+	tests := []int{0, 1, 0, 1}
+	for i, expected := range tests {
+		arm, err := b.SelectArm(1.0)
+		assert.Nil(err)
+		assert.Equal(expected, arm, "should fall back to the least recently selected arm for test %d", i+1)
+	}
+}
@@ -0,0 +1,124 @@
+package bandit
+
+import "math"
+
+// SetCosts sets the cost of serving each arm, which must be greater than zero.
+// Passing nil disables cost-aware selection.
+func (b *EpsilonGreedy) SetCosts(costs []float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	c, err := validateCosts(costs, len(b.Rewards))
+	if err != nil {
+		return err
+	}
+	b.Costs = c
+	return nil
+}
+
+// SetCosts sets the cost of serving each arm, which must be greater than zero.
+// Passing nil disables cost-aware selection.
+func (b *UCB) SetCosts(costs []float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	c, err := validateCosts(costs, len(b.Rewards))
+	if err != nil {
+		return err
+	}
+	b.Costs = c
+	return nil
+}
+
+// bestArm returns the arm to exploit among the provided arms, or all arms
+// when nil, where TieMostPulled breaks the ties. TieRandom is applied by
+// exploitArm.
+func (b *EpsilonGreedy) bestArm(arms []int) int {
+	rewards := b.exploitRewards()
+	best := b.bestOf(rewards, arms)
+	if b.TieBreak == TieMostPulled {
+		best = b.mostPulled(b.tiedWith(rewards, best, arms))
+	}
+	return best
+}
+
+// exploitRewards returns the rewards the arm to exploit is chosen by
+func (b *EpsilonGreedy) exploitRewards() []float64 {
+	if b.Laplace > 0 {
+		return b.laplaceMeans()
+	}
+	return b.Rewards
+}
+
+// bestOf returns the arm with the highest score among the provided arms, or
+// all arms when nil, where ties go to the lowest index, and to the first arm
+// when none has a score
+func (b *EpsilonGreedy) bestOf(rewards []float64, arms []int) int {
+	if arms == nil && b.IndexBest && b.Laplace == 0 && b.ExploitBonus == 0 && len(b.Costs) != len(b.Rewards) {
+		return b.cachedBest()
+	}
+
+	best := -1
+	value := math.Inf(-1)
+	check := func(i int) {
+		if v := b.score(rewards, i); best < 0 || v > value {
+			best, value = i, v
+		}
+	}
+
+	if arms == nil {
+		for i := range rewards {
+			check(i)
+		}
+	} else {
+		for _, i := range arms {
+			check(i)
+		}
+	}
+	if best < 0 {
+		return 0
+	}
+	return best
+}
+
+// score returns the value the arm to exploit maximises, which is its mean,
+// plus the exploit bonus when set, per unit cost when costs are set, and
+// negative infinity for the unplayed arms without a bonus, or a NaN mean
+func (b *EpsilonGreedy) score(rewards []float64, arm int) float64 {
+	var v float64
+	switch {
+	case b.ExploitBonus > 0:
+		v = rewards[arm] + b.ExploitBonus/math.Sqrt(float64(b.observations(arm)))
+	case b.Counts[arm] == 0:
+		return math.Inf(-1)
+	default:
+		v = rewards[arm]
+	}
+	if len(b.Costs) == len(b.Rewards) {
+		v /= b.Costs[arm]
+	}
+	if math.IsNaN(v) {
+		return math.Inf(-1)
+	}
+	return v
+}
+
+// validateCosts returns a copy of the costs after checking them against the
+// number of arms
+func validateCosts(costs []float64, nArms int) ([]float64, error) {
+	if costs == nil {
+		return nil, nil
+	}
+	if len(costs) != nArms {
+		return nil, ErrInvalidLength
+	}
+	for _, cost := range costs {
+		if cost <= 0 {
+			return nil, ErrInvalidCost
+		}
+	}
+
+	sCopy := make([]float64, len(costs))
+	copy(sCopy, costs)
+	return sCopy, nil
+}
@@ -0,0 +1,103 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SetCosts(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	tests := []struct {
+		costs []float64
+		err   error
+	}{
+		{nil, nil},
+		{[]float64{1.0, 2.0}, nil},
+		{[]float64{1.0}, ErrInvalidLength},
+		{[]float64{1.0, 2.0, 3.0}, ErrInvalidLength},
+		{[]float64{1.0, 0.0}, ErrInvalidCost},
+		{[]float64{-1.0, 1.0}, ErrInvalidCost},
+	}
+
+	for i, tt := range tests {
+		err := b.SetCosts(tt.costs)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+
+	costs := []float64{1.0, 2.0}
+	assert.Nil(b.SetCosts(costs))
+	costs[0] = 5.0
+	assert.Equal([]float64{1.0, 2.0}, b.Costs, "should copy the costs")
+}
+
+func TestEpsilonGreedy_SelectArmWithCosts(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1}, []float64{1.0, 0.9})
+	assert.Nil(err)
+
+	arm, err := b.SelectArm(1.0)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should exploit the highest reward")
+
+	assert.Nil(b.SetCosts([]float64{2.0, 1.0}))
+	arm, err = b.SelectArm(1.0)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should exploit the highest reward per cost")
+}
+
+func TestUCB_SelectArmWithCosts(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewUCB([]int{100, 100}, []float64{1.0, 0.9})
+	assert.Nil(err)
+
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should select the highest reward")
+
+	assert.Equal(ErrInvalidCost, b.SetCosts([]float64{0.0, 1.0}), "should throw error for invalid cost")
+	assert.Nil(b.SetCosts([]float64{2.0, 1.0}))
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should select the highest reward per cost")
+}
+
+func TestEpsilonGreedy_BestOf(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		counts   []int
+		rewards  []float64
+		costs    []float64
+		arms     []int
+		expected int
+	}{
+		{[]int{1, 1, 1}, []float64{1, 3, 2}, nil, []int{0, 1, 2}, 1},
+		{[]int{1, 1, 1}, []float64{1, 3, 2}, nil, []int{0, 2}, 2},
+		{[]int{0, 0, 0}, []float64{0, 0, 0}, nil, []int{1, 2}, 1},
+		{[]int{100, 1, 1}, []float64{0.9, 0.1, 0.5}, nil, []int{0, 1}, 0},
+		{[]int{0, 1}, []float64{0.9, 0.1}, nil, nil, 1},
+		{[]int{1, 1}, []float64{1.0, 0.9}, []float64{1, 1}, nil, 0},
+		{[]int{1, 1}, []float64{1.0, 0.9}, []float64{2, 1}, nil, 1},
+		{[]int{1, 1, 1}, []float64{1.0, 0.9, 0.1}, []float64{2, 1, 1}, []int{0, 2}, 0},
+		{[]int{0, 0}, []float64{0, 0}, []float64{1, 1}, []int{1}, 1},
+		{[]int{100, 1}, []float64{0.9, 0.1}, []float64{1, 1}, nil, 0},
+		{[]int{100, 1}, []float64{0.9, 0.1}, []float64{10, 1}, nil, 1},
+	}
+
+	for i, tt := range tests {
+		b, err := NewEpsilonGreedy(0, tt.counts, tt.rewards)
+		assert.Nil(err)
+		assert.Nil(b.SetCosts(tt.costs))
+		assert.Equal(tt.expected, b.bestOf(b.Rewards, tt.arms), "should return the best arm for test %d", i+1)
+	}
+}
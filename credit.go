@@ -0,0 +1,139 @@
+package bandit
+
+import (
+	"slices"
+	"time"
+)
+
+// CreditBucket holds the updates of the selections made within one window,
+// starting at Start
+type CreditBucket struct {
+	Start   time.Time `json:"start"`
+	Counts  []int     `json:"counts"`
+	Rewards []float64 `json:"rewards"`
+}
+
+// CreditWindows credits delayed rewards to the window of their selection time
+// rather than of their arrival, keeping the last windows of Granularity each,
+// e.g. to track a drifting policy when the feedback is late. It is guarded by
+// the lock of the bandit it belongs to.
+type CreditWindows struct {
+	// Granularity is the duration of a window, and should not change once
+	// updates are credited
+	Granularity time.Duration
+
+	// Now returns the current time, and defaults to time.Now. Updates without
+	// a selection time are credited to it.
+	Now func() time.Time
+
+	size    int
+	buckets []CreditBucket
+}
+
+// add credits the reward of an arm to the window of the selection time, and
+// drops it when the window is older than the windows kept
+func (c *CreditWindows) add(arm, nArms int, reward float64, selectedAt time.Time) {
+	if selectedAt.IsZero() {
+		now := time.Now
+		if c.Now != nil {
+			now = c.Now
+		}
+		selectedAt = now()
+	}
+	start := selectedAt.Truncate(c.Granularity)
+
+	i, found := slices.BinarySearchFunc(c.buckets, start, func(bucket CreditBucket, start time.Time) int {
+		return bucket.Start.Compare(start)
+	})
+	if !found {
+		if i == 0 && len(c.buckets) == c.size {
+			return
+		}
+		c.buckets = slices.Insert(c.buckets, i, CreditBucket{Start: start})
+		if len(c.buckets) > c.size {
+			c.buckets = slices.Delete(c.buckets, 0, 1)
+			i--
+		}
+	}
+
+	bucket := &c.buckets[i]
+	if len(bucket.Counts) < nArms {
+		bucket.Counts = append(bucket.Counts, make([]int, nArms-len(bucket.Counts))...)
+		bucket.Rewards = append(bucket.Rewards, make([]float64, nArms-len(bucket.Rewards))...)
+	}
+	bucket.Counts[arm]++
+	bucket.Rewards[arm] += (reward - bucket.Rewards[arm]) / float64(bucket.Counts[arm])
+}
+
+// NewCreditWindows returns a pointer to the CreditWindows struct keeping up to
+// size windows of the granularity
+func NewCreditWindows(granularity time.Duration, size int) (*CreditWindows, error) {
+	if granularity <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	if size < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &CreditWindows{
+		Granularity: granularity,
+		size:        size,
+	}, nil
+}
+
+// UpdateAt will update an arm with some reward value like Update, crediting it
+// to the window of the time the arm was selected at when Credit is set
+func (b *EpsilonGreedy) UpdateAt(chosenArm int, reward float64, selectedAt time.Time) error {
+	b.Lock()
+	callbacks, err := b.updateAt(chosenArm, reward, 1, selectedAt)
+	logger := b.logger
+	b.Unlock()
+
+	return b.updated(logger, chosenArm, reward, callbacks, err)
+}
+
+// CreditBuckets returns a copy of the credit windows from the oldest to the
+// newest, or nil when Credit is not set
+func (b *EpsilonGreedy) CreditBuckets() []CreditBucket {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.Credit == nil {
+		return nil
+	}
+	buckets := make([]CreditBucket, len(b.Credit.buckets))
+	for i, bucket := range b.Credit.buckets {
+		buckets[i] = CreditBucket{
+			Start:   bucket.Start,
+			Counts:  slices.Clone(bucket.Counts),
+			Rewards: slices.Clone(bucket.Rewards),
+		}
+	}
+	return buckets
+}
+
+// WindowedRewards returns the counts and the mean rewards of each arm over the
+// credit windows starting at or after since, or nils when Credit is not set
+func (b *EpsilonGreedy) WindowedRewards(since time.Time) ([]int, []float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.Credit == nil {
+		return nil, nil
+	}
+	counts := make([]int, len(b.Rewards))
+	rewards := make([]float64, len(b.Rewards))
+	for _, bucket := range b.Credit.buckets {
+		if bucket.Start.Before(since.Truncate(b.Credit.Granularity)) {
+			continue
+		}
+		for i, count := range bucket.Counts {
+			if i >= len(counts) || count == 0 {
+				continue
+			}
+			counts[i] += count
+			rewards[i] += (bucket.Rewards[i] - rewards[i]) * float64(count) / float64(counts[i])
+		}
+	}
+	return counts, rewards
+}
@@ -0,0 +1,80 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCreditWindows(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		granularity time.Duration
+		size        int
+		err         error
+	}{
+		{time.Minute, 1, nil},
+		{0, 1, ErrInvalidDuration},
+		{-time.Minute, 1, ErrInvalidDuration},
+		{time.Minute, 0, ErrInvalidSize},
+	}
+
+	for _, tt := range tests {
+		c, err := NewCreditWindows(tt.granularity, tt.size)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw error for invalid params")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.granularity, c.Granularity)
+		}
+	}
+}
+
+func TestEpsilonGreedy_UpdateAt(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	assert.Nil(b.CreditBuckets(), "should have no windows without credit")
+	assert.Nil(b.UpdateAt(0, 1, start), "should update without credit")
+
+	credit, err := NewCreditWindows(time.Minute, 3)
+	assert.Nil(err)
+	clock := &fakeClock{now: start.Add(10 * time.Minute)}
+	credit.Now = clock.Now
+	b.Credit = credit
+
+	// Rewards arrive out of order, long after their selection
+	assert.Nil(b.UpdateAt(0, 1, start.Add(2*time.Minute+10*time.Second)))
+	assert.Nil(b.UpdateAt(1, 0, start.Add(30*time.Second)))
+	assert.Nil(b.UpdateAt(0, 0, start.Add(2*time.Minute+50*time.Second)))
+	assert.Nil(b.UpdateAt(1, 1, start.Add(20*time.Second)))
+	assert.Nil(b.UpdateAt(1, 1, start.Add(time.Minute)))
+	assert.True(errors.Is(b.UpdateAt(2, 1, start), ErrArmsIndexOutOfRange))
+
+	buckets := b.CreditBuckets()
+	assert.Equal([]CreditBucket{
+		{Start: start, Counts: []int{0, 2}, Rewards: []float64{0, 0.5}},
+		{Start: start.Add(time.Minute), Counts: []int{0, 1}, Rewards: []float64{0, 1}},
+		{Start: start.Add(2 * time.Minute), Counts: []int{2, 0}, Rewards: []float64{0.5, 0}},
+	}, buckets, "should credit the rewards to the windows of their selection")
+	assert.Equal([]int{3, 3}, b.GetCounts(), "should update the long-run stats too")
+
+	counts, rewards := b.WindowedRewards(start.Add(time.Minute + 30*time.Second))
+	assert.Equal([]int{2, 1}, counts)
+	assert.Equal([]float64{0.5, 1}, rewards)
+
+	// An update without a selection time is credited at arrival, evicting the
+	// oldest window, while the windows older than the kept ones are dropped
+	assert.Nil(b.Update(0, 1))
+	assert.Nil(b.UpdateAt(1, 1, start))
+	buckets = b.CreditBuckets()
+	assert.Equal(3, len(buckets))
+	assert.Equal(start.Add(time.Minute), buckets[0].Start, "should evict the oldest window")
+	assert.Equal(start.Add(10*time.Minute), buckets[2].Start, "should credit at the current time")
+	assert.Equal([]int{1, 0}, buckets[2].Counts)
+}
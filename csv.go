@@ -0,0 +1,31 @@
+package bandit
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes a header and one row of index, count, mean and share per
+// arm, from a consistent snapshot of the bandit stats
+func (b *EpsilonGreedy) WriteCSV(w io.Writer) error {
+	metrics := b.Metrics()
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"index", "count", "mean", "share"}); err != nil {
+		return err
+	}
+	for _, arm := range metrics.Arms {
+		err := writer.Write([]string{
+			strconv.Itoa(arm.Index),
+			strconv.Itoa(arm.Count),
+			strconv.FormatFloat(arm.Mean, 'g', -1, 64),
+			strconv.FormatFloat(arm.Share, 'g', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,41 @@
+package bandit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_WriteCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		counts  []int
+		rewards []float64
+		rows    [][]string
+	}{
+		{nil, nil, nil},
+		{
+			[]int{1, 3}, []float64{0.5, 0.25},
+			[][]string{{"0", "1", "0.5", "0.25"}, {"1", "3", "0.25", "0.75"}},
+		},
+	}
+
+	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0.1, tt.counts, tt.rewards)
+		assert.Nil(err)
+
+		var buf bytes.Buffer
+		assert.Nil(b.WriteCSV(&buf))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		assert.Nil(err)
+		assert.Equal(len(tt.counts)+1, len(records), "should write a row per arm")
+		assert.Equal([]string{"index", "count", "mean", "share"}, records[0], "should write the header")
+		if tt.rows != nil {
+			assert.Equal(tt.rows, records[1:])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package bandit
+
+import "container/list"
+
+// defaultDedupWindow is the number of event IDs remembered when no window is
+// set
+const defaultDedupWindow = 1024
+
+// UpdateOnce is like Update, but ignores the update with ErrDuplicateUpdate
+// when the event ID was already applied within the last DedupWindow updates,
+// e.g. for an event bus with at-least-once delivery. Failed updates are not
+// remembered, so they can be retried.
+func (b *EpsilonGreedy) UpdateOnce(eventID string, chosenArm int, reward float64) error {
+	b.Lock()
+	if b.seen.contains(eventID) {
+		b.Unlock()
+		return ErrDuplicateUpdate
+	}
+	callbacks, err := b.update(chosenArm, reward)
+	if err == nil {
+		window := b.DedupWindow
+		if window < 1 {
+			window = defaultDedupWindow
+		}
+		b.seen.add(eventID, window)
+	}
+	logger := b.logger
+	b.Unlock()
+
+	return b.updated(logger, chosenArm, reward, callbacks, err)
+}
+
+// dedup remembers the most recently seen event IDs
+type dedup struct {
+	order *list.List
+	ids   map[string]*list.Element
+}
+
+// contains returns whether the event ID was seen, and refreshes it if so
+func (d *dedup) contains(id string) bool {
+	e, ok := d.ids[id]
+	if ok {
+		d.order.MoveToFront(e)
+	}
+	return ok
+}
+
+// clone returns a copy of the seen event IDs, in the same order
+func (d *dedup) clone() dedup {
+	if d.ids == nil {
+		return dedup{}
+	}
+	c := dedup{order: list.New(), ids: make(map[string]*list.Element, len(d.ids))}
+	for e := d.order.Back(); e != nil; e = e.Prev() {
+		id := e.Value.(string)
+		c.ids[id] = c.order.PushFront(id)
+	}
+	return c
+}
+
+// add remembers the event ID, and forgets the least recently seen ones beyond
+// the window
+func (d *dedup) add(id string, window int) {
+	if d.ids == nil {
+		d.order = list.New()
+		d.ids = make(map[string]*list.Element)
+	}
+	d.ids[id] = d.order.PushFront(id)
+	for d.order.Len() > window {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.ids, oldest.Value.(string))
+	}
+}
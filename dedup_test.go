@@ -0,0 +1,63 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_UpdateOnce(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	assert.Nil(b.UpdateOnce("event-1", 1, 1.0))
+	assert.Equal(ErrDuplicateUpdate, b.UpdateOnce("event-1", 1, 1.0), "should ignore the redelivery")
+	assert.Equal([]int{0, 1}, b.GetCounts(), "should apply the update once")
+	assert.Equal([]float64{0, 1}, b.GetRewards(), "should apply the update once")
+
+	assert.True(errors.Is(b.UpdateOnce("event-2", 5, 1.0), ErrArmsIndexOutOfRange))
+	assert.Nil(b.UpdateOnce("event-2", 0, 1.0), "should not remember failed updates")
+	assert.Equal([]int{1, 1}, b.GetCounts())
+}
+
+func TestEpsilonGreedy_DedupWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(1))
+	b.DedupWindow = 2
+
+	assert.Nil(b.UpdateOnce("a", 0, 1.0))
+	assert.Nil(b.UpdateOnce("b", 0, 1.0))
+	assert.Equal(ErrDuplicateUpdate, b.UpdateOnce("a", 0, 1.0), "should refresh the seen event")
+	assert.Nil(b.UpdateOnce("c", 0, 1.0))
+
+	assert.Equal(ErrDuplicateUpdate, b.UpdateOnce("a", 0, 1.0), "should remember the recent events")
+	assert.Nil(b.UpdateOnce("b", 0, 1.0), "should forget the least recent event beyond the window")
+	assert.Equal([]int{4}, b.GetCounts())
+}
+
+func TestEpsilonGreedy_UpdateOnceRestoreCheckpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(1))
+	b.DedupWindow = 2
+
+	assert.Nil(b.UpdateOnce("a", 0, 1.0))
+	assert.Nil(b.UpdateOnce("b", 0, 1.0))
+	checkpoint := b.CheckpointState()
+	assert.Nil(b.UpdateOnce("c", 0, 1.0))
+
+	assert.Nil(b.RestoreCheckpoint(checkpoint))
+	assert.Nil(b.UpdateOnce("c", 0, 1.0), "should apply the rolled back event again")
+	assert.Equal(ErrDuplicateUpdate, b.UpdateOnce("b", 0, 1.0), "should remember the events of the checkpoint")
+	assert.Nil(b.UpdateOnce("a", 0, 1.0), "should keep the order of the events of the checkpoint")
+	assert.Equal([]int{4}, b.GetCounts())
+}
@@ -0,0 +1,42 @@
+package bandit
+
+// ArmDiff represents the difference in the stats of an arm between two
+// bandits
+type ArmDiff struct {
+	Index      int     `json:"index"`
+	CountDelta int     `json:"count_delta"`
+	MeanDelta  float64 `json:"mean_delta"`
+}
+
+// Diff returns the per-arm differences of b relative to a, e.g. a positive
+// CountDelta means b has seen more pulls of the arm than a
+func Diff(a, b *EpsilonGreedy) ([]ArmDiff, error) {
+	// NOTE: Each bandit is copied separately to avoid holding both locks
+	aCounts, aRewards := a.stats()
+	bCounts, bRewards := b.stats()
+	if len(aCounts) != len(bCounts) {
+		return nil, ErrArmsMismatch
+	}
+
+	diffs := make([]ArmDiff, len(aCounts))
+	for i := range diffs {
+		diffs[i] = ArmDiff{
+			Index:      i,
+			CountDelta: bCounts[i] - aCounts[i],
+			MeanDelta:  bRewards[i] - aRewards[i],
+		}
+	}
+	return diffs, nil
+}
+
+// stats returns a copy of the counts and rewards taken under a single lock
+func (b *EpsilonGreedy) stats() ([]int, []float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	counts := make([]int, len(b.Counts))
+	copy(counts, b.Counts)
+	rewards := make([]float64, len(b.Rewards))
+	copy(rewards, b.Rewards)
+	return counts, rewards
+}
@@ -0,0 +1,36 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := NewEpsilonGreedy(0.1, []int{10, 20, 30}, []float64{0.5, 0.25, 0.1})
+	assert.Nil(err)
+	b, err := NewEpsilonGreedy(0.1, []int{10, 25, 20}, []float64{0.5, 0.5, 0.2})
+	assert.Nil(err)
+
+	diffs, err := Diff(a, b)
+	assert.Nil(err)
+	assert.Equal([]ArmDiff{
+		{Index: 0, CountDelta: 0, MeanDelta: 0.0},
+		{Index: 1, CountDelta: 5, MeanDelta: 0.25},
+		{Index: 2, CountDelta: -10, MeanDelta: 0.1},
+	}, diffs, "should return the per-arm differences")
+}
+
+func TestDiff_WithMismatchedArms(t *testing.T) {
+	assert := assert.New(t)
+
+	a, err := NewEpsilonGreedy(0.1, []int{1, 2}, []float64{0.1, 0.2})
+	assert.Nil(err)
+	b, err := NewEpsilonGreedy(0.1, []int{1}, []float64{0.1})
+	assert.Nil(err)
+
+	_, err = Diff(a, b)
+	assert.Equal(ErrArmsMismatch, err, "should throw error when arms differ")
+}
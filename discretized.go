@@ -0,0 +1,76 @@
+package bandit
+
+// Discretized adapts a discrete bandit to a continuous action in the range
+// of min to max, by splitting the range into buckets of equal width
+type Discretized struct {
+	Bandit  Bandit
+	Min     float64
+	Max     float64
+	Buckets int
+}
+
+// SelectAction returns the midpoint of the bucket chosen by the underlying
+// bandit
+func (d *Discretized) SelectAction(probability float64) (float64, error) {
+	bucket, err := d.Bandit.SelectArm(probability)
+	if err != nil {
+		return 0, err
+	}
+	return d.Min + (float64(bucket)+0.5)*d.width(), nil
+}
+
+// Update will update the bucket that the action falls into with some reward
+// value
+func (d *Discretized) Update(action, reward float64) error {
+	bucket, err := d.Bucket(action)
+	if err != nil {
+		return err
+	}
+	return d.Bandit.Update(bucket, reward)
+}
+
+// Bucket returns the bucket that the action falls into. The max of the range
+// belongs to the last bucket.
+func (d *Discretized) Bucket(action float64) (int, error) {
+	if action < d.Min || action > d.Max {
+		return -1, ErrActionOutOfRange
+	}
+
+	bucket := int((action - d.Min) / d.width())
+	if bucket >= d.Buckets {
+		bucket = d.Buckets - 1
+	}
+	return bucket, nil
+}
+
+// Boundaries returns the edges of the buckets, from min to max
+func (d *Discretized) Boundaries() []float64 {
+	boundaries := make([]float64, d.Buckets+1)
+	for i := range boundaries {
+		boundaries[i] = d.Min + float64(i)*d.width()
+	}
+	boundaries[d.Buckets] = d.Max
+	return boundaries
+}
+
+func (d *Discretized) width() float64 {
+	return (d.Max - d.Min) / float64(d.Buckets)
+}
+
+// NewDiscretized returns a pointer to the Discretized struct, and initialises
+// the bandit with one arm per bucket
+func NewDiscretized(b Bandit, min, max float64, buckets int) (*Discretized, error) {
+	if min >= max {
+		return nil, ErrInvalidRange
+	}
+	if err := b.Init(buckets); err != nil {
+		return nil, err
+	}
+
+	return &Discretized{
+		Bandit:  b,
+		Min:     min,
+		Max:     max,
+		Buckets: buckets,
+	}, nil
+}
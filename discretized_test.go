@@ -0,0 +1,77 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDiscretized(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		min     float64
+		max     float64
+		buckets int
+		err     error
+	}{
+		{0.5, 2.0, 3, nil},
+		{0.5, 0.5, 3, ErrInvalidRange},
+		{2.0, 0.5, 3, ErrInvalidRange},
+		{0.5, 2.0, 0, ErrInvalidArms},
+	}
+
+	for i, tt := range tests {
+		b, _ := NewEpsilonGreedy(0.1, nil, nil)
+		_, err := NewDiscretized(b, tt.min, tt.max, tt.buckets)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestDiscretized_Bucket(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	d, err := NewDiscretized(b, 0.5, 2.0, 3)
+	assert.Nil(err)
+	assert.Equal([]float64{0.5, 1.0, 1.5, 2.0}, d.Boundaries(), "should split the range evenly")
+
+	tests := []struct {
+		action float64
+		bucket int
+		err    error
+	}{
+		{0.4, -1, ErrActionOutOfRange},
+		{0.5, 0, nil},
+		{0.99, 0, nil},
+		{1.0, 1, nil},
+		{1.5, 2, nil},
+		{2.0, 2, nil},
+		{2.1, -1, ErrActionOutOfRange},
+	}
+
+	for _, tt := range tests {
+		bucket, err := d.Bucket(tt.action)
+		assert.Equal(tt.err, err, "should throw the correct error for action %v", tt.action)
+		assert.Equal(tt.bucket, bucket, "should map action %v to the correct bucket", tt.action)
+	}
+}
+
+func TestDiscretized_SelectActionAndUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.0, nil, nil)
+	d, err := NewDiscretized(b, 0.5, 2.0, 3)
+	assert.Nil(err)
+
+	err = d.Update(2.0, 1.0)
+	assert.Nil(err)
+	assert.Equal([]int{0, 0, 1}, b.GetCounts(), "should update the last bucket")
+
+	action, err := d.SelectAction(1.0)
+	assert.Nil(err)
+	assert.Equal(1.75, action, "should select the midpoint of the best bucket")
+
+	err = d.Update(0.0, 1.0)
+	assert.Equal(ErrActionOutOfRange, err, "should throw error for invalid action")
+}
@@ -0,0 +1,70 @@
+package bandit
+
+import "slices"
+
+// DistinctTracker records the distinct rewards of each arm, up to Limit per
+// arm, e.g. to check the reward wiring when the rewards are categorical like 0,
+// 1 and 2. An arm seeing more than Limit distinct rewards overflows, which
+// suggests continuous rewards. It is guarded by the lock of the bandit it
+// belongs to.
+type DistinctTracker struct {
+	Limit int
+
+	values     [][]float64
+	overflowed []bool
+}
+
+// observe records a reward of the arm, starting over when the number of arms
+// changed
+func (d *DistinctTracker) observe(arm, nArms int, reward float64) {
+	if len(d.values) != nArms {
+		d.values = make([][]float64, nArms)
+		d.overflowed = make([]bool, nArms)
+	}
+
+	i, found := slices.BinarySearch(d.values[arm], reward)
+	if found {
+		return
+	}
+	if len(d.values[arm]) >= d.Limit {
+		d.overflowed[arm] = true
+		return
+	}
+	d.values[arm] = slices.Insert(d.values[arm], i, reward)
+}
+
+// DistinctRewards returns the distinct rewards the arm received before any
+// RewardTransform, in increasing order, which is nil without Distinct or
+// before any reward. Once the arm overflowed, only the first Limit distinct
+// rewards are kept.
+func (b *EpsilonGreedy) DistinctRewards(arm int) []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.Distinct == nil || arm < 0 || arm >= len(b.Distinct.values) {
+		return nil
+	}
+	return slices.Clone(b.Distinct.values[arm])
+}
+
+// DistinctOverflowed returns whether the arm received more than Limit distinct
+// rewards of Distinct
+func (b *EpsilonGreedy) DistinctOverflowed(arm int) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.Distinct == nil || arm < 0 || arm >= len(b.Distinct.overflowed) {
+		return false
+	}
+	return b.Distinct.overflowed[arm]
+}
+
+// NewDistinctTracker returns a pointer to the DistinctTracker struct keeping
+// up to limit distinct rewards per arm
+func NewDistinctTracker(limit int) (*DistinctTracker, error) {
+	if limit < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &DistinctTracker{Limit: limit}, nil
+}
@@ -0,0 +1,50 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDistinctTracker(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		limit int
+		err   error
+	}{
+		{8, nil},
+		{1, nil},
+		{0, ErrInvalidSize},
+	}
+
+	for i, tt := range tests {
+		_, err := NewDistinctTracker(tt.limit)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_DistinctRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	assert.Nil(b.DistinctRewards(0), "should be off by default")
+
+	var err error
+	b.Distinct, err = NewDistinctTracker(3)
+	assert.Nil(err)
+
+	for _, reward := range []float64{2, 0, 1, 1, 0, 2, 2} {
+		assert.Nil(b.Update(0, reward))
+	}
+	for _, reward := range []float64{0.12, 0.5, 0.31, 0.77} {
+		assert.Nil(b.Update(1, reward))
+	}
+
+	assert.Equal([]float64{0, 1, 2}, b.DistinctRewards(0), "should record the categories")
+	assert.False(b.DistinctOverflowed(0))
+	assert.Equal([]float64{0.12, 0.31, 0.5}, b.DistinctRewards(1), "should cap the distinct rewards")
+	assert.True(b.DistinctOverflowed(1), "should flag continuous rewards")
+	assert.Nil(b.DistinctRewards(2))
+}
@@ -0,0 +1,45 @@
+package bandit
+
+import "math"
+
+// ArmDivergence returns the Kullback-Leibler divergence KL(first || second) of
+// the Bernoulli reward distributions with the mean rewards of the two arms,
+// e.g. to group similar arms in experiment design. It is zero for equal means
+// and grows as they separate, and is infinite when second has a mean of 0 or
+// 1 that first does not share. Both arms must have rewards, and means in
+// range 0 to 1.
+func (b *EpsilonGreedy) ArmDivergence(first, second int) (float64, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.initialized() {
+		return 0, ErrNotInitialized
+	}
+	means := make([]float64, 2)
+	for i, arm := range []int{first, second} {
+		if arm < 0 || arm >= len(b.Rewards) {
+			return 0, &ArmIndexError{Index: arm, NumArms: len(b.Rewards)}
+		}
+		arm = b.canonical(arm)
+		if b.observations(arm) == 0 {
+			return 0, ErrInsufficientData
+		}
+		if !(b.Rewards[arm] >= 0 && b.Rewards[arm] <= 1) {
+			return 0, ErrInvalidProbability
+		}
+		means[i] = b.Rewards[arm]
+	}
+	return bernoulliKL(means[0], means[1]), nil
+}
+
+// bernoulliKL returns the Kullback-Leibler divergence of the Bernoulli
+// distribution of mean q from the one of mean p
+func bernoulliKL(p, q float64) float64 {
+	term := func(x, y float64) float64 {
+		if x == 0 {
+			return 0
+		}
+		return x * math.Log(x/y)
+	}
+	return term(p, q) + term(1-p, 1-q)
+}
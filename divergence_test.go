@@ -0,0 +1,45 @@
+package bandit
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ArmDivergence(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{4, 4, 4, 0, 2}, []float64{0.5, 0.25, 1, 0, 1.5})
+	assert.Nil(err)
+
+	tests := []struct {
+		first    int
+		second   int
+		expected float64
+	}{
+		{0, 0, 0},
+		{0, 1, 0.5*math.Log(2) + 0.5*math.Log(2.0/3)},
+		{1, 0, 0.25*math.Log(0.5) + 0.75*math.Log(1.5)},
+		{2, 0, math.Log(2)},
+		{0, 2, math.Inf(1)},
+	}
+
+	for i, tt := range tests {
+		divergence, err := b.ArmDivergence(tt.first, tt.second)
+		assert.Nil(err)
+		if math.IsInf(tt.expected, 1) {
+			assert.True(math.IsInf(divergence, 1), "should be infinite for test %d", i+1)
+			continue
+		}
+		assert.InDelta(tt.expected, divergence, 1e-12, "should match the analytic divergence for test %d", i+1)
+	}
+
+	_, err = b.ArmDivergence(0, 3)
+	assert.Equal(ErrInsufficientData, err, "should throw error for an unplayed arm")
+	_, err = b.ArmDivergence(4, 0)
+	assert.Equal(ErrInvalidProbability, err, "should throw error for a mean above one")
+	_, err = b.ArmDivergence(0, 5)
+	assert.True(errors.Is(err, ErrArmsIndexOutOfRange))
+}
@@ -0,0 +1,69 @@
+package bandit
+
+// DriftDetector compares the mean of the last Window rewards of each arm
+// against its long-run mean, and calls OnDrift once when the recent mean drops
+// by more than Fraction of the long-run mean, e.g. when a broken reward feed
+// starts sending zeros. It fires again only after the arm has recovered, and
+// never with a Window below one. It is guarded by the lock of the bandit it
+// belongs to.
+type DriftDetector struct {
+	Window   int
+	Fraction float64
+	OnDrift  func(arm int, recentMean, longRunMean float64)
+
+	recent   [][]float64
+	next     []int
+	drifting []bool
+}
+
+// observe records the reward of an arm, and returns the callback to run when
+// the arm starts drifting
+func (d *DriftDetector) observe(arm, nArms int, reward, longRunMean float64) func() {
+	if d.Window < 1 {
+		return nil
+	}
+	if len(d.recent) != nArms {
+		d.recent = make([][]float64, nArms)
+		d.next = make([]int, nArms)
+		d.drifting = make([]bool, nArms)
+	}
+
+	if len(d.recent[arm]) < d.Window {
+		d.recent[arm] = append(d.recent[arm], reward)
+	} else {
+		d.recent[arm][d.next[arm]] = reward
+	}
+	d.next[arm] = (d.next[arm] + 1) % d.Window
+	if len(d.recent[arm]) < d.Window {
+		return nil
+	}
+
+	recentMean := sumFloat64(d.recent[arm]...) / float64(d.Window)
+	drifting := recentMean < longRunMean*(1-d.Fraction)
+	started := drifting && !d.drifting[arm]
+	d.drifting[arm] = drifting
+	if !started || d.OnDrift == nil {
+		return nil
+	}
+
+	onDrift := d.OnDrift
+	return func() {
+		onDrift(arm, recentMean, longRunMean)
+	}
+}
+
+// NewDriftDetector returns a pointer to the DriftDetector struct
+func NewDriftDetector(window int, fraction float64, onDrift func(arm int, recentMean, longRunMean float64)) (*DriftDetector, error) {
+	if window < 1 {
+		return nil, ErrInvalidSize
+	}
+	if fraction < 0 || fraction > 1 {
+		return nil, ErrInvalidFraction
+	}
+
+	return &DriftDetector{
+		Window:   window,
+		Fraction: fraction,
+		OnDrift:  onDrift,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDriftDetector(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		window   int
+		fraction float64
+		err      error
+	}{
+		{10, 0.5, nil},
+		{0, 0.5, ErrInvalidSize},
+		{10, -0.1, ErrInvalidFraction},
+		{10, 1.1, ErrInvalidFraction},
+	}
+
+	for _, tt := range tests {
+		_, err := NewDriftDetector(tt.window, tt.fraction, nil)
+		assert.Equal(tt.err, err, "should throw the correct error")
+	}
+}
+
+func TestEpsilonGreedy_UpdateWithDrift(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	type drift struct {
+		arm         int
+		recentMean  float64
+		longRunMean float64
+	}
+	var drifts []drift
+	b.Drift, err = NewDriftDetector(10, 0.5, func(arm int, recentMean, longRunMean float64) {
+		// Calling back into the bandit must not deadlock
+		b.GetRewards()
+		drifts = append(drifts, drift{arm, recentMean, longRunMean})
+	})
+	assert.Nil(err)
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(0, 1.0))
+		assert.Nil(b.Update(1, float64(i%2)))
+	}
+	assert.Empty(drifts, "should not fire while the rewards are stable")
+
+	// The reward feed breaks and sends zeros
+	for i := 0; i < 20; i++ {
+		assert.Nil(b.Update(0, 0.0))
+	}
+	assert.Equal(1, len(drifts), "should fire once")
+	assert.Equal(0, drifts[0].arm, "should report the drifting arm")
+	assert.True(drifts[0].recentMean < drifts[0].longRunMean*0.5, "should report the means")
+
+	// The feed recovers, then breaks again
+	for i := 0; i < 20; i++ {
+		assert.Nil(b.Update(0, 1.0))
+	}
+	for i := 0; i < 20; i++ {
+		assert.Nil(b.Update(0, 0.0))
+	}
+	assert.Equal(2, len(drifts), "should fire again after recovering")
+}
+
+func TestEpsilonGreedy_DriftZeroWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(1))
+	var drifts int
+	b.Drift = &DriftDetector{Fraction: 0.5, OnDrift: func(int, float64, float64) { drifts++ }}
+
+	for _, reward := range []float64{1, 1, 0, 0} {
+		assert.Nil(b.Update(0, reward))
+	}
+	assert.Equal(0, drifts, "should never fire without a window")
+	assert.Equal([]int{4}, b.GetCounts())
+}
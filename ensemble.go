@@ -0,0 +1,172 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// Ensemble holds several independently seeded copies of a bandit, and selects
+// with epsilon greedy over the means averaged across the members, e.g. to
+// stabilize the decisions on noisy rewards. Every member is offered each
+// reward, and with Bootstrap a member learns from it a Poisson(1) number of
+// times, as in online bagging, so that the members of deterministic
+// algorithms disagree.
+type Ensemble struct {
+	sync.RWMutex
+	Members []Bandit
+	Epsilon float64
+
+	// Bootstrap, when set, weighs each reward of each member by a Poisson(1)
+	// draw instead of once
+	Bootstrap bool
+
+	// Rand is used for the exploration and the bootstrap, and defaults to the
+	// math/rand source
+	Rand Rand
+
+	counts []int
+}
+
+// Init will initialise the members with the provided number of arms, and
+// start the members that can start over, e.g. EpsilonGreedy, from scratch
+func (e *Ensemble) Init(nArms int) error {
+	e.Lock()
+	defer e.Unlock()
+
+	for _, member := range e.Members {
+		if err := resetBandit(member, nArms); err != nil {
+			return err
+		}
+	}
+	e.counts = make([]int, nArms)
+	return nil
+}
+
+// SelectArm exploits the arm with the highest averaged mean when probability
+// is above Epsilon, and otherwise explores an arm uniformly at random
+func (e *Ensemble) SelectArm(probability float64) (int, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	rewards := e.averagedRewards()
+	if len(rewards) == 0 {
+		return -1, ErrNotInitialized
+	}
+	if e.Epsilon == 0 || probability > e.Epsilon {
+		return max(rewards...), nil
+	}
+	return randIntn(e.Rand, len(rewards)), nil
+}
+
+// EffectiveEpsilon returns the exploration rate the next selection uses, which
+// is the fixed Epsilon
+func (e *Ensemble) EffectiveEpsilon() float64 {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.Epsilon
+}
+
+// Update will offer the reward of an arm to every member
+func (e *Ensemble) Update(chosenArm int, reward float64) error {
+	e.Lock()
+	defer e.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(e.counts) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(e.counts)}
+	}
+	for _, member := range e.Members {
+		times := 1
+		if e.Bootstrap {
+			times = e.poisson()
+		}
+		for i := 0; i < times; i++ {
+			if err := member.Update(chosenArm, reward); err != nil {
+				return err
+			}
+		}
+	}
+	e.counts[chosenArm]++
+	return nil
+}
+
+// poisson draws from the Poisson distribution with a mean of 1
+func (e *Ensemble) poisson() int {
+	limit := math.Exp(-1)
+	k, p := 0, randFloat64(e.Rand)
+	for p > limit {
+		k++
+		p *= randFloat64(e.Rand)
+	}
+	return k
+}
+
+// averagedRewards returns the mean of each arm averaged over the members that
+// have pulled it
+func (e *Ensemble) averagedRewards() []float64 {
+	rewards := make([]float64, len(e.counts))
+	members := make([]int, len(e.counts))
+	for _, member := range e.Members {
+		counts, means := member.GetCounts(), member.GetRewards()
+		for i := range rewards {
+			if i < len(counts) && i < len(means) && counts[i] > 0 {
+				members[i]++
+				rewards[i] += (means[i] - rewards[i]) / float64(members[i])
+			}
+		}
+	}
+	return rewards
+}
+
+// MemberCount returns the number of members
+func (e *Ensemble) MemberCount() int {
+	e.RLock()
+	defer e.RUnlock()
+
+	return len(e.Members)
+}
+
+// MemberStats returns the counts and rewards of a member
+func (e *Ensemble) MemberStats(member int) ([]int, []float64, error) {
+	e.RLock()
+	defer e.RUnlock()
+
+	if member < 0 || member >= len(e.Members) {
+		return nil, nil, ErrMemberOutOfRange
+	}
+	return e.Members[member].GetCounts(), e.Members[member].GetRewards(), nil
+}
+
+// GetCounts returns the number of rewards offered to the members for each arm
+func (e *Ensemble) GetCounts() []int {
+	e.RLock()
+	defer e.RUnlock()
+
+	sCopy := make([]int, len(e.counts))
+	copy(sCopy, e.counts)
+	return sCopy
+}
+
+// GetRewards returns the means averaged over the members
+func (e *Ensemble) GetRewards() []float64 {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.averagedRewards()
+}
+
+// NewEnsemble returns a pointer to the Ensemble struct over the members, which
+// should be seeded independently, e.g. each with its own Rand
+func NewEnsemble(epsilon float64, members ...Bandit) (*Ensemble, error) {
+	if epsilon < 0 || epsilon > 1 {
+		return nil, ErrInvalidEpsilon
+	}
+	if len(members) == 0 {
+		return nil, ErrInvalidSize
+	}
+
+	return &Ensemble{
+		Members: members,
+		Epsilon: epsilon,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEnsemble(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewEnsemble(0.1)
+	assert.Equal(ErrInvalidSize, err)
+	member, _ := NewEpsilonGreedy(0.1, nil, nil)
+	_, err = NewEnsemble(2, member)
+	assert.Equal(ErrInvalidEpsilon, err)
+
+	e, err := NewEnsemble(0.1, member)
+	assert.Nil(err)
+	_, err = e.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Equal(ErrInvalidArms, e.Init(0))
+	assert.Nil(e.Init(2))
+	assert.True(errors.Is(e.Update(2, 1), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidReward, e.Update(0, -1), "should throw the errors of the members")
+	_, _, err = e.MemberStats(1)
+	assert.Equal(ErrMemberOutOfRange, err)
+}
+
+func TestEnsemble_Update(t *testing.T) {
+	assert := assert.New(t)
+
+	members := make([]Bandit, 3)
+	for i := range members {
+		member, _ := NewEpsilonGreedy(0.1, nil, nil)
+		member.Rand = rand.New(rand.NewSource(int64(i)))
+		members[i] = member
+	}
+	e, err := NewEnsemble(0, members...)
+	assert.Nil(err)
+	assert.Nil(e.Init(2))
+	assert.Equal(3, e.MemberCount())
+
+	assert.Nil(e.Update(0, 1))
+	assert.Nil(e.Update(1, 0.5))
+	for i := 0; i < e.MemberCount(); i++ {
+		counts, rewards, err := e.MemberStats(i)
+		assert.Nil(err)
+		assert.Equal([]int{1, 1}, counts, "should update every member")
+		assert.Equal([]float64{1, 0.5}, rewards)
+	}
+	assert.Equal([]int{1, 1}, e.GetCounts())
+
+	arm, err := e.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should exploit the highest averaged mean")
+}
+
+func TestEnsemble_Bootstrap(t *testing.T) {
+	assert := assert.New(t)
+
+	members := make([]Bandit, 5)
+	for i := range members {
+		members[i], _ = NewEpsilonGreedy(0.1, nil, nil)
+	}
+	e, err := NewEnsemble(0.1, members...)
+	assert.Nil(err)
+	e.Bootstrap = true
+	e.Rand = rand.New(rand.NewSource(1))
+	assert.Nil(e.Init(2))
+
+	env, err := NewBernoulliEnv([]float64{0.4, 0.6}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	for i := 0; i < 200; i++ {
+		arm := i % 2
+		assert.Nil(e.Update(arm, env.Pull(arm)))
+	}
+
+	var average float64
+	means := make(map[float64]bool)
+	for i := 0; i < e.MemberCount(); i++ {
+		counts, rewards, err := e.MemberStats(i)
+		assert.Nil(err)
+		assert.InDelta(100, counts[1], 40, "should weigh the rewards around once")
+		average += rewards[1] / float64(e.MemberCount())
+		means[rewards[1]] = true
+	}
+	assert.Greater(len(means), 1, "should bootstrap the members apart")
+	assert.InDelta(average, e.GetRewards()[1], 1e-9, "should average the means of the members")
+	assert.Equal([]int{100, 100}, e.GetCounts())
+}
+
+func TestEnsemble_InitResetsMembers(t *testing.T) {
+	assert := assert.New(t)
+
+	eg, _ := NewEpsilonGreedy(0.1, nil, nil)
+	ucb, _ := NewUCB(nil, nil)
+	e, err := NewEnsemble(0.1, eg, ucb)
+	assert.Nil(err)
+	assert.Nil(e.Init(2))
+	assert.Nil(e.Update(0, 1))
+
+	assert.Nil(e.Init(2))
+	assert.Equal([]int{0, 0}, eg.GetCounts(), "should start the epsilon greedy member over")
+	assert.Equal([]int{0, 0}, ucb.GetCounts())
+}
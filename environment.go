@@ -0,0 +1,147 @@
+package bandit
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Environment represents the arms a bandit plays against in a simulation
+type Environment interface {
+	// Pull returns the reward of pulling the arm
+	Pull(arm int) float64
+	NumArms() int
+
+	// OptimalMean returns the mean reward of the best arm, which is the
+	// baseline of the regret
+	OptimalMean() float64
+}
+
+// NormRand represents a source of random numbers that also draws from the
+// standard normal distribution, e.g. *rand.Rand
+type NormRand interface {
+	Rand
+	NormFloat64() float64
+}
+
+// BernoulliArm represents an arm that pays a reward of 1 with probability P,
+// and 0 otherwise
+type BernoulliArm struct {
+	P float64
+}
+
+// Draw returns the reward of pulling the arm, drawn from r. A nil r defaults
+// to the math/rand source.
+func (a BernoulliArm) Draw(r *rand.Rand) float64 {
+	draw := rand.Float64
+	if r != nil {
+		draw = r.Float64
+	}
+	if draw() < a.P {
+		return 1.0
+	}
+	return 0.0
+}
+
+// NewBernoulliArm returns a BernoulliArm paying with probability p in range 0
+// to 1
+func NewBernoulliArm(p float64) (BernoulliArm, error) {
+	if !(p >= 0 && p <= 1) {
+		return BernoulliArm{}, ErrInvalidProbability
+	}
+	return BernoulliArm{P: p}, nil
+}
+
+// BernoulliEnv represents arms that pay a reward of 1 with their probability,
+// and 0 otherwise
+type BernoulliEnv struct {
+	Probabilities []float64
+
+	// Rand draws the rewards, and defaults to the math/rand source
+	Rand Rand
+}
+
+// Pull returns the reward of pulling the arm
+func (e *BernoulliEnv) Pull(arm int) float64 {
+	if randFloat64(e.Rand) < e.Probabilities[arm] {
+		return 1.0
+	}
+	return 0.0
+}
+
+// NumArms returns the number of arms
+func (e *BernoulliEnv) NumArms() int {
+	return len(e.Probabilities)
+}
+
+// OptimalMean returns the highest probability
+func (e *BernoulliEnv) OptimalMean() float64 {
+	return e.Probabilities[max(e.Probabilities...)]
+}
+
+// NewBernoulliEnv returns a pointer to the BernoulliEnv struct
+func NewBernoulliEnv(probabilities []float64, rnd Rand) (*BernoulliEnv, error) {
+	if len(probabilities) == 0 {
+		return nil, ErrInvalidArms
+	}
+	for _, p := range probabilities {
+		if p < 0 || p > 1 {
+			return nil, ErrInvalidProbability
+		}
+	}
+
+	return &BernoulliEnv{
+		Probabilities: probabilities,
+		Rand:          rnd,
+	}, nil
+}
+
+// GaussianEnv represents arms that pay normally distributed rewards. The
+// bandits reject negative rewards, so the means should be a few standard
+// deviations above zero.
+type GaussianEnv struct {
+	Means   []float64
+	StdDevs []float64
+
+	// Rand draws the rewards, and defaults to the math/rand source
+	Rand NormRand
+}
+
+// Pull returns the reward of pulling the arm
+func (e *GaussianEnv) Pull(arm int) float64 {
+	draw := rand.NormFloat64
+	if e.Rand != nil {
+		draw = e.Rand.NormFloat64
+	}
+	return e.Means[arm] + e.StdDevs[arm]*draw()
+}
+
+// NumArms returns the number of arms
+func (e *GaussianEnv) NumArms() int {
+	return len(e.Means)
+}
+
+// OptimalMean returns the highest mean
+func (e *GaussianEnv) OptimalMean() float64 {
+	return e.Means[max(e.Means...)]
+}
+
+// NewGaussianEnv returns a pointer to the GaussianEnv struct
+func NewGaussianEnv(means, stdDevs []float64, rnd NormRand) (*GaussianEnv, error) {
+	if len(means) != len(stdDevs) {
+		return nil, ErrInvalidLength
+	}
+	if len(means) == 0 {
+		return nil, ErrInvalidArms
+	}
+	for _, stdDev := range stdDevs {
+		if stdDev < 0 || math.IsNaN(stdDev) {
+			return nil, ErrInvalidDeviation
+		}
+	}
+
+	return &GaussianEnv{
+		Means:   means,
+		StdDevs: stdDevs,
+		Rand:    rnd,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package bandit
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBernoulliEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		probabilities []float64
+		err           error
+	}{
+		{[]float64{0.1, 0.9}, nil},
+		{[]float64{0, 1}, nil},
+		{nil, ErrInvalidArms},
+		{[]float64{0.1, 1.1}, ErrInvalidProbability},
+		{[]float64{-0.1}, ErrInvalidProbability},
+	}
+
+	for _, tt := range tests {
+		env, err := NewBernoulliEnv(tt.probabilities, nil)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(len(tt.probabilities), env.NumArms(), "should have every arm")
+		}
+	}
+}
+
+func TestBernoulliEnv_Pull(t *testing.T) {
+	assert := assert.New(t)
+
+	env, err := NewBernoulliEnv([]float64{0.1, 0.8, 0.5}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	assert.Equal(0.8, env.OptimalMean(), "should return the best probability")
+
+	pulls := 10000
+	for arm, p := range env.Probabilities {
+		var total float64
+		for i := 0; i < pulls; i++ {
+			reward := env.Pull(arm)
+			assert.True(reward == 0 || reward == 1, "should pay zero or one")
+			total += reward
+		}
+		assert.InDelta(p, total/float64(pulls), 0.02, "arm %d should pay with its probability", arm)
+	}
+}
+
+func TestBernoulliArm(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		p   float64
+		err error
+	}{
+		{0, nil},
+		{0.3, nil},
+		{1, nil},
+		{-0.1, ErrInvalidProbability},
+		{1.1, ErrInvalidProbability},
+		{math.NaN(), ErrInvalidProbability},
+	}
+	for _, tt := range tests {
+		arm, err := NewBernoulliArm(tt.p)
+		assert.Equal(tt.err, err, "p %v", tt.p)
+		if err == nil {
+			assert.Equal(tt.p, arm.P)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	arm := BernoulliArm{P: 0.3}
+	var total float64
+	for i := 0; i < 10000; i++ {
+		total += arm.Draw(r)
+	}
+	assert.InDelta(0.3, total/10000, 0.02, "should pay with its probability")
+	assert.Equal(0.0, BernoulliArm{P: 0}.Draw(nil))
+	assert.Equal(1.0, BernoulliArm{P: 1}.Draw(nil))
+}
+
+func TestNewGaussianEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		means   []float64
+		stdDevs []float64
+		err     error
+	}{
+		{[]float64{1, 2}, []float64{0.1, 0}, nil},
+		{nil, nil, ErrInvalidArms},
+		{[]float64{1, 2}, []float64{0.1}, ErrInvalidLength},
+		{[]float64{1}, []float64{-0.1}, ErrInvalidDeviation},
+	}
+
+	for _, tt := range tests {
+		env, err := NewGaussianEnv(tt.means, tt.stdDevs, nil)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(len(tt.means), env.NumArms(), "should have every arm")
+		}
+	}
+}
+
+func TestGaussianEnv_Pull(t *testing.T) {
+	assert := assert.New(t)
+
+	env, err := NewGaussianEnv([]float64{5, 10, 0}, []float64{1, 2, 0}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	assert.Equal(10.0, env.OptimalMean(), "should return the best mean")
+
+	pulls := 10000
+	for arm, mean := range env.Means {
+		var total, squares float64
+		for i := 0; i < pulls; i++ {
+			reward := env.Pull(arm)
+			total += reward
+			squares += reward * reward
+		}
+		sampleMean := total / float64(pulls)
+		variance := squares/float64(pulls) - sampleMean*sampleMean
+		assert.InDelta(mean, sampleMean, 0.1, "arm %d should pay around its mean", arm)
+		assert.InDelta(env.StdDevs[arm]*env.StdDevs[arm], variance, 0.2, "arm %d should pay with its deviation", arm)
+	}
+}
@@ -1,8 +1,10 @@
 package bandit
 
 import (
-	"math/rand"
+	"log/slog"
+	"math"
 	"sync"
+	"time"
 )
 
 // EpsilonGreedy represents the epsilon greedy algorithm
@@ -11,9 +13,260 @@ type EpsilonGreedy struct {
 	Epsilon float64   `json:"epsilon"`
 	Counts  []int     `json:"counts"`
 	Rewards []float64 `json:"values"`
+
+	// Observations holds the number of rewards averaged into the mean of each
+	// arm, which falls behind Counts by the pulls recorded without a reward
+	Observations []int `json:"observations,omitempty"`
+
+	// M2 holds the running sum of squared deviations from the mean of each
+	// arm, used to estimate the reward variance
+	M2 []float64 `json:"m2,omitempty"`
+
+	// M2Counts holds the number of rewards of each arm accumulated into M2
+	// since it began tracking them, which falls behind the observations when
+	// M2 was zero-filled, e.g. for a state created from counts and rewards
+	// alone or migrated from version 1
+	M2Counts []int `json:"m2_counts,omitempty"`
+
+	// StableMean updates the means with Welford's online algorithm, which
+	// keeps its precision over long-lived arms and enables GetVariances
+	StableMean bool `json:"stable_mean,omitempty"`
+
+	// AnchorWeight, when positive, keeps the initial reward of each arm in its
+	// mean as a pseudo-observation of that weight, so that optimistic initial
+	// rewards blend with the first real rewards instead of being overwritten
+	AnchorWeight float64 `json:"anchor_weight,omitempty"`
+
+	// TieBreak chooses among the arms tied for the highest mean when
+	// exploiting, and defaults to TieLowest. The other policies scan the arms
+	// for ties, even with IndexBest.
+	TieBreak TieBreak `json:"tie_break,omitempty"`
+
+	// IndexBest keeps the arms in a heap by their mean, so that exploiting
+	// among all the arms takes constant time instead of a scan, for bandits
+	// with many arms. The counts and rewards must then only change through
+	// the methods of the bandit.
+	IndexBest bool `json:"index_best,omitempty"`
+
+	// Weights holds the sum of the confidence weights of the rewards of each
+	// arm, and SquaredWeights the sum of their squares, once any reward was
+	// weighted by UpdateWithConfidence. Unweighted rewards weigh one.
+	Weights        []float64 `json:"weights,omitempty"`
+	SquaredWeights []float64 `json:"squared_weights,omitempty"`
+
+	// Precisions holds the sum of the precisions, 1/variance, of the rewards
+	// of each arm reported by UpdateWithVariance, and PrecisionCounts their
+	// number
+	Precisions      []float64 `json:"precisions,omitempty"`
+	PrecisionCounts []int     `json:"precision_counts,omitempty"`
+
+	bestIndex bestIndex
+	meanIndex bestIndex
+
+	// SignedRewards accepts negative rewards, e.g. refunds or complaints as
+	// penalties that decrease the mean of an arm, instead of rejecting them
+	SignedRewards bool `json:"signed_rewards,omitempty"`
+
+	// RewardTransform is applied to every reward before it is validated and
+	// averaged, e.g. math.Log1p for revenue. Rewards are unchanged when nil.
+	RewardTransform func(raw float64) float64 `json:"-"`
+
+	// Disabled marks the arms that are excluded from selection while keeping
+	// their counts and rewards
+	Disabled []bool `json:"disabled,omitempty"`
+
+	// Aliases maps the arms pooled by AliasArm to their canonical index
+	Aliases map[int]int `json:"aliases,omitempty"`
+
+	// JitterFraction is the maximum relative jitter that was applied to the
+	// epsilon at construction
+	JitterFraction float64 `json:"jitter_fraction,omitempty"`
+
+	// Frozen stops the bandit from learning, while selection keeps working
+	// from the current state
+	Frozen bool `json:"frozen,omitempty"`
+
+	// MinPulls is the number of pulls an arm needs before its mean is
+	// trusted by ArmsAbove
+	MinPulls int `json:"min_pulls,omitempty"`
+
+	// SelectionCount is the number of selections made, which is the time
+	// step of the epsilon schedule
+	SelectionCount int `json:"selection_count,omitempty"`
+
+	// ObjectiveWeights combines the objectives of UpdateMulti into the reward
+	// used for selection. Objectives without a weight count as zero.
+	ObjectiveWeights map[string]float64 `json:"objective_weights,omitempty"`
+
+	// ObjectiveMeans holds the running mean of each objective per arm, and
+	// ObjectiveCounts the number of multi-objective updates per arm
+	ObjectiveMeans  map[string][]float64 `json:"objective_means,omitempty"`
+	ObjectiveCounts []int                `json:"objective_counts,omitempty"`
+
+	// Cooldown is the number of selections following the selection of an arm
+	// during which the arm is not selected again
+	Cooldown int `json:"cooldown,omitempty"`
+
+	// CooldownUntil holds the selection count at which each arm is eligible
+	// again
+	CooldownUntil []int `json:"cooldown_until,omitempty"`
+
+	// Costs holds the cost of serving each arm. When set, exploitation
+	// maximises the reward per unit cost.
+	Costs []float64 `json:"costs,omitempty"`
+
+	// PositionBias holds the examination probability of each position of a
+	// ranked display, set by SetPositionBias, and defaults to 1/(position+1)
+	PositionBias []float64 `json:"position_bias,omitempty"`
+
+	// SimilarityMatrix holds the similarity between each pair of arms, set by
+	// SetSimilarityMatrix, with which every reward is shared
+	SimilarityMatrix [][]float64 `json:"similarity_matrix,omitempty"`
+
+	// Smoothing is the factor of the exponential moving average applied to
+	// the selection probabilities across rounds, in the range 0 to 1. Zero
+	// disables smoothing, and one follows the policy without delay.
+	Smoothing float64 `json:"smoothing,omitempty"`
+
+	// Smoothed holds the smoothed selection probabilities of each arm
+	Smoothed []float64 `json:"smoothed,omitempty"`
+
+	// Schedule, when set, replaces the fixed Epsilon with an annealed one
+	Schedule Schedule `json:"-"`
+
+	// Reservoir, when set, keeps a uniform sample of the updates
+	Reservoir *Reservoir `json:"-"`
+
+	// Drift, when set, watches for the recent rewards of an arm dropping
+	// below its long-run mean
+	Drift *DriftDetector `json:"-"`
+
+	// BestWindow, when set, watches for the best arm over the recent rewards
+	// changing
+	BestWindow *BestArmWindow `json:"-"`
+
+	// Realized, when set, estimates quantiles of the rewards received across
+	// all the arms, and is off by default
+	Realized *RewardQuantiles `json:"-"`
+
+	// Watchdog, when set, reports the reward feed as unhealthy once no reward
+	// arrived for its timeout
+	Watchdog *FeedWatchdog `json:"-"`
+
+	// Distinct, when set, records the distinct rewards of each arm up to its
+	// limit
+	Distinct *DistinctTracker `json:"-"`
+
+	// Credit, when set, credits the rewards to windows of their selection
+	// time
+	Credit *CreditWindows `json:"-"`
+
+	// Trace, when set, keeps the last decisions for debugging
+	Trace *DecisionTrace `json:"-"`
+
+	// Winsor, when set, caps the rewards of an arm at a high percentile of
+	// its recent rewards
+	Winsor *Winsorizer `json:"-"`
+
+	// Rand is used for exploration, and defaults to the math/rand source
+	Rand Rand `json:"-"`
+
+	// BackoffFactor scales down the exploration of an arm for every
+	// consecutive zero reward in ZeroStreaks, and zero disables the backoff
+	BackoffFactor float64 `json:"backoff_factor,omitempty"`
+	ZeroStreaks   []int   `json:"zero_streaks,omitempty"`
+
+	// ExplorePrior, when set, returns a score per arm, e.g. from a
+	// recommender model, and exploration draws the arms in proportion to the
+	// scores instead of uniformly. It is called on every selection under the
+	// lock, so it must not call the bandit.
+	ExplorePrior func() []float64 `json:"-"`
+
+	prior []float64
+
+	// InstanceID, when set, identifies the instance in a fleet, and is mixed
+	// into the exploration draws so that instances with identical seeds do
+	// not explore the same arms in lockstep. It belongs to the instance, so it
+	// is neither marshalled nor restored from a checkpoint.
+	InstanceID string `json:"-"`
+
+	// ExploreFloor, when greater than zero, is the minimum probability of
+	// exploring each enabled arm, and raises the epsilon in use to
+	// ExploreFloor times the number of enabled arms, capped at 1. Adding arms
+	// then increases the total exploration, trading exploitation for keeping
+	// every arm learning, so the floor should stay well below 1/K for K arms.
+	ExploreFloor float64 `json:"explore_floor,omitempty"`
+
+	// ExploitBonus, when greater than zero, exploits the arm with the highest
+	// mean plus ExploitBonus/sqrt(n) for n rewards, nudging the exploitation
+	// toward the less pulled arms, with the unplayed arms first
+	ExploitBonus float64 `json:"exploit_bonus,omitempty"`
+
+	// Laplace, when greater than zero, exploits the arms by their Laplace
+	// smoothed means (mean·n + Laplace)/(n + 2·Laplace), which pulls the
+	// means of rarely pulled Bernoulli arms toward 0.5
+	Laplace float64 `json:"laplace,omitempty"`
+
+	// BestArmSamples is the number of samples drawn by ProbabilityBestIsBest,
+	// and defaults to 1000
+	BestArmSamples int `json:"best_arm_samples,omitempty"`
+
+	// DedupWindow is the number of recent event IDs remembered by UpdateOnce,
+	// and defaults to 1024
+	DedupWindow int `json:"dedup_window,omitempty"`
+
+	seen dedup
+
+	// ReportEvery is the number of updates between the calls of OnReport
+	ReportEvery int `json:"report_every,omitempty"`
+
+	// OnReport is called with a snapshot of the stats every ReportEvery
+	// updates
+	OnReport func(Metrics) `json:"-"`
+
+	sinceReport int
+
+	// OnAddArm and OnRemoveArm are called with the index of the arm added by
+	// AddArm or removed by RemoveArm, once the lock is released
+	OnAddArm    func(index int) `json:"-"`
+	OnRemoveArm func(index int) `json:"-"`
+
+	// Targets holds the cumulative reward of each arm after which it retires
+	// from selection, where zero is no target, and Earned holds the
+	// cumulative reward of each arm since the targets were set
+	Targets []float64 `json:"targets,omitempty"`
+	Earned  []float64 `json:"earned,omitempty"`
+
+	// OnTargetReached is called with the arm and its cumulative reward when it
+	// reaches its target, once the lock is released
+	OnTargetReached func(arm int, earned float64) `json:"-"`
+
+	// DefaultArm, with UseDefaultArm, is the arm SelectArm returns when there
+	// is no arm to choose from, i.e. before Init or while every arm is
+	// excluded, instead of an error
+	DefaultArm    int  `json:"default_arm,omitempty"`
+	UseDefaultArm bool `json:"use_default_arm,omitempty"`
+
+	usedDefault bool
+
+	// explanation holds the context of the most recent selection, once
+	// explained
+	explanation Explanation
+	explained   bool
+
+	// scratch and tied are reused by the selection under the lock, so that
+	// selecting among eligible or tied arms does not allocate
+	scratch []int
+	tied    []int
+
+	logger *slog.Logger
 }
 
-// Init will initialise the counts and rewards with the provided number of arms
+// Init will initialise the counts and rewards with the provided number of
+// arms. It is safe to call concurrently, e.g. for lazy setup: once the bandit
+// is initialized, Init keeps its state for the same number of arms, and
+// returns ErrAlreadyInitialized for another one rather than discarding it.
+// Reset starts over instead.
 func (b *EpsilonGreedy) Init(nArms int) error {
 	b.Lock()
 	defer b.Unlock()
@@ -21,46 +274,477 @@ func (b *EpsilonGreedy) Init(nArms int) error {
 	if nArms < 1 {
 		return ErrInvalidArms
 	}
+	if b.initialized() {
+		if nArms == len(b.Rewards) {
+			return nil
+		}
+		return ErrAlreadyInitialized
+	}
+	return b.reset(nArms)
+}
+
+// Reset will initialise the counts and rewards with the provided number of
+// arms like Init, discarding the state of an initialized bandit, including
+// its objectives, targets and the event IDs seen by UpdateOnce
+func (b *EpsilonGreedy) Reset(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	return b.reset(nArms)
+}
+
+// reset initialises the arms under the lock held by the caller
+func (b *EpsilonGreedy) reset(nArms int) error {
 	b.Counts = make([]int, nArms)
 	b.Rewards = make([]float64, nArms)
+	b.invalidateBest()
+	b.Observations = make([]int, nArms)
+	b.M2 = make([]float64, nArms)
+	b.M2Counts = make([]int, nArms)
+	b.Weights = nil
+	b.SquaredWeights = nil
+	b.Precisions = nil
+	b.PrecisionCounts = nil
+	b.Disabled = nil
+	b.Aliases = nil
+	b.Smoothed = nil
+	b.SelectionCount = 0
+	b.CooldownUntil = nil
+	b.ZeroStreaks = nil
+	b.ObjectiveMeans = nil
+	b.ObjectiveCounts = nil
+	b.Targets = nil
+	b.Earned = nil
+	b.seen = dedup{}
+	b.explained = false
 	return nil
 }
 
 // SelectArm chooses an arm that exploits if the value is more than the epsilon
-// threshold, and explore if the value is less than epsilon
-func (b *EpsilonGreedy) SelectArm(probability float64) int {
-	b.RLock()
-	defer b.RUnlock()
+// threshold, and explore if the value is less than epsilon. Disabled arms are
+// never selected.
+func (b *EpsilonGreedy) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	d, err := b.selectArm(probability)
+	d, b.usedDefault, err = b.fallback(d, err)
+	if err == nil && !b.usedDefault {
+		b.record(d)
+	}
+	var stale func()
+	if b.Watchdog != nil {
+		stale = b.Watchdog.check()
+	}
+	logger := b.logger
+	b.Unlock()
+
+	// NOTE: The stale callback runs without the lock so it can call back into
+	// the bandit
+	if stale != nil {
+		stale()
+	}
+	return selected(logger, d, err)
+}
+
+// record applies the selection to the state under the lock
+func (b *EpsilonGreedy) record(d decision) {
+	b.startCooldown(d.arm)
+	b.SelectionCount++
+	if b.Trace != nil {
+		b.Trace.add(d)
+	}
+	b.explain(d)
+}
+
+// selected logs a successful selection once the lock is released, and
+// returns the selected arm
+func selected(logger *slog.Logger, d decision, err error) (int, error) {
+	if err != nil {
+		return -1, err
+	}
+	if logger != nil {
+		logger.Debug("bandit: select arm", "arm", d.arm, "explored", d.explored, "epsilon", d.epsilon, "propensity", d.propensity)
+	}
+	return d.arm, nil
+}
+
+// decision describes how an arm was selected. Exploring can land on the arm
+// that exploiting would select, so explored does not imply that the arm is
+// suboptimal, and the propensity of the exploit arm is epsilon/K + 1-epsilon
+// on both branches, for K arms explored uniformly.
+type decision struct {
+	arm        int
+	explored   bool
+	epsilon    float64
+	propensity float64
+}
+
+// propensity returns the probability of the policy selecting the arm, where
+// exploit is the probability of exploiting the arm and share the probability
+// of exploring it
+func propensity(exploit, share, epsilon float64) float64 {
+	return epsilon*share + (1-epsilon)*exploit
+}
+
+func (b *EpsilonGreedy) selectArm(probability float64) (decision, error) {
+	if !b.initialized() {
+		return decision{}, ErrNotInitialized
+	}
+	if err := b.loadPrior(); err != nil {
+		return decision{}, err
+	}
+	epsilon := b.epsilon()
+
+	// With a single arm there is nothing to explore
+	if len(b.Rewards) == 1 && !b.hasDisabled() {
+		return decision{arm: 0, epsilon: epsilon, propensity: 1}, nil
+	}
+
+	if b.Smoothing > 0 {
+		return b.selectSmoothed(probability)
+	}
+
+	// NOTE: A zero epsilon never explores, even for a zero probability, so
+	// the exploit-only path does not draw random numbers
+	exploit := epsilon == 0 || probability > epsilon
+
+	// NOTE: A nil slice of eligible arms stands for all the arms
+	var eligible []int
+	if b.hasDisabled() || len(b.Aliases) > 0 || b.Cooldown > 0 {
+		eligible = b.eligibleArms()
+		if len(eligible) == 0 {
+			return decision{}, ErrNoEligibleArms
+		}
+	}
+
+	best := b.bestArm(eligible)
+	ties := b.ties(best, eligible)
 
 	// Exploit
-	if probability > b.Epsilon {
-		return maxMean(b.Counts, b.Rewards)
+	if exploit {
+		arm := b.exploitArm(best, ties)
+		return decision{arm: arm, epsilon: epsilon, propensity: propensity(exploitShare(arm, best, ties), b.exploreShare(arm, eligible), epsilon)}, nil
 	}
 
 	// Explore
-	return rand.Intn(len(b.Rewards))
+	arm := b.explore(eligible)
+	return decision{arm: arm, explored: true, epsilon: epsilon, propensity: propensity(exploitShare(arm, best, ties), b.exploreShare(arm, eligible), epsilon)}, nil
+}
+
+// GetSelectionCount returns the number of selections made, which can run
+// ahead of the sum of the counts while updates are delayed
+func (b *EpsilonGreedy) GetSelectionCount() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.SelectionCount
+}
+
+// ArmsAbove returns the indices of the arms whose mean reward exceeds the
+// threshold, ignoring arms with fewer than MinPulls pulls
+func (b *EpsilonGreedy) ArmsAbove(threshold float64) []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	var arms []int
+	for i, count := range b.Counts {
+		if count == 0 || count < b.MinPulls {
+			continue
+		}
+		if b.Rewards[i] > threshold {
+			arms = append(arms, i)
+		}
+	}
+	return arms
+}
+
+// Freeze stops any further learning, so that Update returns ErrFrozen without
+// changing the state
+func (b *EpsilonGreedy) Freeze() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.Frozen = true
+}
+
+// Unfreeze resumes learning
+func (b *EpsilonGreedy) Unfreeze() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.Frozen = false
+}
+
+// IsFrozen returns true when the bandit is frozen
+func (b *EpsilonGreedy) IsFrozen() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Frozen
+}
+
+// Disable excludes an arm from selection without removing its counts and
+// rewards
+func (b *EpsilonGreedy) Disable(arm int) error {
+	return b.setDisabled(arm, true)
+}
+
+// Enable makes a disabled arm eligible for selection again
+func (b *EpsilonGreedy) Enable(arm int) error {
+	return b.setDisabled(arm, false)
+}
+
+// EnabledArms returns the indices of the arms eligible for selection
+func (b *EpsilonGreedy) EnabledArms() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.enabledArms()
+}
+
+func (b *EpsilonGreedy) setDisabled(arm int, disabled bool) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.initialized() {
+		return ErrNotInitialized
+	}
+	if arm < 0 || arm >= len(b.Rewards) {
+		return &ArmIndexError{Index: arm, NumArms: len(b.Rewards)}
+	}
+	if len(b.Disabled) != len(b.Rewards) {
+		b.Disabled = make([]bool, len(b.Rewards))
+	}
+	b.Disabled[arm] = disabled
+	return nil
+}
+
+func (b *EpsilonGreedy) hasDisabled() bool {
+	if len(b.Disabled) != len(b.Rewards) {
+		return false
+	}
+	for _, disabled := range b.Disabled {
+		if disabled {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *EpsilonGreedy) enabledArms() []int {
+	return b.appendEnabled(make([]int, 0, len(b.Rewards)))
+}
+
+// appendEnabled appends the indices of the enabled arms to arms
+func (b *EpsilonGreedy) appendEnabled(arms []int) []int {
+	for i := range b.Rewards {
+		if len(b.Disabled) == len(b.Rewards) && b.Disabled[i] || b.isAlias(i) {
+			continue
+		}
+		arms = append(arms, i)
+	}
+	return arms
+}
+
+// initialized returns whether the bandit has arms, which the zero value
+// lacks until Init is called
+func (b *EpsilonGreedy) initialized() bool {
+	return len(b.Rewards) > 0
+}
+
+func (b *EpsilonGreedy) intn(n int) int {
+	return randIntn(b.Rand, n)
+}
+
+func (b *EpsilonGreedy) float64() float64 {
+	return randFloat64(b.Rand)
+}
+
+// EffectiveEpsilon returns the exploration rate the next selection uses,
+// which is the epsilon of the Schedule at the current time step when set
+func (b *EpsilonGreedy) EffectiveEpsilon() float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.epsilon()
+}
+
+// epsilon returns the exploration rate currently in use
+func (b *EpsilonGreedy) epsilon() float64 {
+	epsilon := b.Epsilon
+	if b.Schedule != nil {
+		epsilon = b.Schedule.Epsilon(timeStep(b.SelectionCount, b.Counts))
+	}
+	if b.ExploreFloor > 0 {
+		epsilon = math.Min(math.Max(epsilon, b.ExploreFloor*float64(b.enabledCount())), 1)
+	}
+	return epsilon
+}
+
+// enabledCount returns the number of enabled arms without allocating
+func (b *EpsilonGreedy) enabledCount() int {
+	n := 0
+	for i := range b.Rewards {
+		if len(b.Disabled) == len(b.Rewards) && b.Disabled[i] || b.isAlias(i) {
+			continue
+		}
+		n++
+	}
+	return n
 }
 
 // Update will update an arm with some reward value,
 // e.g. click = 1, no click = 0
 func (b *EpsilonGreedy) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	callbacks, err := b.update(chosenArm, reward)
+	logger := b.logger
+	b.Unlock()
+
+	return b.updated(logger, chosenArm, reward, callbacks, err)
+}
+
+// updated logs a successful update and runs its callbacks, once the lock is
+// released
+func (b *EpsilonGreedy) updated(logger *slog.Logger, chosenArm int, reward float64, callbacks []func(), err error) error {
+	if err == nil && logger != nil {
+		logger.Debug("bandit: update", "arm", chosenArm, "reward", reward)
+	}
+
+	// NOTE: Callbacks run without the lock so they can call back into the
+	// bandit
+	for _, callback := range callbacks {
+		callback()
+	}
+	return err
+}
+
+// WithLogger sets the logger that receives a debug record for every selection
+// and update. A nil logger disables logging.
+func (b *EpsilonGreedy) WithLogger(logger *slog.Logger) *EpsilonGreedy {
 	b.Lock()
 	defer b.Unlock()
 
+	b.logger = logger
+	return b
+}
+
+// update applies the reward under the lock, and returns the callbacks to run
+// once the lock is released
+func (b *EpsilonGreedy) update(chosenArm int, reward float64) ([]func(), error) {
+	return b.updateAt(chosenArm, reward, 1, time.Time{})
+}
+
+// updateAt updates an arm selected at some time, where the zero time is the
+// time of the update
+func (b *EpsilonGreedy) updateAt(chosenArm int, reward, weight float64, selectedAt time.Time) ([]func(), error) {
+	if !b.initialized() {
+		return nil, ErrNotInitialized
+	}
+	if b.Frozen {
+		return nil, ErrFrozen
+	}
+
+	raw := reward
+	if b.RewardTransform != nil {
+		reward = b.RewardTransform(reward)
+	}
 	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
-		return ErrArmsIndexOutOfRange
+		return nil, &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if err := validateReward(reward, b.SignedRewards); err != nil {
+		return nil, err
 	}
-	if reward < 0 {
-		return ErrInvalidReward
+	chosenArm = b.canonical(chosenArm)
+	if b.Winsor != nil {
+		reward = b.Winsor.winsorize(chosenArm, len(b.Rewards), reward)
 	}
 
+	b.ensureObservations()
 	b.Counts[chosenArm]++
-	n := float64(b.Counts[chosenArm])
+	b.Observations[chosenArm]++
+	n := float64(b.Observations[chosenArm])
 
 	oldRewards := b.Rewards[chosenArm]
-	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
+	switch {
+	case b.AnchorWeight > 0:
+		total := n
+		if weight != 1 || len(b.Weights) == len(b.Rewards) {
+			total = b.addWeight(chosenArm, weight)
+		}
+		b.Rewards[chosenArm] += weight / (total + b.AnchorWeight) * (reward - oldRewards)
+	case weight != 1 || len(b.Weights) == len(b.Rewards):
+		b.Rewards[chosenArm] += weight / b.addWeight(chosenArm, weight) * (reward - oldRewards)
+	case b.StableMean:
+		b.Rewards[chosenArm] += (reward - oldRewards) / n
+	default:
+		b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
+	}
 
-	return nil
+	b.ensureM2()
+	b.M2[chosenArm] += weight * (reward - oldRewards) * (reward - b.Rewards[chosenArm])
+	b.M2Counts[chosenArm]++
+	b.observeStreak(chosenArm, reward)
+	b.fixBest(chosenArm)
+	b.shareReward(chosenArm, reward, weight)
+
+	if b.Reservoir != nil {
+		b.Reservoir.add(chosenArm, raw, b.intn)
+	}
+	if b.Realized != nil {
+		b.Realized.observe(raw)
+	}
+	if b.Distinct != nil {
+		b.Distinct.observe(chosenArm, len(b.Rewards), raw)
+	}
+	if b.Watchdog != nil {
+		b.Watchdog.fed()
+	}
+	if b.Credit != nil {
+		b.Credit.add(chosenArm, len(b.Rewards), reward, selectedAt)
+	}
+
+	var callbacks []func()
+	if callback := b.earn(chosenArm, reward); callback != nil {
+		callbacks = append(callbacks, callback)
+	}
+	if b.Drift != nil {
+		if callback := b.Drift.observe(chosenArm, len(b.Rewards), reward, b.Rewards[chosenArm]); callback != nil {
+			callbacks = append(callbacks, callback)
+		}
+	}
+	if b.BestWindow != nil {
+		if callback := b.BestWindow.observe(chosenArm, len(b.Rewards), reward); callback != nil {
+			callbacks = append(callbacks, callback)
+		}
+	}
+	if callback := b.report(); callback != nil {
+		callbacks = append(callbacks, callback)
+	}
+	return callbacks, nil
+}
+
+// GetVariances returns the sample variance of the rewards of each arm, which
+// is zero for arms with fewer than two rewards
+func (b *EpsilonGreedy) GetVariances() ([]float64, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.StableMean {
+		return nil, ErrVarianceDisabled
+	}
+	variances := make([]float64, len(b.Rewards))
+	if len(b.M2) != len(b.Rewards) {
+		return variances, nil
+	}
+	for i := range variances {
+		if n := b.observations(i); n > 1 {
+			variances[i] = b.M2[i] / b.varianceDenominator(i)
+		}
+	}
+	return variances, nil
 }
 
 // GetCounts returns the counts
@@ -73,6 +757,15 @@ func (b *EpsilonGreedy) GetCounts() []int {
 	return sCopy
 }
 
+// GetTotalCounts returns the total number of pulls of all the arms, which is
+// summed as int64 so it does not overflow on 32-bit platforms
+func (b *EpsilonGreedy) GetTotalCounts() int64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return totalCounts(b.Counts)
+}
+
 // GetRewards returns the rewards
 func (b *EpsilonGreedy) GetRewards() []float64 {
 	b.RLock()
@@ -83,6 +776,105 @@ func (b *EpsilonGreedy) GetRewards() []float64 {
 	return sCopy
 }
 
+// Snapshot returns copies of the counts and the rewards with the epsilon in
+// use, all taken under one read lock, so that they are consistent with each
+// other unlike separate calls of GetCounts and GetRewards
+func (b *EpsilonGreedy) Snapshot() ([]int, []float64, float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	counts := make([]int, len(b.Counts))
+	copy(counts, b.Counts)
+	rewards := make([]float64, len(b.Rewards))
+	copy(rewards, b.Rewards)
+	return counts, rewards, b.epsilon()
+}
+
+// SetCounts replaces the counts with a copy of counts, which must have one
+// non-negative count per arm. Every pull is assumed to be rewarded.
+func (b *EpsilonGreedy) SetCounts(counts []int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(counts) != len(b.Counts) {
+		return ErrInvalidLength
+	}
+	for _, count := range counts {
+		if count < 0 {
+			return ErrInvalidCount
+		}
+	}
+	copy(b.Counts, counts)
+	b.invalidateBest()
+	b.Observations = nil
+	b.Weights = nil
+	b.SquaredWeights = nil
+	b.Precisions = nil
+	b.PrecisionCounts = nil
+	return nil
+}
+
+// SetRewards replaces the rewards with a copy of rewards, which must have one
+// non-negative mean reward per arm, or any finite one with SignedRewards.
+// Unlike with Update, a NaN mean is rejected either way.
+func (b *EpsilonGreedy) SetRewards(rewards []float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(rewards) != len(b.Rewards) {
+		return ErrInvalidLength
+	}
+	for _, reward := range rewards {
+		if math.IsNaN(reward) {
+			return ErrInvalidReward
+		}
+		if err := validateReward(reward, b.SignedRewards); err != nil {
+			return err
+		}
+	}
+	copy(b.Rewards, rewards)
+	b.invalidateBest()
+	return nil
+}
+
+// ForEachArm calls fn with the count and mean of every arm under the read
+// lock, and stops early when fn returns false. fn must not call back into the
+// bandit, since an update waiting on the lock deadlocks it.
+func (b *EpsilonGreedy) ForEachArm(fn func(index int, count int, mean float64) bool) {
+	b.RLock()
+	defer b.RUnlock()
+
+	for i, count := range b.Counts {
+		if !fn(i, count, b.Rewards[i]) {
+			return
+		}
+	}
+}
+
+// CopyCounts copies the counts into dst without allocating, and returns the
+// number of arms copied
+func (b *EpsilonGreedy) CopyCounts(dst []int) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Counts) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Counts), nil
+}
+
+// CopyRewards copies the rewards into dst without allocating, and returns the
+// number of arms copied
+func (b *EpsilonGreedy) CopyRewards(dst []float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Rewards) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Rewards), nil
+}
+
 // NewEpsilonGreedy returns a pointer to the EpsilonGreedy struct
 func NewEpsilonGreedy(epsilon float64, counts []int, rewards []float64) (*EpsilonGreedy, error) {
 	if epsilon < 0 || epsilon > 1 {
@@ -98,3 +890,23 @@ func NewEpsilonGreedy(epsilon float64, counts []int, rewards []float64) (*Epsilo
 		Counts:  counts,
 	}, nil
 }
+
+// NewJitteredEpsilonGreedy returns a pointer to the EpsilonGreedy struct with
+// the epsilon moved randomly by up to jitter times its value, so that identical
+// instances do not explore in lockstep. The jitter is drawn once from rnd,
+// which is also used for exploration, and the result is kept in range 0 to 1.
+func NewJitteredEpsilonGreedy(epsilon, jitter float64, rnd Rand, counts []int, rewards []float64) (*EpsilonGreedy, error) {
+	if jitter < 0 || jitter > 1 {
+		return nil, ErrInvalidJitter
+	}
+	b, err := NewEpsilonGreedy(epsilon, counts, rewards)
+	if err != nil {
+		return nil, err
+	}
+	b.Rand = rnd
+	b.JitterFraction = jitter
+
+	u := 2*b.float64() - 1
+	b.Epsilon = math.Max(0, math.Min(1, epsilon*(1+u*jitter)))
+	return b, nil
+}
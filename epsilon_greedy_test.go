@@ -1,6 +1,11 @@
 package bandit
 
 import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,10 +45,13 @@ func TestEpsilonGreedy_SelectArm(t *testing.T) {
 	b, err := NewEpsilonGreedy(0.1, nil, nil)
 	assert.Nil(err)
 	b.Init(3)
-	arm := b.SelectArm(0.1)
+	b.Rand = rand.New(rand.NewSource(1))
+	arm, err := b.SelectArm(0.1)
+	assert.Nil(err)
 	assert.Equal(2, arm, "arm should be equal last item")
 	b.Update(arm, 1.0)
-	arm = b.SelectArm(1)
+	arm, err = b.SelectArm(1)
+	assert.Nil(err)
 	assert.Equal(2, arm, "should select the best arm")
 }
 func TestEpsilonInit_WithValidParams(t *testing.T) {
@@ -58,7 +66,7 @@ func TestEpsilonInit_WithValidParams(t *testing.T) {
 		expectedRewards []float64
 	}{
 		{0.1, nil, nil, 3, []int{0, 0, 0}, []float64{0.0, 0.0, 0.0}},
-		{0.1, []int{1, 2, 3}, []float64{1.0, 2.0, 3.0}, 3, []int{0, 0, 0}, []float64{0.0, 0.0, 0.0}},
+		{0.1, []int{1, 2, 3}, []float64{1.0, 2.0, 3.0}, 3, []int{1, 2, 3}, []float64{1.0, 2.0, 3.0}},
 	}
 
 	for _, tt := range tests {
@@ -70,15 +78,17 @@ func TestEpsilonInit_WithValidParams(t *testing.T) {
 
 		assert.Equal(tt.arms, len(b.Counts), "should have length of %d", tt.arms)
 		assert.Equal(tt.arms, len(b.Rewards), "should have length of %d", tt.arms)
-		assert.Equal(tt.expectedCounts, b.Counts, "should be initialized to zero values")
-		assert.Equal(tt.expectedRewards, b.Rewards, "should be initialized to zero values")
+		assert.Equal(tt.expectedCounts, b.Counts, "should be initialized to zero values or kept")
+		assert.Equal(tt.expectedRewards, b.Rewards, "should be initialized to zero values or kept")
+
+		assert.Nil(b.Reset(tt.arms))
+		assert.Equal(make([]int, tt.arms), b.Counts, "should reset to zero values")
+		assert.Equal(make([]float64, tt.arms), b.Rewards, "should reset to zero values")
 	}
 }
 
 func TestEpsilonInit_WithInvalidParams(t *testing.T) {
 	assert := assert.New(t)
-	b, err := NewEpsilonGreedy(0.1, nil, nil)
-	assert.Nil(err)
 
 	tests := []struct {
 		arms int
@@ -93,6 +103,8 @@ func TestEpsilonInit_WithInvalidParams(t *testing.T) {
 		{-100, true},
 	}
 	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0.1, nil, nil)
+		assert.Nil(err)
 		err = b.Init(tt.arms)
 		if tt.err {
 			assert.Equal(ErrInvalidArms, err, "should throw error when arms length is invalid")
@@ -103,6 +115,67 @@ func TestEpsilonInit_WithInvalidParams(t *testing.T) {
 	}
 }
 
+func TestEpsilonInit_AlreadyInitialized(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.Update(1, 1))
+
+	assert.Nil(b.Init(3), "should accept the same number of arms")
+	assert.Equal([]int{0, 1, 0}, b.GetCounts(), "should keep the state")
+	assert.Equal(ErrAlreadyInitialized, b.Init(4), "should throw error for another number of arms")
+	assert.Equal([]int{0, 1, 0}, b.GetCounts(), "should not discard the state")
+	assert.Equal(ErrInvalidArms, b.Reset(0))
+	assert.Nil(b.Reset(4))
+	assert.Equal([]int{0, 0, 0, 0}, b.GetCounts(), "should start over on Reset")
+
+	assert.Nil(b.SetTargets([]float64{1, 1, 1, 1}))
+	assert.Nil(b.UpdateMulti(0, map[string]float64{"clicks": 1}))
+	assert.Nil(b.UpdateOnce("event", 0, 1))
+	assert.Nil(b.Reset(4))
+	assert.Nil(b.RemainingBudget(), "should drop the targets on Reset")
+	assert.Nil(b.ObjectiveMeans, "should drop the objectives on Reset")
+	assert.Nil(b.UpdateOnce("event", 0, 1), "should forget the event IDs on Reset")
+}
+
+func TestEpsilonInit_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	for run := 0; run < 20; run++ {
+		b, err := NewEpsilonGreedy(0.1, nil, nil)
+		assert.Nil(err)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 8)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = b.Init(3 + i%2)
+				if errs[i] == nil {
+					errs[i] = b.Update(0, 1)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		nArms := len(b.GetCounts())
+		assert.Contains([]int{3, 4}, nArms)
+		succeeded := 0
+		for i, err := range errs {
+			if 3+i%2 == nArms {
+				assert.Nil(err, "should initialize once with the same number of arms")
+				succeeded++
+			} else {
+				assert.Equal(ErrAlreadyInitialized, err, "should reject another number of arms")
+			}
+		}
+		assert.Equal(succeeded, b.GetCounts()[0], "should keep every update of the single initialization")
+	}
+}
+
 func TestEpsilonUpdate_Independent(t *testing.T) {
 	assert := assert.New(t)
 
@@ -173,3 +246,698 @@ func TestUpdate_Continuous(t *testing.T) {
 	}
 
 }
+
+func TestEpsilonUpdate_RewardTransform(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	b.RewardTransform = math.Log1p
+
+	tests := []struct {
+		chosenArm       int
+		reward          float64
+		expectedRewards float64
+	}{
+		{0, math.E - 1, 1.0},
+		{0, math.Exp(2) - 1, 1.5},
+		{0, math.Exp(3) - 1, 2.0},
+	}
+
+	for i, tt := range tests {
+		err = b.Update(tt.chosenArm, tt.reward)
+		assert.Nil(err)
+		assert.InDelta(tt.expectedRewards, b.Rewards[tt.chosenArm], 1e-9, "mean should reflect the transformed rewards for test %d", i+1)
+	}
+
+	b.RewardTransform = func(raw float64) float64 { return raw - 10 }
+	err = b.Update(1, 1.0)
+	assert.Equal(ErrInvalidReward, err, "should validate the transformed reward")
+}
+
+func TestEpsilonGreedy_CopyCountsAndRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{1, 2, 3}, []float64{0.1, 0.2, 0.3})
+	assert.Nil(err)
+
+	tests := []struct {
+		size int
+		n    int
+		err  error
+	}{
+		{0, 0, ErrBufferTooSmall},
+		{2, 0, ErrBufferTooSmall},
+		{3, 3, nil},
+		{5, 3, nil},
+	}
+
+	for i, tt := range tests {
+		counts := make([]int, tt.size)
+		n, err := b.CopyCounts(counts)
+		assert.Equal(tt.err, err, "should return the correct error for test %d", i+1)
+		assert.Equal(tt.n, n, "should copy the counts for test %d", i+1)
+
+		rewards := make([]float64, tt.size)
+		n, err = b.CopyRewards(rewards)
+		assert.Equal(tt.err, err, "should return the correct error for test %d", i+1)
+		assert.Equal(tt.n, n, "should copy the rewards for test %d", i+1)
+
+		if tt.err == nil {
+			assert.Equal(b.GetCounts(), counts[:n], "counts should be equal")
+			assert.Equal(b.GetRewards(), rewards[:n], "rewards should be equal")
+		}
+	}
+}
+
+func BenchmarkEpsilonGreedy_GetCounts(b *testing.B) {
+	bandit, _ := NewEpsilonGreedy(0.1, nil, nil)
+	bandit.Init(10)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bandit.GetCounts()
+	}
+}
+
+func BenchmarkEpsilonGreedy_CopyCounts(b *testing.B) {
+	bandit, _ := NewEpsilonGreedy(0.1, nil, nil)
+	bandit.Init(10)
+	dst := make([]int, 10)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bandit.CopyCounts(dst)
+	}
+}
+
+func BenchmarkEpsilonGreedy_CopyRewards(b *testing.B) {
+	bandit, _ := NewEpsilonGreedy(0.1, nil, nil)
+	bandit.Init(10)
+	dst := make([]float64, 10)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bandit.CopyRewards(dst)
+	}
+}
+
+func BenchmarkEpsilonGreedy_SelectArm(b *testing.B) {
+	bandit, _ := NewEpsilonGreedy(0.1, nil, nil)
+	bandit.Init(10)
+	for i := 0; i < 10; i++ {
+		bandit.Update(i, float64(i)/10)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bandit.SelectArm(float64(i%100) / 100)
+	}
+}
+
+func TestEpsilonGreedy_SelectArmAllocs(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name  string
+		setup func(b *EpsilonGreedy)
+	}{
+		{"default", func(b *EpsilonGreedy) {}},
+		{"disabled", func(b *EpsilonGreedy) { b.Disable(0) }},
+		{"cooldown", func(b *EpsilonGreedy) { b.Cooldown = 2 }},
+		{"costs", func(b *EpsilonGreedy) { b.SetCosts([]float64{1, 2, 3, 4}) }},
+		{"backoff", func(b *EpsilonGreedy) { b.SetBackoff(0.5) }},
+		{"alias", func(b *EpsilonGreedy) { b.AliasArm(1, 0) }},
+		{"tie random", func(b *EpsilonGreedy) { b.TieBreak = TieRandom; b.SetRewards([]float64{1, 1, 1, 0}) }},
+		{"tie most pulled", func(b *EpsilonGreedy) { b.TieBreak = TieMostPulled; b.SetRewards([]float64{1, 1, 1, 0}) }},
+	}
+
+	for _, test := range tests {
+		b, _ := NewEpsilonGreedy(0.5, nil, nil)
+		b.Init(4)
+		for i := 0; i < 4; i++ {
+			b.Update(i, float64(i)/4)
+		}
+		test.setup(b)
+
+		var i int
+		allocs := testing.AllocsPerRun(100, func() {
+			i++
+			b.SelectArm(float64(i%10) / 10)
+		})
+		assert.Zero(allocs, "should select without allocating: %s", test.name)
+	}
+}
+
+func TestEpsilonGreedy_DisableAndEnable(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(3)
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	assert.True(errors.Is(b.Disable(-1), ErrArmsIndexOutOfRange), "should throw error for invalid arm")
+	assert.True(errors.Is(b.Enable(3), ErrArmsIndexOutOfRange), "should throw error for invalid arm")
+
+	err = b.Update(2, 1.0)
+	assert.Nil(err)
+	assert.Nil(b.Disable(2))
+	assert.Equal([]int{0, 1}, b.EnabledArms(), "should exclude the disabled arm")
+	assert.Equal([]int{0, 0, 1}, b.GetCounts(), "should preserve the counts of the disabled arm")
+	assert.Equal([]float64{0.0, 0.0, 1.0}, b.GetRewards(), "should preserve the rewards of the disabled arm")
+
+	for _, probability := range []float64{0.0, 0.05, 0.5, 1.0} {
+		for i := 0; i < 100; i++ {
+			arm, err := b.SelectArm(probability)
+			assert.Nil(err)
+			assert.NotEqual(2, arm, "should never select the disabled arm")
+		}
+	}
+
+	assert.Nil(b.Enable(2))
+	assert.Equal([]int{0, 1, 2}, b.EnabledArms(), "should include the enabled arm")
+	arm, err := b.SelectArm(1.0)
+	assert.Nil(err)
+	assert.Equal(2, arm, "should exploit the re-enabled arm")
+}
+
+func TestEpsilonGreedy_SelectArmWithAllArmsDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+	assert.Nil(b.Disable(0))
+	assert.Nil(b.Disable(1))
+
+	for _, probability := range []float64{0.0, 1.0} {
+		_, err = b.SelectArm(probability)
+		assert.Equal(ErrNoEligibleArms, err, "should throw error when all arms are disabled")
+	}
+}
+
+func TestEpsilonGreedy_GetSelectionCount(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	for i := 0; i < 5; i++ {
+		_, err = b.SelectArm(1.0)
+		assert.Nil(err)
+	}
+	err = b.Update(0, 1.0)
+	assert.Nil(err)
+
+	assert.Equal(5, b.GetSelectionCount(), "should count the selections")
+	assert.Equal(1, sum(b.GetCounts()...), "should count the updates")
+
+	assert.Nil(b.Disable(0))
+	assert.Nil(b.Disable(1))
+	_, err = b.SelectArm(1.0)
+	assert.Equal(ErrNoEligibleArms, err)
+	assert.Equal(5, b.GetSelectionCount(), "should not count failed selections")
+}
+
+func TestNewJitteredEpsilonGreedy(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		epsilon float64
+		jitter  float64
+		err     error
+	}{
+		{0.1, 0.0, nil},
+		{0.1, 0.5, nil},
+		{0.1, 1.0, nil},
+		{0.1, -0.1, ErrInvalidJitter},
+		{0.1, 1.1, ErrInvalidJitter},
+		{1.1, 0.5, ErrInvalidEpsilon},
+	}
+
+	for i, tt := range tests {
+		_, err := NewJitteredEpsilonGreedy(tt.epsilon, tt.jitter, nil, nil, nil)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestNewJitteredEpsilonGreedy_EpsilonInRange(t *testing.T) {
+	assert := assert.New(t)
+
+	epsilons := make(map[float64]bool)
+	for seed := int64(0); seed < 100; seed++ {
+		for _, epsilon := range []float64{0.0, 0.1, 0.9, 1.0} {
+			b, err := NewJitteredEpsilonGreedy(epsilon, 0.5, rand.New(rand.NewSource(seed)), nil, nil)
+			assert.Nil(err)
+			assert.Equal(0.5, b.JitterFraction, "should expose the jitter fraction")
+			assert.True(b.Epsilon >= 0 && b.Epsilon <= 1, "should keep epsilon in range 0 to 1")
+			assert.True(b.Epsilon >= epsilon*0.5 && b.Epsilon <= epsilon*1.5, "should jitter by up to the fraction")
+			if epsilon == 0.1 {
+				epsilons[b.Epsilon] = true
+			}
+		}
+	}
+	assert.True(len(epsilons) > 1, "should decorrelate the epsilon across instances")
+}
+
+func TestEpsilonGreedy_ArmsAbove(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{0, 2, 10, 10, 50}, []float64{0.0, 0.9, 0.6, 0.4, 0.5})
+	assert.Nil(err)
+
+	tests := []struct {
+		minPulls  int
+		threshold float64
+		expected  []int
+	}{
+		{0, 0.5, []int{1, 2}},
+		{5, 0.5, []int{2}},
+		{5, 0.45, []int{2, 4}},
+		{5, -1.0, []int{2, 3, 4}},
+		{100, 0.0, nil},
+	}
+
+	for i, tt := range tests {
+		b.MinPulls = tt.minPulls
+		assert.Equal(tt.expected, b.ArmsAbove(tt.threshold), "should return the arms above the threshold for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_Freeze(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+	assert.Nil(b.Update(1, 1.0))
+	assert.False(b.IsFrozen(), "should not be frozen by default")
+
+	b.Freeze()
+	assert.True(b.IsFrozen(), "should be frozen")
+	for i := 0; i < 5; i++ {
+		assert.Equal(ErrFrozen, b.Update(0, 1.0), "should throw error while frozen")
+	}
+	assert.Equal([]int{0, 1}, b.GetCounts(), "should not change the counts while frozen")
+	assert.Equal([]float64{0.0, 1.0}, b.GetRewards(), "should not change the rewards while frozen")
+
+	arm, err := b.SelectArm(1.0)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should select from the frozen state")
+
+	b.Unfreeze()
+	assert.False(b.IsFrozen(), "should not be frozen")
+	assert.Nil(b.Update(0, 1.0))
+	assert.Equal([]int{1, 1}, b.GetCounts(), "should learn again")
+}
+
+func TestEpsilonGreedy_SelectArmWithZeroEpsilon(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1, 1}, []float64{0.1, 0.9, 0.5})
+	assert.Nil(err)
+
+	rnd := &countingRand{Rand: rand.New(rand.NewSource(1))}
+	b.Rand = rnd
+
+	for _, probability := range []float64{0.0, 0.5, 1.0} {
+		arm, err := b.SelectArm(probability)
+		assert.Nil(err)
+		assert.Equal(1, arm, "should always exploit the best arm")
+	}
+	assert.Equal(0, rnd.calls, "should not draw random numbers")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		b.SelectArm(0.0)
+	})
+	assert.Equal(0.0, allocs, "should not allocate")
+}
+
+// fixedRand always draws the same arm
+type fixedRand struct {
+	arm int
+}
+
+func (r fixedRand) Float64() float64 {
+	return 0
+}
+
+func (r fixedRand) Intn(n int) int {
+	return r.arm
+}
+
+func TestEpsilonGreedy_Propensity(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name        string
+		probability float64
+		drawn       int
+		disabled    int
+		arm         int
+		explored    bool
+		propensity  float64
+	}{
+		{"exploit", 0.5, 0, -1, 1, false, 0.2/3 + 0.8},
+		{"explore the best arm", 0.1, 1, -1, 1, true, 0.2/3 + 0.8},
+		{"explore another arm", 0.1, 2, -1, 2, true, 0.2 / 3},
+		{"exploit eligible arms", 0.5, 0, 2, 1, false, 0.2/2 + 0.8},
+		{"explore the best eligible arm", 0.1, 1, 2, 1, true, 0.2/2 + 0.8},
+		{"explore another eligible arm", 0.1, 0, 2, 0, true, 0.2 / 2},
+	}
+
+	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0.2, []int{1, 1, 1}, []float64{0.1, 0.9, 0.5})
+		assert.Nil(err)
+		b.Rand = fixedRand{tt.drawn}
+		if tt.disabled >= 0 {
+			assert.Nil(b.Disable(tt.disabled))
+		}
+
+		d, err := b.selectArm(tt.probability)
+		assert.Nil(err)
+		assert.Equal(tt.arm, d.arm, tt.name)
+		assert.Equal(tt.explored, d.explored, tt.name)
+		assert.InDelta(tt.propensity, d.propensity, 1e-9, "%s should include both the explore and exploit share", tt.name)
+	}
+}
+
+func TestEpsilonGreedy_StableMean(t *testing.T) {
+	assert := assert.New(t)
+
+	naive, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(naive.Init(1))
+	stable, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(stable.Init(1))
+	stable.StableMean = true
+
+	rnd := rand.New(rand.NewSource(1))
+	total := new(big.Float).SetPrec(256)
+	n := 1000000
+	for i := 0; i < n; i++ {
+		reward := rnd.Float64()
+		total.Add(total, big.NewFloat(reward))
+		assert.Nil(naive.Update(0, reward))
+		assert.Nil(stable.Update(0, reward))
+	}
+	reference, _ := new(big.Float).Quo(total, big.NewFloat(float64(n))).Float64()
+
+	naiveErr := math.Abs(naive.GetRewards()[0] - reference)
+	stableErr := math.Abs(stable.GetRewards()[0] - reference)
+	assert.Less(stableErr, naiveErr, "stable mean should be closer to the reference")
+	assert.Less(stableErr, 1e-14, "stable mean should keep its precision")
+}
+
+func TestEpsilonGreedy_AnchorWeight(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		anchorWeight float64
+		expected     []float64
+	}{
+		{0, []float64{0, 0.5}},
+		{5, []float64{5.0 / 6, 6.0 / 7}},
+	}
+
+	for i, tt := range tests {
+		// Optimistic initial rewards of 1 before any pull
+		b, err := NewEpsilonGreedy(0.1, []int{0, 0}, []float64{1, 1})
+		assert.Nil(err)
+		b.AnchorWeight = tt.anchorWeight
+		assert.Nil(b.Update(0, 0))
+		assert.Nil(b.Update(1, 0))
+		assert.Nil(b.Update(1, 1))
+		assert.InDeltaSlice(tt.expected, b.GetRewards(), 1e-9, "should blend the initial rewards for test %d", i+1)
+	}
+
+	b, err := NewEpsilonGreedy(0.1, []int{0}, []float64{1})
+	assert.Nil(err)
+	b.AnchorWeight = 2
+	assert.Nil(b.UpdateWithConfidence(0, 0, 2))
+	assert.InDelta(0.5, b.GetRewards()[0], 1e-9, "should weigh the anchor against the confidence")
+}
+
+func TestEpsilonGreedy_GetVariances(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+
+	_, err = b.GetVariances()
+	assert.Equal(ErrVarianceDisabled, err, "should throw error without the stable mean")
+
+	b.StableMean = true
+	for _, reward := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		assert.Nil(b.Update(0, reward))
+	}
+	assert.Nil(b.Update(1, 1.0))
+
+	variances, err := b.GetVariances()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{32.0 / 7, 0, 0}, variances, 1e-9, "should return the sample variance")
+	assert.Equal(5.0, b.GetRewards()[0])
+}
+
+func TestEpsilonGreedy_NotInitialized(t *testing.T) {
+	assert := assert.New(t)
+
+	var b EpsilonGreedy
+	b.Epsilon = 1.0
+
+	_, err := b.SelectArm(0.0)
+	assert.Equal(ErrNotInitialized, err)
+	_, _, err = b.SelectSlate(1)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Equal(ErrNotInitialized, b.Update(0, 1.0))
+	assert.Equal(ErrNotInitialized, b.UpdateMulti(0, map[string]float64{"click": 1}))
+	assert.Equal(ErrNotInitialized, b.RecordPull(0))
+	assert.Equal(ErrNotInitialized, b.Disable(0))
+	assert.Equal(ErrNotInitialized, b.Enable(0))
+	assert.Equal(ErrNotInitialized, b.SeedFromPrior(nil, 1))
+
+	// The read-only methods stay harmless
+	assert.Empty(b.GetCounts())
+	assert.Empty(b.GetRewards())
+	assert.Empty(b.GetObservations())
+	assert.Empty(b.EnabledArms())
+	assert.Empty(b.CoolingArms())
+	assert.Empty(b.ArmsAbove(0))
+	assert.Empty(b.Metrics().Arms)
+	assert.False(b.IsConverged(0, 1))
+	assert.Equal(0, b.GetSelectionCount())
+	n, err := b.CopyCounts(nil)
+	assert.Nil(err)
+	assert.Equal(0, n)
+
+	assert.Nil(b.Init(2))
+	_, err = b.SelectArm(0.0)
+	assert.Nil(err, "should work once initialised")
+}
+
+func TestEpsilonGreedy_ForEachArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{1, 2, 3}, []float64{0.1, 0.2, 0.4})
+	assert.Nil(err)
+
+	var total float64
+	var counts []int
+	b.ForEachArm(func(index int, count int, mean float64) bool {
+		assert.Equal(len(counts), index, "should visit the arms in order")
+		counts = append(counts, count)
+		total += mean
+		return true
+	})
+	assert.Equal(b.GetCounts(), counts)
+	assert.InDelta(sumFloat64(b.GetRewards()...), total, 1e-9, "should visit every mean")
+
+	var visited int
+	b.ForEachArm(func(index int, count int, mean float64) bool {
+		visited++
+		return index < 1
+	})
+	assert.Equal(2, visited, "should stop early")
+}
+
+func TestEpsilonGreedy_SetCountsAndRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	assert.Equal(ErrInvalidLength, b.SetCounts([]int{1}))
+	assert.Equal(ErrInvalidLength, b.SetRewards([]float64{1, 2, 3}))
+	assert.Equal(ErrInvalidReward, b.SetRewards([]float64{1, -1}))
+	assert.Equal(ErrInvalidReward, b.SetRewards([]float64{1, math.NaN()}), "should reject NaN unsigned")
+	assert.Equal(ErrInvalidCount, b.SetCounts([]int{1, -1}))
+	assert.Equal([]int{0, 0}, b.GetCounts(), "should not change the counts on error")
+	assert.Equal([]float64{0, 0}, b.GetRewards(), "should not change the rewards on error")
+
+	assert.Nil(b.RecordPull(0))
+	counts := []int{2, 3}
+	rewards := []float64{0.5, 0.25}
+	assert.Nil(b.SetCounts(counts))
+	assert.Nil(b.SetRewards(rewards))
+	assert.Equal([]int{2, 3}, b.GetObservations(), "should assume every pull was rewarded")
+
+	counts[0] = 100
+	rewards[0] = 100
+	assert.Equal([]int{2, 3}, b.GetCounts(), "should copy the counts")
+	assert.Equal([]float64{0.5, 0.25}, b.GetRewards(), "should copy the rewards")
+}
+
+func TestEpsilonGreedy_SetConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	b.Rand = rand.New(rand.NewSource(1))
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			assert.Nil(b.SetCounts([]int{i, i, i}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			assert.Nil(b.SetRewards([]float64{0.1, float64(i%2) * 0.5, 0.2}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			_, err := b.SelectArm(float64(i%10) / 10)
+			assert.Nil(err)
+		}
+	}()
+	wg.Wait()
+	assert.Equal([]int{499, 499, 499}, b.GetCounts())
+}
+
+func TestEpsilonGreedy_ExploreFloor(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		arms     int
+		disabled int
+		epsilon  float64
+	}{
+		{2, 0, 0.1},
+		{10, 0, 0.1},
+		{20, 0, 0.2},
+		{40, 0, 0.4},
+		{40, 20, 0.2},
+		{200, 0, 1},
+	}
+
+	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0.1, nil, nil)
+		assert.Nil(err)
+		assert.Nil(b.Init(tt.arms))
+		b.ExploreFloor = 0.01
+		for i := 0; i < tt.disabled; i++ {
+			assert.Nil(b.Disable(i))
+		}
+		assert.InDelta(tt.epsilon, b.EffectiveEpsilon(), 1e-9, "should scale epsilon for %d arms", tt.arms)
+	}
+
+	// Doubling the arms doubles the exploration at the floor
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(20))
+	b.ExploreFloor = 0.01
+	before := b.EffectiveEpsilon()
+	for i := 0; i < 20; i++ {
+		_, err := b.AddArm()
+		assert.Nil(err)
+	}
+	assert.InDelta(2*before, b.EffectiveEpsilon(), 1e-9, "should increase the total exploration")
+	assert.InDelta(0.01, b.EffectiveEpsilon()/40, 1e-9, "should keep the per-arm floor")
+}
+
+func TestEpsilonGreedy_ExploitBonus(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		counts  []int
+		rewards []float64
+		bonus   float64
+		arm     int
+	}{
+		{[]int{100, 10}, []float64{0.5, 0.5}, 0.1, 1},
+		{[]int{10, 100}, []float64{0.5, 0.5}, 0.1, 0},
+		{[]int{100, 90}, []float64{0.6, 0.5}, 0.01, 0},
+		{[]int{100, 0}, []float64{0.9, 0}, 0.01, 1},
+	}
+
+	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0, tt.counts, tt.rewards)
+		assert.Nil(err)
+		b.ExploitBonus = tt.bonus
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.Equal(tt.arm, arm, "should exploit with the bonus for counts %v and rewards %v", tt.counts, tt.rewards)
+	}
+}
+
+func TestEpsilonGreedy_SnapshotConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	// NOTE: The k-th reward of arm 0 is 2k-1, so its mean always equals its
+	// count when both are read together
+	const updates = 500
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for k := 1; k <= updates; k++ {
+			assert.Nil(b.Update(0, float64(2*k-1)))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_, err := b.AddArm()
+			assert.Nil(err)
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		counts, rewards, epsilon := b.Snapshot()
+		assert.Equal(len(counts), len(rewards), "should snapshot as many counts as rewards")
+		assert.Equal(float64(counts[0]), rewards[0], "should snapshot the mean with its count")
+		assert.Equal(0.1, epsilon)
+	}
+	wg.Wait()
+
+	counts, rewards, _ := b.Snapshot()
+	assert.Equal(updates, counts[0])
+	assert.Equal(float64(updates), rewards[0])
+	assert.Len(counts, 52)
+}
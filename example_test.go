@@ -0,0 +1,41 @@
+package bandit_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	bandit "github.com/eqwile/go-bandit"
+)
+
+func ExampleBernoulliArm() {
+	r := rand.New(rand.NewSource(1))
+	arms := []bandit.BernoulliArm{{P: 0.1}, {P: 0.5}, {P: 0.9}}
+
+	b, _ := bandit.NewEpsilonGreedy(0.1, nil, nil)
+	b.Init(len(arms))
+	b.Rand = r
+	for i := 0; i < 1000; i++ {
+		arm, _ := b.SelectArm(r.Float64())
+		b.Update(arm, arms[arm].Draw(r))
+	}
+
+	for arm, mean := range b.GetRewards() {
+		fmt.Printf("arm %d: %.1f\n", arm, mean)
+	}
+	// Output:
+	// arm 0: 0.1
+	// arm 1: 0.5
+	// arm 2: 0.9
+}
+
+func ExampleRecordTranscript() {
+	b, _ := bandit.NewEpsilonGreedy(0.5, nil, nil)
+	b.Init(3)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	rewards := []float64{1, 0, 1, 1, 0, 0, 1, 0, 1, 1}
+	transcript, _ := bandit.RecordTranscript(b, rand.New(rand.NewSource(2)), rewards)
+	fmt.Println(transcript)
+	// Output:
+	// 2 0 2 2 2 2 1 0 1 2
+}
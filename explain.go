@@ -0,0 +1,79 @@
+package bandit
+
+import "fmt"
+
+// Explanation describes why the most recent selection chose its arm, with the
+// means as of the selection. The runner-up is the enabled arm with the
+// highest mean other than the chosen one, and is -1 when there is none.
+type Explanation struct {
+	Arm          int     `json:"arm"`
+	Explored     bool    `json:"explored"`
+	Epsilon      float64 `json:"epsilon"`
+	Mean         float64 `json:"mean"`
+	RunnerUp     int     `json:"runner_up"`
+	RunnerUpMean float64 `json:"runner_up_mean"`
+	Propensity   float64 `json:"propensity"`
+}
+
+// String returns the explanation in a sentence, e.g. for a support ticket
+func (e Explanation) String() string {
+	how := "exploited"
+	if e.Explored {
+		how = "explored"
+	}
+	s := fmt.Sprintf("arm %d %s with epsilon %.3g and probability %.3g, mean %.3g", e.Arm, how, e.Epsilon, e.Propensity, e.Mean)
+	if e.RunnerUp >= 0 {
+		s += fmt.Sprintf(" against runner-up arm %d with mean %.3g", e.RunnerUp, e.RunnerUpMean)
+	}
+	return s
+}
+
+// Explain returns the explanation of the most recent selection made by
+// SelectArm or SelectArmFrom
+func (b *EpsilonGreedy) Explain() (Explanation, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.explained {
+		return Explanation{}, ErrNoSelection
+	}
+	return b.explanation, nil
+}
+
+// explain caches the context of the decision for Explain
+func (b *EpsilonGreedy) explain(d decision) {
+	var runnerUp int
+	if b.IndexBest && !b.hasDisabled() && len(b.Aliases) == 0 {
+		runnerUp = b.cachedRunnerUp(d.arm)
+	} else {
+		runnerUp = b.scanRunnerUp(d.arm)
+	}
+
+	b.explanation = Explanation{
+		Arm:        d.arm,
+		Explored:   d.explored,
+		Epsilon:    d.epsilon,
+		Mean:       b.Rewards[d.arm],
+		RunnerUp:   runnerUp,
+		Propensity: d.propensity,
+	}
+	if runnerUp >= 0 {
+		b.explanation.RunnerUpMean = b.Rewards[runnerUp]
+	}
+	b.explained = true
+}
+
+// scanRunnerUp returns the enabled arm with the highest mean other than the
+// arm, or -1 when there is none
+func (b *EpsilonGreedy) scanRunnerUp(arm int) int {
+	runnerUp := -1
+	for i := range b.Rewards {
+		if i == arm || len(b.Disabled) == len(b.Rewards) && b.Disabled[i] || b.isAlias(i) {
+			continue
+		}
+		if runnerUp < 0 || b.Rewards[i] > b.Rewards[runnerUp] {
+			runnerUp = i
+		}
+	}
+	return runnerUp
+}
@@ -0,0 +1,56 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_Explain(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.2, []int{1, 1, 1}, []float64{0.1, 0.9, 0.5})
+	assert.Nil(err)
+	_, err = b.Explain()
+	assert.Equal(ErrNoSelection, err)
+
+	b.Rand = fixedRand{2}
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm)
+	explanation, err := b.Explain()
+	assert.Nil(err)
+	assert.Equal(1, explanation.Arm)
+	assert.False(explanation.Explored, "should explain the exploitation")
+	assert.Equal(0.2, explanation.Epsilon)
+	assert.Equal(0.9, explanation.Mean)
+	assert.Equal(2, explanation.RunnerUp)
+	assert.Equal(0.5, explanation.RunnerUpMean)
+	assert.InDelta(0.2/3+0.8, explanation.Propensity, 1e-9)
+	assert.Equal("arm 1 exploited with epsilon 0.2 and probability 0.867, mean 0.9 against runner-up arm 2 with mean 0.5", explanation.String())
+
+	arm, err = b.SelectArm(0.1)
+	assert.Nil(err)
+	assert.Equal(2, arm)
+	explanation, err = b.Explain()
+	assert.Nil(err)
+	assert.True(explanation.Explored, "should explain the forced exploration")
+	assert.Equal(0.5, explanation.Mean)
+	assert.Equal(1, explanation.RunnerUp, "should compare against the best other arm")
+	assert.InDelta(0.2/3, explanation.Propensity, 1e-9)
+
+	assert.Nil(b.Update(2, 1))
+	explanation, _ = b.Explain()
+	assert.Equal(0.5, explanation.Mean, "should keep the means as of the selection")
+
+	single, _ := NewEpsilonGreedy(0.2, []int{1}, []float64{0.5})
+	_, err = single.SelectArm(0.5)
+	assert.Nil(err)
+	explanation, _ = single.Explain()
+	assert.Equal(-1, explanation.RunnerUp)
+	assert.Equal("arm 0 exploited with epsilon 0.2 and probability 1, mean 0.5", explanation.String())
+
+	assert.Nil(b.Reset(3))
+	_, err = b.Explain()
+	assert.Equal(ErrNoSelection, err, "should forget the selection on Reset")
+}
@@ -0,0 +1,29 @@
+package bandit
+
+import "math"
+
+// loadPrior calls ExplorePrior for the scores of the current selection. The
+// scores must be one per arm, non-negative and sum to more than zero.
+func (b *EpsilonGreedy) loadPrior() error {
+	b.prior = nil
+	if b.ExplorePrior == nil {
+		return nil
+	}
+
+	scores := b.ExplorePrior()
+	if len(scores) != len(b.Rewards) {
+		return ErrInvalidLength
+	}
+	var total float64
+	for _, score := range scores {
+		if score < 0 || math.IsNaN(score) || math.IsInf(score, 0) {
+			return ErrInvalidPrior
+		}
+		total += score
+	}
+	if !(total > 0) || math.IsInf(total, 0) {
+		return ErrInvalidPrior
+	}
+	b.prior = scores
+	return nil
+}
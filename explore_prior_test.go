@@ -0,0 +1,62 @@
+package bandit
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ExplorePrior(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(1, []int{10, 10, 10}, []float64{0.5, 0.5, 0.5})
+	b.Rand = rand.New(rand.NewSource(1))
+	b.ExplorePrior = func() []float64 {
+		return []float64{1, 1, 8}
+	}
+
+	counts := make([]int, 3)
+	for i := 0; i < 1000; i++ {
+		arm, err := b.SelectArm(0)
+		assert.Nil(err)
+		counts[arm]++
+	}
+	assert.Greater(counts[2], 700, "should explore the high-scored arm more")
+	assert.Greater(counts[0], 0, "should still explore the other arms")
+
+	propensities, err := b.Propensities()
+	assert.Nil(err)
+	assert.InDelta(0.8, propensities[2], 1e-9, "should follow the prior")
+
+	b.ExplorePrior = nil
+	propensities, err = b.Propensities()
+	assert.Nil(err)
+	assert.InDelta(1.0/3, propensities[2], 1e-9, "should fall back to uniform")
+}
+
+func TestEpsilonGreedy_ExplorePriorWithInvalidScores(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(1, nil, nil)
+	assert.Nil(b.Init(2))
+
+	tests := []struct {
+		scores []float64
+		err    error
+	}{
+		{[]float64{1}, ErrInvalidLength},
+		{[]float64{0, 0}, ErrInvalidPrior},
+		{[]float64{-1, 2}, ErrInvalidPrior},
+		{[]float64{math.NaN(), 1}, ErrInvalidPrior},
+		{[]float64{math.Inf(1), 1}, ErrInvalidPrior},
+		{[]float64{0, 1}, nil},
+	}
+
+	for i, tt := range tests {
+		b.ExplorePrior = func() []float64 { return tt.scores }
+		_, err := b.SelectArm(0)
+		assert.Equal(tt.err, err, "should validate the scores for test %d", i+1)
+	}
+}
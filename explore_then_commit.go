@@ -0,0 +1,128 @@
+package bandit
+
+import "sync"
+
+// ExploreThenCommit represents the explore-then-commit algorithm, which pulls
+// every arm exactly Pulls times in round-robin, then commits to the arm with
+// the best mean reward for good
+type ExploreThenCommit struct {
+	sync.RWMutex
+	Pulls   int
+	Counts  []int
+	Rewards []float64
+
+	// SelectionCount is the number of selections made, which drives the
+	// round-robin exploration
+	SelectionCount int
+
+	Committed    bool
+	CommittedArm int
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *ExploreThenCommit) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.SelectionCount = 0
+	b.Committed = false
+	b.CommittedArm = 0
+	return nil
+}
+
+// SelectArm chooses the next arm in round-robin until every arm has been
+// pulled Pulls times, and the committed arm after that. The probability is
+// not used.
+func (b *ExploreThenCommit) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	nArms := len(b.Rewards)
+	if nArms == 0 {
+		return 0, ErrNotInitialized
+	}
+	if b.Committed {
+		return b.CommittedArm, nil
+	}
+	if b.SelectionCount < b.Pulls*nArms {
+		arm := b.SelectionCount % nArms
+		b.SelectionCount++
+		return arm, nil
+	}
+
+	b.Committed = true
+	b.CommittedArm = max(b.Rewards...)
+	return b.CommittedArm, nil
+}
+
+// CommittedTo returns the arm committed to, and whether the exploration is
+// over
+func (b *ExploreThenCommit) CommittedTo() (arm int, committed bool) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.CommittedArm, b.Committed
+}
+
+// Update will update an arm with some reward value,
+// e.g. click = 1, no click = 0
+func (b *ExploreThenCommit) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+
+	oldRewards := b.Rewards[chosenArm]
+	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
+
+	return nil
+}
+
+// GetCounts returns the counts
+func (b *ExploreThenCommit) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *ExploreThenCommit) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewExploreThenCommit returns a pointer to the ExploreThenCommit struct
+func NewExploreThenCommit(pulls int, counts []int, rewards []float64) (*ExploreThenCommit, error) {
+	if pulls < 1 {
+		return nil, ErrInvalidPulls
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &ExploreThenCommit{
+		Pulls:   pulls,
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
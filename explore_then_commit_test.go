@@ -0,0 +1,104 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExploreThenCommit_New(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		pulls   int
+		counts  []int
+		rewards []float64
+		err     error
+	}{
+		{1, nil, nil, nil},
+		{3, make([]int, 3), make([]float64, 3), nil},
+		{0, nil, nil, ErrInvalidPulls},
+		{-1, nil, nil, ErrInvalidPulls},
+		{1, make([]int, 3), nil, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		etc, err := NewExploreThenCommit(tt.pulls, tt.counts, tt.rewards)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.pulls, etc.Pulls, "pulls should be equal")
+		}
+	}
+}
+
+func TestExploreThenCommit_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		pulls int
+		arms  int
+		best  int
+	}{
+		{1, 1, 0},
+		{1, 3, 2},
+		{5, 3, 1},
+		{10, 4, 3},
+	}
+
+	for _, tt := range tests {
+		etc, _ := NewExploreThenCommit(tt.pulls, nil, nil)
+		assert.Nil(etc.Init(tt.arms))
+
+		explored := make([]int, tt.arms)
+		for i := 0; i < tt.pulls*tt.arms; i++ {
+			_, committed := etc.CommittedTo()
+			assert.False(committed, "should not commit while exploring")
+
+			arm, err := etc.SelectArm(0)
+			assert.Nil(err)
+			assert.Equal(i%tt.arms, arm, "should explore in round-robin")
+			explored[arm]++
+
+			reward := 0.0
+			if arm == tt.best {
+				reward = 1.0
+			}
+			assert.Nil(etc.Update(arm, reward))
+		}
+		for arm, pulls := range explored {
+			assert.Equal(tt.pulls, pulls, "arm %d should be explored exactly m times", arm)
+		}
+
+		for i := 0; i < 10; i++ {
+			arm, err := etc.SelectArm(0)
+			assert.Nil(err)
+			assert.Equal(tt.best, arm, "should select the committed arm")
+		}
+		arm, committed := etc.CommittedTo()
+		assert.True(committed)
+		assert.Equal(tt.best, arm, "should commit to the best arm")
+	}
+}
+
+func TestExploreThenCommit_Init(t *testing.T) {
+	assert := assert.New(t)
+
+	etc, _ := NewExploreThenCommit(1, nil, nil)
+	_, err := etc.SelectArm(0)
+	assert.Equal(ErrNotInitialized, err, "should throw error without arms")
+
+	assert.Equal(ErrInvalidArms, etc.Init(0))
+	assert.Nil(etc.Init(2))
+	etc.SelectArm(0)
+	etc.SelectArm(0)
+	etc.SelectArm(0)
+	_, committed := etc.CommittedTo()
+	assert.True(committed)
+
+	assert.Nil(etc.Init(2))
+	_, committed = etc.CommittedTo()
+	assert.False(committed, "init should restart the exploration")
+	assert.Equal(0, etc.SelectionCount)
+}
@@ -0,0 +1,151 @@
+package bandit
+
+import "sync"
+
+// FairnessConstraint wraps a bandit, and keeps the share of the selections of
+// each group of arms at or above its floor, e.g. so that no protected segment
+// is systematically under-served. When a selection would leave a group below
+// its floor, the arm of the group with the highest mean is selected instead,
+// and otherwise the bandit selects as usual.
+type FairnessConstraint struct {
+	sync.RWMutex
+	Bandit Bandit
+
+	// Groups maps each arm to its group, and Floors holds the minimum share
+	// of the selections of each group
+	Groups []int
+	Floors []float64
+
+	selections []int
+	total      int
+}
+
+// Init will initialise the bandit with the provided number of arms, which
+// must match the groups, starting it from scratch when it can start over, e.g.
+// EpsilonGreedy, and reset the exposure
+func (f *FairnessConstraint) Init(nArms int) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if nArms != len(f.Groups) {
+		return ErrInvalidLength
+	}
+	if err := resetBandit(f.Bandit, nArms); err != nil {
+		return err
+	}
+	f.selections = nil
+	f.total = 0
+	return nil
+}
+
+// SelectArm chooses an arm with the bandit, unless a group would fall below
+// its floor
+func (f *FairnessConstraint) SelectArm(probability float64) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	if len(f.selections) != len(f.Floors) {
+		f.selections = make([]int, len(f.Floors))
+	}
+
+	// Serve the group furthest below its floor after this selection
+	group := -1
+	var deficit float64
+	for g, floor := range f.Floors {
+		if d := floor*float64(f.total+1) - float64(f.selections[g]); d > 0 && d > deficit {
+			group, deficit = g, d
+		}
+	}
+
+	var arm int
+	if group >= 0 {
+		if arm = f.bestOf(group); arm < 0 {
+			return -1, ErrNotInitialized
+		}
+	} else {
+		var err error
+		if arm, err = f.Bandit.SelectArm(probability); err != nil {
+			return -1, err
+		}
+	}
+	if arm < len(f.Groups) {
+		f.selections[f.Groups[arm]]++
+	}
+	f.total++
+	return arm, nil
+}
+
+// bestOf returns the arm of the group with the highest mean, or -1 when the
+// bandit is not initialised
+func (f *FairnessConstraint) bestOf(group int) int {
+	rewards := f.Bandit.GetRewards()
+	best := -1
+	for i, g := range f.Groups {
+		if g == group && i < len(rewards) && (best < 0 || rewards[i] > rewards[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Update will update the bandit with some reward value
+func (f *FairnessConstraint) Update(chosenArm int, reward float64) error {
+	return f.Bandit.Update(chosenArm, reward)
+}
+
+// Exposure returns the share of the selections made on each group
+func (f *FairnessConstraint) Exposure() []float64 {
+	f.RLock()
+	defer f.RUnlock()
+
+	exposure := make([]float64, len(f.Floors))
+	if f.total == 0 {
+		return exposure
+	}
+	for g, selections := range f.selections {
+		exposure[g] = float64(selections) / float64(f.total)
+	}
+	return exposure
+}
+
+// GetCounts returns the counts of the bandit
+func (f *FairnessConstraint) GetCounts() []int {
+	return f.Bandit.GetCounts()
+}
+
+// GetRewards returns the rewards of the bandit
+func (f *FairnessConstraint) GetRewards() []float64 {
+	return f.Bandit.GetRewards()
+}
+
+// NewFairnessConstraint returns a pointer to the FairnessConstraint struct,
+// where groups maps each arm of b to an index of floors. The floors must be in
+// range 0 to 1, sum to at most 1, and belong to groups with arms.
+func NewFairnessConstraint(b Bandit, groups []int, floors []float64) (*FairnessConstraint, error) {
+	arms := make([]int, len(floors))
+	for _, g := range groups {
+		if g < 0 || g >= len(floors) {
+			return nil, ErrInvalidGroup
+		}
+		arms[g]++
+	}
+	var total float64
+	for g, floor := range floors {
+		if !(floor >= 0 && floor <= 1) {
+			return nil, ErrInvalidFraction
+		}
+		if floor > 0 && arms[g] == 0 {
+			return nil, ErrInvalidGroup
+		}
+		total += floor
+	}
+	if total > 1 {
+		return nil, ErrInvalidFraction
+	}
+
+	return &FairnessConstraint{
+		Bandit: b,
+		Groups: groups,
+		Floors: floors,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFairnessConstraint(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		groups []int
+		floors []float64
+		err    error
+	}{
+		{[]int{0, 0, 1}, []float64{0, 0.2}, nil},
+		{[]int{0, 0, 2}, []float64{0, 0.2}, ErrInvalidGroup},
+		{[]int{0, 0, -1}, []float64{0, 0.2}, ErrInvalidGroup},
+		{[]int{0, 0, 0}, []float64{0, 0.2}, ErrInvalidGroup},
+		{[]int{0, 0, 1}, []float64{0, 1.2}, ErrInvalidFraction},
+		{[]int{0, 0, 1}, []float64{0.6, 0.6}, ErrInvalidFraction},
+	}
+
+	for _, tt := range tests {
+		b, _ := NewEpsilonGreedy(0, nil, nil)
+		_, err := NewFairnessConstraint(b, tt.groups, tt.floors)
+		assert.Equal(tt.err, err, "should validate %v and %v", tt.groups, tt.floors)
+	}
+
+	b, _ := NewEpsilonGreedy(0, nil, nil)
+	f, err := NewFairnessConstraint(b, []int{0, 1}, []float64{0, 0.5})
+	assert.Nil(err)
+	assert.Equal(ErrInvalidLength, f.Init(3), "should match the arms to the groups")
+	_, err = f.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+}
+
+func TestFairnessConstraint_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	// The policy only exploits arm 0, neglecting group 1
+	b, _ := NewEpsilonGreedy(0, nil, nil)
+	f, err := NewFairnessConstraint(b, []int{0, 0, 1, 1}, []float64{0, 0.25})
+	assert.Nil(err)
+	assert.Nil(f.Init(4))
+	assert.Nil(f.Update(0, 1))
+	assert.Nil(f.Update(1, 0.5))
+	assert.Nil(f.Update(2, 0.1))
+	assert.Nil(f.Update(3, 0.2))
+	assert.Equal([]float64{0, 0}, f.Exposure())
+
+	counts := make([]int, 4)
+	for i := 0; i < 100; i++ {
+		arm, err := f.SelectArm(0.5)
+		assert.Nil(err)
+		counts[arm]++
+		assert.GreaterOrEqual(f.Exposure()[1], 0.25, "should keep the group at its floor")
+	}
+	assert.Equal([]int{75, 0, 0, 25}, counts, "should serve the best arm of the neglected group")
+	assert.Equal([]float64{0.75, 0.25}, f.Exposure())
+
+	unconstrained, _ := NewFairnessConstraint(b, []int{0, 0, 1, 1}, []float64{0, 0})
+	for i := 0; i < 10; i++ {
+		arm, err := unconstrained.SelectArm(0.5)
+		assert.Nil(err)
+		assert.Equal(0, arm, "should follow the policy without floors")
+	}
+}
@@ -0,0 +1,27 @@
+package bandit
+
+import "errors"
+
+// fallback replaces the failed selection of a bandit without arms to choose
+// from with the DefaultArm, when UseDefaultArm is set, and returns whether it
+// did. The default is not recorded as a selection, so it starts no cooldown.
+// A negative DefaultArm, or one outside the arms once initialized, is
+// rejected with an ArmIndexError rather than returned.
+func (b *EpsilonGreedy) fallback(d decision, err error) (decision, bool, error) {
+	if !b.UseDefaultArm || !(errors.Is(err, ErrNotInitialized) || errors.Is(err, ErrNoEligibleArms)) {
+		return d, false, err
+	}
+	if b.DefaultArm < 0 || b.initialized() && b.DefaultArm >= len(b.Rewards) {
+		return decision{}, false, &ArmIndexError{Index: b.DefaultArm, NumArms: len(b.Rewards)}
+	}
+	return decision{arm: b.DefaultArm, epsilon: b.epsilon(), propensity: 1}, true, nil
+}
+
+// UsedDefaultArm returns whether the last call of SelectArm returned the
+// DefaultArm because there was no arm to choose from
+func (b *EpsilonGreedy) UsedDefaultArm() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.usedDefault
+}
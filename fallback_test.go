@@ -0,0 +1,64 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_DefaultArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err, "should throw an error without a default arm")
+	assert.False(b.UsedDefaultArm())
+
+	b.DefaultArm, b.UseDefaultArm = 1, true
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should return the default arm before Init")
+	assert.True(b.UsedDefaultArm())
+
+	assert.Nil(b.Init(3))
+	assert.Nil(b.Update(2, 1))
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(2, arm, "should select the best arm once initialized")
+	assert.False(b.UsedDefaultArm())
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(b.Disable(i))
+	}
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should return the default arm while every arm is disabled")
+	assert.True(b.UsedDefaultArm())
+	assert.Equal(1, b.GetSelectionCount(), "should not count the default as a selection")
+
+	assert.Nil(b.Enable(0))
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should select the enabled arm again")
+	assert.False(b.UsedDefaultArm())
+}
+
+func TestEpsilonGreedy_InvalidDefaultArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	b.DefaultArm, b.UseDefaultArm = -1, true
+	_, err = b.SelectArm(0.5)
+	assert.Equal(&ArmIndexError{Index: -1, NumArms: 0}, err, "should reject a negative default arm")
+	assert.False(b.UsedDefaultArm())
+
+	assert.Nil(b.Init(2))
+	b.DefaultArm = 2
+	assert.Nil(b.Disable(0))
+	assert.Nil(b.Disable(1))
+	_, err = b.SelectArm(0.5)
+	assert.Equal(&ArmIndexError{Index: 2, NumArms: 2}, err, "should reject a default arm outside the arms")
+	assert.False(b.UsedDefaultArm())
+}
@@ -0,0 +1,156 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// Gittins represents an index policy for the discounted infinite horizon,
+// selecting the arm with the highest approximate Gittins index. The exact
+// index is intractable, so it uses the approximation of Brezzi and Lai (2002)
+// for normal rewards:
+//
+//	mean + sqrt(v)·ψ(1/(n·c)), where v = variance/n and c = -ln(Discount)
+//
+// with the piecewise ψ of the paper, and the variance of 0.25 for arms with
+// fewer than two rewards. Unplayed arms have an infinite index.
+type Gittins struct {
+	sync.RWMutex
+	Discount float64
+	Counts   []int
+	Rewards  []float64
+
+	// M2 holds the running sum of squared deviations from the mean of each
+	// arm, used to estimate the reward variance
+	M2 []float64
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *Gittins) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.M2 = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm chooses the arm with the highest index. The probability is
+// ignored.
+func (b *Gittins) SelectArm(probability float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(b.Rewards) == 0 {
+		return -1, ErrNotInitialized
+	}
+	return max(b.indices()...), nil
+}
+
+// Indices returns the approximate Gittins index of each arm
+func (b *Gittins) Indices() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.indices()
+}
+
+func (b *Gittins) indices() []float64 {
+	c := -math.Log(b.Discount)
+	indices := make([]float64, len(b.Rewards))
+	for i, mean := range b.Rewards {
+		n := b.Counts[i]
+		if n == 0 {
+			indices[i] = math.Inf(1)
+			continue
+		}
+		variance := 0.25
+		if n > 1 && len(b.M2) == len(b.Rewards) {
+			variance = b.M2[i] / float64(n-1)
+		}
+		indices[i] = mean + math.Sqrt(variance/float64(n))*gittinsPsi(1/(float64(n)*c))
+	}
+	return indices
+}
+
+// gittinsPsi returns the boundary function ψ(s) of Brezzi and Lai
+func gittinsPsi(s float64) float64 {
+	switch {
+	case s <= 0.2:
+		return math.Sqrt(s / 2)
+	case s <= 1:
+		return 0.49 - 0.11/math.Sqrt(s)
+	case s <= 5:
+		return 0.63 - 0.26/math.Sqrt(s)
+	case s <= 15:
+		return 0.77 - 0.58/math.Sqrt(s)
+	}
+	return math.Sqrt(2*math.Log(s) - math.Log(math.Log(s)) - math.Log(16*math.Pi))
+}
+
+// Update will update an arm with some reward value,
+// e.g. click = 1, no click = 0
+func (b *Gittins) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	// NOTE: M2 may be missing when the bandit was created from counts and
+	// rewards alone
+	if len(b.M2) != len(b.Rewards) {
+		b.M2 = make([]float64, len(b.Rewards))
+	}
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+	oldRewards := b.Rewards[chosenArm]
+	b.Rewards[chosenArm] += (reward - oldRewards) / n
+	b.M2[chosenArm] += (reward - oldRewards) * (reward - b.Rewards[chosenArm])
+	return nil
+}
+
+// GetCounts returns the counts
+func (b *Gittins) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *Gittins) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewGittins returns a pointer to the Gittins struct with the discount factor,
+// in range 0 to 1 exclusive
+func NewGittins(discount float64, counts []int, rewards []float64) (*Gittins, error) {
+	if !(discount > 0 && discount < 1) {
+		return nil, ErrInvalidDiscount
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &Gittins{
+		Discount: discount,
+		Counts:   counts,
+		Rewards:  rewards,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package bandit
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGittins(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, discount := range []float64{0, 1, -0.5, math.NaN()} {
+		_, err := NewGittins(discount, nil, nil)
+		assert.Equal(ErrInvalidDiscount, err)
+	}
+	_, err := NewGittins(0.9, []int{1}, nil)
+	assert.Equal(ErrInvalidLength, err)
+
+	b, err := NewGittins(0.9, nil, nil)
+	assert.Nil(err)
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Nil(b.Init(2))
+	assert.True(errors.Is(b.Update(2, 1), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidReward, b.Update(0, -1))
+}
+
+func TestGittins_Indices(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewGittins(0.9, []int{10, 10, 100, 0}, []float64{0.5, 0.6, 0.5, 0})
+	assert.Nil(err)
+	indices := b.Indices()
+	assert.Greater(indices[1], indices[0], "should raise the index with the mean")
+	assert.Greater(indices[0], indices[2], "should raise the index with the uncertainty")
+	assert.Greater(indices[2], 0.5, "should add a bonus to the mean")
+	assert.True(math.IsInf(indices[3], 1), "should play unplayed arms first")
+
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(3, arm)
+
+	patient, _ := NewGittins(0.99, []int{10}, []float64{0.5})
+	impatient, _ := NewGittins(0.5, []int{10}, []float64{0.5})
+	assert.Greater(patient.Indices()[0], impatient.Indices()[0], "should explore more with a larger discount")
+}
+
+func TestGittins_Simulate(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewGittins(0.99, nil, nil)
+	assert.Nil(b.Init(3))
+	env, err := NewBernoulliEnv([]float64{0.2, 0.5, 0.8}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+
+	pulls := 2000
+	for i := 0; i < pulls; i++ {
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.Nil(b.Update(arm, env.Pull(arm)))
+	}
+	assert.Greater(b.GetCounts()[2], pulls*3/4, "should favor the best arm")
+}
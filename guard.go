@@ -0,0 +1,71 @@
+package bandit
+
+import (
+	"log"
+	"math"
+)
+
+// RewardGuard tracks the range of the observed rewards and warns when a
+// reward falls outside the expected range, e.g. raw revenue sent to a softmax
+// tuned for click rates. It is guarded by the lock of the bandit it belongs
+// to.
+type RewardGuard struct {
+	Min float64
+	Max float64
+
+	// OnOutOfRange is called with every reward outside the expected range,
+	// and the reward is logged instead when nil
+	OnOutOfRange func(arm int, reward float64)
+
+	observedMin float64
+	observedMax float64
+	seen        bool
+}
+
+// observe records the reward, and returns the warning to run once the lock
+// of the bandit is released. A nil guard observes nothing.
+func (g *RewardGuard) observe(arm int, reward float64) func() {
+	if g == nil {
+		return nil
+	}
+	if !g.seen {
+		g.observedMin, g.observedMax, g.seen = reward, reward, true
+	}
+	g.observedMin = math.Min(g.observedMin, reward)
+	g.observedMax = math.Max(g.observedMax, reward)
+	if reward >= g.Min && reward <= g.Max {
+		return nil
+	}
+
+	if g.OnOutOfRange != nil {
+		onOutOfRange := g.OnOutOfRange
+		return func() {
+			onOutOfRange(arm, reward)
+		}
+	}
+	min, max := g.Min, g.Max
+	return func() {
+		log.Printf("bandit: reward %v of arm %d is outside the expected range %v to %v", reward, arm, min, max)
+	}
+}
+
+func (g *RewardGuard) observedRange() (min, max float64) {
+	if g == nil || !g.seen {
+		return math.Inf(1), math.Inf(-1)
+	}
+	return g.observedMin, g.observedMax
+}
+
+// NewRewardGuard returns a pointer to the RewardGuard struct expecting rewards
+// in range min to max
+func NewRewardGuard(min, max float64, onOutOfRange func(arm int, reward float64)) (*RewardGuard, error) {
+	if min >= max {
+		return nil, ErrInvalidRange
+	}
+
+	return &RewardGuard{
+		Min:          min,
+		Max:          max,
+		OnOutOfRange: onOutOfRange,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRewardGuard(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewRewardGuard(0, 1, nil)
+	assert.Nil(err)
+	_, err = NewRewardGuard(1, 1, nil)
+	assert.Equal(ErrInvalidRange, err, "should throw error for empty range")
+	_, err = NewRewardGuard(1, 0, nil)
+	assert.Equal(ErrInvalidRange, err, "should throw error for inverted range")
+}
+
+func TestRewardGuard_Update(t *testing.T) {
+	assert := assert.New(t)
+
+	type warning struct {
+		arm    int
+		reward float64
+	}
+
+	ucb, _ := NewUCB(nil, nil)
+	softmax, _ := NewSoftmax(0.1, nil, nil)
+	annealingSoftmax, _ := NewAnnealingSoftmax(nil, nil)
+
+	tests := []struct {
+		name   string
+		bandit interface {
+			Bandit
+			ObservedRewardRange() (float64, float64)
+		}
+		setGuard func(*RewardGuard)
+	}{
+		{"ucb", ucb, func(g *RewardGuard) { ucb.Guard = g }},
+		{"softmax", softmax, func(g *RewardGuard) { softmax.Guard = g }},
+		{"annealing softmax", annealingSoftmax, func(g *RewardGuard) { annealingSoftmax.Guard = g }},
+	}
+
+	for _, tt := range tests {
+		assert.Nil(tt.bandit.Init(2))
+
+		min, max := tt.bandit.ObservedRewardRange()
+		assert.True(math.IsInf(min, 1) && math.IsInf(max, -1), "%s should report an empty range without a guard", tt.name)
+
+		var warnings []warning
+		guard, err := NewRewardGuard(0, 0.8, func(arm int, reward float64) {
+			warnings = append(warnings, warning{arm, reward})
+		})
+		assert.Nil(err)
+		tt.setGuard(guard)
+
+		assert.Nil(tt.bandit.Update(0, 0.5))
+		assert.Nil(tt.bandit.Update(1, 0.8))
+		assert.Empty(warnings, "%s should not warn for rewards in range", tt.name)
+
+		assert.Nil(tt.bandit.Update(1, 1.0))
+		assert.Equal([]warning{{1, 1.0}}, warnings, "%s should warn for rewards out of range", tt.name)
+		assert.Equal(ErrInvalidReward, tt.bandit.Update(0, -1.0))
+
+		min, max = tt.bandit.ObservedRewardRange()
+		assert.Equal(0.5, min, "%s should report the observed min", tt.name)
+		assert.Equal(1.0, max, "%s should report the observed max", tt.name)
+	}
+}
+
+func TestRewardGuard_ZeroValue(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewSoftmax(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	b.SignedRewards = true
+	b.Guard = &RewardGuard{Min: -5, Max: 5}
+
+	min, max := b.ObservedRewardRange()
+	assert.True(math.IsInf(min, 1) && math.IsInf(max, -1), "should report an empty range before observations")
+
+	assert.Nil(b.Update(0, -2))
+	assert.Nil(b.Update(1, -1))
+	min, max = b.ObservedRewardRange()
+	assert.Equal(-2.0, min, "should report the negative min")
+	assert.Equal(-1.0, max, "should not report a max of zero")
+}
@@ -0,0 +1,163 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// Hedge represents the exponential weights algorithm for the full-information
+// setting, where the reward of every arm is observed each round. The weight of
+// each arm is exp(Eta·total reward), and the arms are selected with
+// probabilities proportional to their weights.
+type Hedge struct {
+	sync.RWMutex
+	Eta     float64
+	Counts  []int
+	Rewards []float64
+
+	// LogWeights holds the log of the weight of each arm, which keeps the
+	// weights from overflowing
+	LogWeights []float64
+}
+
+// Init will initialise the counts, rewards and weights with the provided
+// number of arms
+func (b *Hedge) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.LogWeights = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm samples an arm from the weight distribution, where probability is
+// uniform in the range 0 to 1
+func (b *Hedge) SelectArm(probability float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(b.Rewards) == 0 {
+		return -1, ErrNotInitialized
+	}
+	return categoricalProb(probability, b.weights()...), nil
+}
+
+// GetWeights returns the normalized weight of each arm, which is its
+// probability of being selected
+func (b *Hedge) GetWeights() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.weights()
+}
+
+func (b *Hedge) weights() []float64 {
+	weights := make([]float64, len(b.Rewards))
+	if len(b.LogWeights) != len(b.Rewards) {
+		// NOTE: The weights are missing for a bandit created from counts and
+		// rewards alone, and start uniform
+		for i := range weights {
+			weights[i] = 1 / float64(len(weights))
+		}
+		return weights
+	}
+
+	// NOTE: Shifting by the largest log weight keeps the exponentials in range
+	shift := b.LogWeights[max(b.LogWeights...)]
+	var z float64
+	for i, logWeight := range b.LogWeights {
+		weights[i] = math.Exp(logWeight - shift)
+		z += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= z
+	}
+	return weights
+}
+
+// UpdateAll will update every arm with its reward of the round
+func (b *Hedge) UpdateAll(rewards []float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(rewards) != len(b.Rewards) {
+		return ErrInvalidLength
+	}
+	for _, reward := range rewards {
+		if !(reward >= 0) || math.IsInf(reward, 1) {
+			return ErrInvalidReward
+		}
+	}
+	for arm, reward := range rewards {
+		b.update(arm, reward)
+	}
+	return nil
+}
+
+// Update will update an arm with some reward value, for a round where only the
+// reward of the chosen arm is known and the other weights are unchanged
+func (b *Hedge) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if !(reward >= 0) || math.IsInf(reward, 1) {
+		return ErrInvalidReward
+	}
+	b.update(chosenArm, reward)
+	return nil
+}
+
+func (b *Hedge) update(arm int, reward float64) {
+	if len(b.LogWeights) != len(b.Rewards) {
+		b.LogWeights = make([]float64, len(b.Rewards))
+	}
+
+	b.Counts[arm]++
+	n := float64(b.Counts[arm])
+	b.Rewards[arm] = (b.Rewards[arm]*(n-1) + reward) / n
+	b.LogWeights[arm] += b.Eta * reward
+}
+
+// GetCounts returns the counts
+func (b *Hedge) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *Hedge) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewHedge returns a pointer to the Hedge struct with the learning rate eta
+func NewHedge(eta float64, counts []int, rewards []float64) (*Hedge, error) {
+	if !(eta > 0) || math.IsInf(eta, 1) {
+		return nil, ErrInvalidEta
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &Hedge{
+		Eta:     eta,
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHedge(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewHedge(0, nil, nil)
+	assert.Equal(ErrInvalidEta, err)
+	_, err = NewHedge(0.1, []int{0}, nil)
+	assert.Equal(ErrInvalidLength, err)
+
+	b, err := NewHedge(0.1, []int{1, 1}, []float64{0.5, 0.5})
+	assert.Nil(err)
+	assert.Equal([]float64{0.5, 0.5}, b.GetWeights(), "should start uniform without weights")
+
+	b = &Hedge{Eta: 0.1}
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Nil(b.Init(3))
+	assert.Equal(ErrInvalidLength, b.UpdateAll([]float64{1, 0}))
+	assert.Equal(ErrInvalidReward, b.UpdateAll([]float64{1, -1, 0}))
+	assert.Equal([]int{0, 0, 0}, b.GetCounts(), "should not apply an invalid round")
+	assert.True(errors.Is(b.Update(3, 1), ErrArmsIndexOutOfRange))
+}
+
+func TestHedge_UpdateAll(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewHedge(0.5, nil, nil)
+	assert.Nil(b.Init(3))
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		// The second arm pays the most on average every round
+		rewards := []float64{0.3 * r.Float64(), 0.4 + 0.6*r.Float64(), 0.6 * r.Float64()}
+		assert.Nil(b.UpdateAll(rewards))
+	}
+
+	weights := b.GetWeights()
+	assert.InDelta(1.0, sumFloat64(weights...), 1e-9, "should normalize the weights")
+	assert.Greater(weights[1], 0.99, "should concentrate on the best arm")
+	assert.Equal([]int{200, 200, 200}, b.GetCounts(), "should observe every arm")
+
+	for i := 0; i < 10; i++ {
+		arm, err := b.SelectArm(r.Float64())
+		assert.Nil(err)
+		assert.Equal(1, arm)
+	}
+
+	// Bandit feedback only moves the weight of the chosen arm
+	before := b.GetWeights()
+	assert.Nil(b.Update(0, 1))
+	assert.Greater(b.GetWeights()[0], before[0])
+}
@@ -2,6 +2,7 @@ package bandit
 
 import (
 	"math"
+	"math/rand"
 )
 
 func sum(values ...int) int {
@@ -28,6 +29,33 @@ func sumFloat64(values ...float64) float64 {
 	return total
 }
 
+// totalCounts returns the sum of the counts as int64, which does not overflow
+// on 32-bit platforms, and saturates at the largest int64 on 64-bit ones
+func totalCounts(counts []int) int64 {
+	var total int64
+	for _, count := range counts {
+		if int64(count) > math.MaxInt64-total {
+			return math.MaxInt64
+		}
+		total += int64(count)
+	}
+	return total
+}
+
+// timeStep returns the number of rounds played, which is the number of
+// selections made unless they are behind the updates, e.g. for a bandit
+// restored from counts. The time step saturates at the largest int instead of
+// overflowing.
+func timeStep(selections int, counts []int) int {
+	if total := totalCounts(counts); total > int64(selections) {
+		if total > math.MaxInt {
+			return math.MaxInt
+		}
+		return int(total)
+	}
+	return selections
+}
+
 func max(values ...float64) (index int) {
 	value := math.Inf(-1)
 	for i, v := range values {
@@ -46,7 +74,7 @@ func maxMean(counts []int, rewards []float64) (index int) {
 			continue
 		}
 
-		mean := rewards[i] / float64(counts[i])
+		mean := rewards[i]
 		if mean > value {
 			value = mean
 			index = i
@@ -55,6 +83,23 @@ func maxMean(counts []int, rewards []float64) (index int) {
 	return
 }
 
+// softmax returns the probabilities proportional to the exponential of each
+// value over the temperature, shifted by the highest value so that the
+// exponentials do not overflow at low temperatures
+func softmax(values []float64, temperature float64) []float64 {
+	highest := values[max(values...)]
+	probs := make([]float64, len(values))
+	var z float64
+	for i, v := range values {
+		probs[i] = math.Exp((v - highest) / temperature)
+		z += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= z
+	}
+	return probs
+}
+
 func categoricalProb(probability float64, probs ...float64) int {
 	var cumulativeProb float64
 	for i := 0; i < len(probs); i++ {
@@ -65,3 +110,60 @@ func categoricalProb(probability float64, probs ...float64) int {
 	}
 	return len(probs) - 1
 }
+
+// ucbValue returns the upper confidence bound of UCB1 for an arm with the
+// mean reward and count, out of totalCounts pulls
+func ucbValue(reward float64, count, totalCounts int) float64 {
+	return UCB1Bonus(count, totalCounts) + reward
+}
+
+// UCB1Bonus returns the exploration bonus of UCB1, sqrt(2·ln(total)/count),
+// for an arm with count pulls out of total pulls
+func UCB1Bonus(count, total int) float64 {
+	return math.Sqrt((2.0 * math.Log(float64(total))) / float64(count))
+}
+
+// validateUnitReward returns ErrInvalidReward for rewards outside the range 0
+// to 1, including NaN, which the confidence bounds of UCB assume
+func validateUnitReward(reward float64) error {
+	if !(reward >= 0 && reward <= 1) {
+		return ErrInvalidReward
+	}
+	return nil
+}
+
+// randFloat64 draws a number from r, or the math/rand source when r is nil,
+// clamped into the range 0 to 1 exclusive, where NaN counts as zero
+func randFloat64(r Rand) float64 {
+	var v float64
+	if r != nil {
+		v = r.Float64()
+	} else {
+		v = rand.Float64()
+	}
+	switch {
+	case !(v >= 0):
+		return 0
+	case v >= 1:
+		return math.Nextafter(1, 0)
+	}
+	return v
+}
+
+// randIntn draws a number from r, or the math/rand source when r is nil,
+// clamped into the range 0 to n exclusive
+func randIntn(r Rand, n int) int {
+	var v int
+	if r != nil {
+		v = r.Intn(n)
+	} else {
+		v = rand.Intn(n)
+	}
+	switch {
+	case v < 0:
+		return 0
+	case v >= n:
+		return n - 1
+	}
+	return v
+}
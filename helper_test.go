@@ -1,6 +1,7 @@
 package bandit
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -86,10 +87,12 @@ func TestMaxMean(t *testing.T) {
 		params   []float64
 		expected int
 	}{
-		{[]int{1, 2, 3, 4, 5}, []float64{1.1, 2.1, 3.1, 4.1, 5.1}, 0},
+		{[]int{1, 2, 3, 4, 5}, []float64{1.1, 2.1, 3.1, 4.1, 5.1}, 4},
 		{[]int{}, []float64{}, 0},
 		{[]int{1, 1}, []float64{-1, 1}, 1},
 		{[]int{1, 2}, []float64{10, 30}, 1},
+		{[]int{100, 1}, []float64{0.9, 0.1}, 0},
+		{[]int{0, 1}, []float64{0.9, 0.1}, 1},
 	}
 
 	for _, tt := range tests {
@@ -117,3 +120,138 @@ func TestCategoricalProb(t *testing.T) {
 		assert.Equal(tt.expected, categoricalProb(tt.probability, tt.params...), "should return the max index")
 	}
 }
+
+func TestTimeStep(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		selections int
+		counts     []int
+		expected   int
+	}{
+		{0, nil, 0},
+		{10, []int{1, 2}, 10},
+		{0, []int{1, 2}, 3},
+		{0, []int{math.MaxInt, 1}, math.MaxInt},
+		{math.MaxInt, []int{math.MaxInt, math.MaxInt}, math.MaxInt},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, timeStep(tt.selections, tt.counts), "should return the time step")
+	}
+}
+
+func TestTotalCounts(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		counts   []int
+		expected int64
+	}{
+		{nil, 0},
+		{[]int{1, 2}, 3},
+		{[]int{math.MaxInt32, math.MaxInt32, 2}, 2 * (math.MaxInt32 + 1)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, totalCounts(tt.counts), "should not overflow the total")
+	}
+
+	b, _ := NewEpsilonGreedy(0.1, []int{math.MaxInt32, math.MaxInt32}, []float64{0.1, 0.2})
+	assert.Equal(int64(2*math.MaxInt32), b.GetTotalCounts())
+	assert.Equal(0.5, b.Metrics().Arms[0].Share)
+}
+
+func TestValidateUnitReward(t *testing.T) {
+	assert := assert.New(t)
+
+	ucb, _ := NewUCB(nil, nil)
+	epsilonGreedy, _ := NewEpsilonGreedy(0.1, nil, nil)
+
+	tests := []struct {
+		reward float64
+		unit   error
+		nonNeg error
+	}{
+		{0, nil, nil},
+		{1, nil, nil},
+		{0.5, nil, nil},
+		{-0.0001, ErrInvalidReward, ErrInvalidReward},
+		{1.0001, ErrInvalidReward, nil},
+		{math.NaN(), ErrInvalidReward, nil},
+		{math.Inf(1), ErrInvalidReward, nil},
+		{math.Inf(-1), ErrInvalidReward, ErrInvalidReward},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.unit, validateUnitReward(tt.reward), "reward %v", tt.reward)
+
+		assert.Nil(ucb.Init(1))
+		assert.Equal(tt.unit, ucb.Update(0, tt.reward), "ucb should validate reward %v", tt.reward)
+
+		assert.Nil(epsilonGreedy.Init(1))
+		assert.Equal(tt.nonNeg, epsilonGreedy.Update(0, tt.reward), "epsilon greedy should only reject negative reward %v", tt.reward)
+	}
+}
+
+// brokenRand returns values outside the expected ranges
+type brokenRand struct {
+	calls int
+}
+
+func (r *brokenRand) Float64() float64 {
+	r.calls++
+	return []float64{1.5, -0.3, math.NaN(), 1, math.Inf(1)}[r.calls%5]
+}
+
+func (r *brokenRand) Intn(n int) int {
+	r.calls++
+	return []int{n, -1, n + 10}[r.calls%3]
+}
+
+func TestRandClamping(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &brokenRand{}
+	for i := 0; i < 20; i++ {
+		v := randFloat64(r)
+		assert.True(v >= 0 && v < 1, "should clamp %v into range 0 to 1", v)
+		n := randIntn(r, 3)
+		assert.True(n >= 0 && n < 3, "should clamp %v into range 0 to 3", n)
+	}
+	assert.Equal(0.25, randFloat64(fixedFloat(0.25)))
+}
+
+type fixedFloat float64
+
+func (f fixedFloat) Float64() float64 { return float64(f) }
+func (f fixedFloat) Intn(n int) int   { return 0 }
+
+func TestBrokenRand_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	epsilonGreedy, _ := NewEpsilonGreedy(1.0, nil, nil)
+	epsilonGreedy.Rand = &brokenRand{}
+	backoff, _ := NewEpsilonGreedy(1.0, nil, nil)
+	backoff.Rand = &brokenRand{}
+	adaptive, _ := NewAdaptiveGreedy(1.0, 10, nil, nil)
+	adaptive.Rand = &brokenRand{}
+	quantile, _ := NewQuantileBandit(0.9, 1.0, nil, nil)
+	quantile.Rand = &brokenRand{}
+
+	for _, b := range []Bandit{epsilonGreedy, backoff, adaptive, quantile} {
+		assert.Nil(b.Init(3))
+		for arm := 0; arm < 3; arm++ {
+			assert.Nil(b.Update(arm, 0))
+		}
+	}
+	assert.Nil(backoff.SetBackoff(0.5))
+
+	for _, b := range []Bandit{epsilonGreedy, backoff, adaptive, quantile} {
+		for i := 0; i < 30; i++ {
+			arm, err := b.SelectArm(0)
+			assert.Nil(err)
+			assert.True(arm >= 0 && arm < 3, "should select a valid arm, got %d", arm)
+		}
+	}
+}
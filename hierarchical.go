@@ -0,0 +1,213 @@
+package bandit
+
+import "sync"
+
+// HierarchicalBandit allocates the exploration across groups of arms first,
+// and within the selected group second, e.g. ad networks with several
+// creatives each. The arms are numbered globally, group after group.
+type HierarchicalBandit struct {
+	sync.RWMutex
+
+	// Top selects the group, and is rewarded with the rewards of all the
+	// arms of the group
+	Top Bandit
+
+	// Groups select the arm within each group
+	Groups []Bandit
+
+	// Rand draws the probability of the selection within the group, and
+	// defaults to the math/rand source
+	Rand Rand
+
+	sizes []int
+}
+
+// Init will initialise every level, where nArms must be the total number of
+// arms of the groups. Levels that can start over, e.g. EpsilonGreedy, are
+// reset.
+func (b *HierarchicalBandit) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 || nArms != sum(b.sizes...) {
+		return ErrInvalidArms
+	}
+	return b.init()
+}
+
+func (b *HierarchicalBandit) init() error {
+	if err := resetBandit(b.Top, len(b.Groups)); err != nil {
+		return err
+	}
+	for i, group := range b.Groups {
+		if err := resetBandit(group, b.sizes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetter is a bandit that can start over once initialized, e.g.
+// EpsilonGreedy
+type resetter interface {
+	Reset(nArms int) error
+}
+
+// resetBandit resets the bandit when it can start over, and initialises it
+// otherwise
+func resetBandit(b Bandit, nArms int) error {
+	if r, ok := b.(resetter); ok {
+		return r.Reset(nArms)
+	}
+	return b.Init(nArms)
+}
+
+// SelectArm chooses a group with the probability, then an arm within the
+// group, and returns the global index of the arm. It holds the write lock,
+// since the draw from Rand is not safe for concurrent use.
+func (b *HierarchicalBandit) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	group, err := b.Top.SelectArm(probability)
+	if err != nil {
+		return -1, err
+	}
+	arm, err := b.Groups[group].SelectArm(b.float64())
+	if err != nil {
+		return -1, err
+	}
+	return b.offset(group) + arm, nil
+}
+
+func (b *HierarchicalBandit) float64() float64 {
+	return randFloat64(b.Rand)
+}
+
+// offset returns the global index of the first arm of the group
+func (b *HierarchicalBandit) offset(group int) int {
+	return sum(b.sizes[:group]...)
+}
+
+// Locate returns the group of the arm, and its index within the group
+func (b *HierarchicalBandit) Locate(arm int) (group, index int, err error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.locate(arm)
+}
+
+func (b *HierarchicalBandit) locate(arm int) (group, index int, err error) {
+	index, nArms := arm, 0
+	for group, size := range b.sizes {
+		if index >= 0 && index < size {
+			return group, index, nil
+		}
+		index -= size
+		nArms += size
+	}
+	return -1, -1, &ArmIndexError{Index: arm, NumArms: nArms}
+}
+
+// Update will update the arm within its group, and the group with the same
+// reward. The update is validated against both levels before either is
+// updated, so that a rejected update leaves them consistent.
+func (b *HierarchicalBandit) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	group, index, err := b.locate(chosenArm)
+	if err != nil {
+		return err
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+	if err := validateBanditUpdate(b.Groups[group], index, reward); err != nil {
+		return err
+	}
+	if err := validateBanditUpdate(b.Top, group, reward); err != nil {
+		return err
+	}
+	if err := b.Groups[group].Update(index, reward); err != nil {
+		return err
+	}
+	return b.Top.Update(group, reward)
+}
+
+// GroupSizes returns the number of arms of each group
+func (b *HierarchicalBandit) GroupSizes() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.sizes))
+	copy(sCopy, b.sizes)
+	return sCopy
+}
+
+// GroupCounts returns the counts of each group
+func (b *HierarchicalBandit) GroupCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Top.GetCounts()
+}
+
+// GroupRewards returns the rewards of each group
+func (b *HierarchicalBandit) GroupRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Top.GetRewards()
+}
+
+// GetCounts returns the counts of every arm by its global index
+func (b *HierarchicalBandit) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	var counts []int
+	for _, group := range b.Groups {
+		counts = append(counts, group.GetCounts()...)
+	}
+	return counts
+}
+
+// GetRewards returns the rewards of every arm by its global index
+func (b *HierarchicalBandit) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	var rewards []float64
+	for _, group := range b.Groups {
+		rewards = append(rewards, group.GetRewards()...)
+	}
+	return rewards
+}
+
+// NewHierarchicalBandit returns a pointer to the HierarchicalBandit struct,
+// where the group bandits have the number of arms of sizes, and top selects
+// among the groups. Every level is initialised.
+func NewHierarchicalBandit(top Bandit, groups []Bandit, sizes []int) (*HierarchicalBandit, error) {
+	if len(groups) != len(sizes) {
+		return nil, ErrInvalidLength
+	}
+	if len(groups) == 0 {
+		return nil, ErrInvalidArms
+	}
+	for _, size := range sizes {
+		if size < 1 {
+			return nil, ErrInvalidArms
+		}
+	}
+
+	b := &HierarchicalBandit{
+		Top:    top,
+		Groups: groups,
+		sizes:  append([]int(nil), sizes...),
+	}
+	if err := b.init(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
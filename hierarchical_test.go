@@ -0,0 +1,168 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHierarchical(sizes ...int) (*HierarchicalBandit, error) {
+	top, _ := NewUCB(nil, nil)
+	groups := make([]Bandit, len(sizes))
+	for i := range groups {
+		group, _ := NewEpsilonGreedy(0.1, nil, nil)
+		group.Rand = rand.New(rand.NewSource(int64(i)))
+		groups[i] = group
+	}
+	return NewHierarchicalBandit(top, groups, sizes)
+}
+
+func TestNewHierarchicalBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		sizes []int
+		err   error
+	}{
+		{[]int{1}, nil},
+		{[]int{2, 3}, nil},
+		{nil, ErrInvalidArms},
+		{[]int{2, 0}, ErrInvalidArms},
+	}
+
+	for _, tt := range tests {
+		b, err := newTestHierarchical(tt.sizes...)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.sizes, b.GroupSizes(), "sizes should be equal")
+			assert.Equal(sum(tt.sizes...), len(b.GetCounts()), "should have every arm")
+			assert.Equal(len(tt.sizes), len(b.GroupCounts()), "should have every group")
+		}
+	}
+
+	top, _ := NewUCB(nil, nil)
+	_, err := NewHierarchicalBandit(top, nil, []int{1})
+	assert.Equal(ErrInvalidLength, err, "should throw error for mismatched groups")
+}
+
+func TestHierarchicalBandit_Init(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := newTestHierarchical(2, 3)
+	assert.Nil(err)
+	assert.Nil(b.Update(4, 1.0))
+
+	assert.Equal(ErrInvalidArms, b.Init(4), "should throw error for a different number of arms")
+	assert.Nil(b.Init(5))
+	assert.Equal([]int{0, 0, 0, 0, 0}, b.GetCounts(), "should reset the arms")
+	assert.Equal([]int{0, 0}, b.GroupCounts(), "should reset the groups")
+}
+
+func TestHierarchicalBandit_Locate(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := newTestHierarchical(2, 3)
+	assert.Nil(err)
+
+	tests := []struct {
+		arm   int
+		group int
+		index int
+		err   error
+	}{
+		{0, 0, 0, nil},
+		{1, 0, 1, nil},
+		{2, 1, 0, nil},
+		{4, 1, 2, nil},
+		{5, -1, -1, ErrArmsIndexOutOfRange},
+		{-1, -1, -1, ErrArmsIndexOutOfRange},
+	}
+
+	for _, tt := range tests {
+		group, index, err := b.Locate(tt.arm)
+		assert.True(errors.Is(err, tt.err))
+		assert.Equal(tt.group, group, "group should be equal")
+		assert.Equal(tt.index, index, "index should be equal")
+	}
+}
+
+func TestHierarchicalBandit_Update(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := newTestHierarchical(2, 3)
+	assert.Nil(err)
+
+	assert.Nil(b.Update(3, 1.0))
+	assert.Nil(b.Update(2, 0.0))
+	assert.Nil(b.Update(0, 0.5))
+	assert.True(errors.Is(b.Update(5, 1.0), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidReward, b.Update(0, -1.0))
+
+	assert.Equal([]int{1, 0, 1, 1, 0}, b.GetCounts(), "should update the arms")
+	assert.Equal([]float64{0.5, 0, 0, 1, 0}, b.GetRewards(), "should update the arms")
+	assert.Equal([]int{1, 2}, b.GroupCounts(), "should update the groups")
+	assert.Equal([]float64{0.5, 0.5}, b.GroupRewards(), "should update the groups")
+}
+
+func TestHierarchicalBandit_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := newTestHierarchical(2, 3)
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	payout := []float64{0.2, 0.1, 0.8, 0.9, 0.7}
+	for i := 0; i < 500; i++ {
+		arm, err := b.SelectArm(0)
+		assert.Nil(err)
+		assert.Nil(b.Update(arm, payout[arm]))
+	}
+
+	counts := b.GroupCounts()
+	assert.Equal(500, counts[0]+counts[1])
+	assert.Greater(counts[1], 4*counts[0], "should favor the strong group")
+}
+
+func TestHierarchicalBandit_SelectArmConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := newTestHierarchical(2, 3)
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, err := b.SelectArm(0.5)
+				assert.Nil(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestHierarchicalBandit_UpdateRejectedByTop(t *testing.T) {
+	assert := assert.New(t)
+
+	top, _ := NewEpsilonGreedy(0.1, nil, nil)
+	group, _ := NewEpsilonGreedy(0.1, nil, nil)
+	b, err := NewHierarchicalBandit(top, []Bandit{group}, []int{2})
+	assert.Nil(err)
+
+	top.Frozen = true
+	assert.Equal(ErrFrozen, b.Update(1, 1.0))
+	assert.Equal([]int{0, 0}, b.GetCounts(), "should not update the group when the top rejects the update")
+
+	top.Frozen = false
+	group.Frozen = true
+	assert.Equal(ErrFrozen, b.Update(1, 1.0))
+	assert.Equal([]int{0}, b.GroupCounts(), "should not update the top when the group rejects the update")
+}
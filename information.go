@@ -0,0 +1,16 @@
+package bandit
+
+// InformationGain returns a heuristic value of information of exploring each
+// arm next, for diagnostics only. It is 1/(n+1) for an arm with n observed
+// rewards, so an unplayed arm has a gain of 1, and the gain falls as the mean
+// of the arm becomes more certain.
+func (b *EpsilonGreedy) InformationGain() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	gains := make([]float64, len(b.Counts))
+	for i := range gains {
+		gains[i] = 1 / float64(b.observations(i)+1)
+	}
+	return gains
+}
@@ -0,0 +1,30 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_InformationGain(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(4))
+
+	assert.Equal([]float64{1, 1, 1, 1}, b.InformationGain(), "unplayed arms should have the highest gain")
+
+	for arm, pulls := range []int{0, 1, 3, 9} {
+		for i := 0; i < pulls; i++ {
+			assert.Nil(b.Update(arm, 0.5))
+		}
+	}
+	assert.Nil(b.RecordPull(3))
+
+	gains := b.InformationGain()
+	assert.Equal([]float64{1, 0.5, 0.25, 0.1}, gains, "should only count the observed rewards")
+	for i := 1; i < len(gains); i++ {
+		assert.Greater(gains[i-1], gains[i], "less pulled arms should have a higher gain")
+	}
+}
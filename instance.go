@@ -0,0 +1,35 @@
+package bandit
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// instanceDraw draws a number in range 0 to 1 exclusive for exploration,
+// shifted by a hash of the InstanceID and the selection count. The shift is
+// independent of the draw, so the draw stays uniform, while instances with
+// different IDs land on different arms from the same seed.
+func (b *EpsilonGreedy) instanceDraw() float64 {
+	u := b.float64()
+	if b.InstanceID == "" {
+		return u
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(b.InstanceID))
+	shift := float64(splitmix64(h.Sum64()^uint64(b.SelectionCount))>>11) / (1 << 53)
+	u += shift
+	if u >= 1 {
+		u--
+	}
+	return math.Min(u, math.Nextafter(1, 0))
+}
+
+// splitmix64 scrambles the bits of x, so that nearby inputs give unrelated
+// outputs
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
@@ -0,0 +1,77 @@
+package bandit
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_InstanceID(t *testing.T) {
+	assert := assert.New(t)
+
+	explore := func(instanceID string) []int {
+		b, _ := NewEpsilonGreedy(1, nil, nil)
+		assert.Nil(b.Init(10))
+		b.Rand = rand.New(rand.NewSource(1))
+		b.InstanceID = instanceID
+
+		arms := make([]int, 50)
+		for i := range arms {
+			arm, err := b.SelectArm(0)
+			assert.Nil(err)
+			arms[i] = arm
+		}
+		return arms
+	}
+
+	assert.Equal(explore(""), explore(""), "should explore in lockstep from the same seed")
+	assert.Equal(explore("pod-a"), explore("pod-a"), "should stay reproducible per instance")
+
+	a, b := explore("pod-a"), explore("pod-b")
+	assert.NotEqual(a, b, "should decorrelate the instances")
+	var same int
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	assert.Less(same, 15, "should rarely explore the same arm at the same step")
+}
+
+func TestEpsilonGreedy_InstanceIDUniform(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(1, nil, nil)
+	assert.Nil(b.Init(4))
+	b.Rand = rand.New(rand.NewSource(1))
+	b.InstanceID = "pod-a"
+
+	counts := make([]int, 4)
+	for i := 0; i < 4000; i++ {
+		arm, err := b.SelectArm(0)
+		assert.Nil(err)
+		counts[arm]++
+	}
+	for arm, count := range counts {
+		assert.InDelta(1000, count, 100, "should explore arm %d uniformly", arm)
+	}
+}
+
+func TestEpsilonGreedy_InstanceIDPerInstance(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	b.InstanceID = "pod-a"
+
+	data, err := json.Marshal(b)
+	assert.Nil(err)
+	assert.NotContains(string(data), "pod-a", "should not marshal the instance")
+
+	checkpoint := b.CheckpointState()
+	b.InstanceID = "pod-b"
+	assert.Nil(b.RestoreCheckpoint(checkpoint))
+	assert.Equal("pod-b", b.InstanceID, "should keep the instance of the restoring bandit")
+}
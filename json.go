@@ -0,0 +1,41 @@
+package bandit
+
+import "encoding/json"
+
+// epsilonGreedyJSON has the fields of EpsilonGreedy without its methods, so
+// encoding and decoding it does not recurse into MarshalJSON and UnmarshalJSON
+type epsilonGreedyJSON EpsilonGreedy
+
+// MarshalJSON encodes the state under the read lock, tagged with the current
+// StateVersion
+func (b *EpsilonGreedy) MarshalJSON() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.marshalState()
+}
+
+// marshalState encodes the state under the lock held by the caller
+func (b *EpsilonGreedy) marshalState() ([]byte, error) {
+	return json.Marshal(struct {
+		Version int `json:"version"`
+		*epsilonGreedyJSON
+	}{StateVersion, (*epsilonGreedyJSON)(b)})
+}
+
+// UnmarshalJSON decodes a persisted state of any version, upgrading it with
+// Migrate first. The rewards are accepted under either the "values" key
+// written by json.Marshal or the "rewards" key, where "values" wins when both
+// are present. The counts are under the "counts" key.
+func (b *EpsilonGreedy) UnmarshalJSON(data []byte) error {
+	migrated, err := Migrate(data)
+	if err != nil {
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.invalidateBest()
+	return json.Unmarshal(migrated, (*epsilonGreedyJSON)(b))
+}
@@ -0,0 +1,48 @@
+package bandit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_UnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		data    string
+		rewards []float64
+	}{
+		{`{"epsilon": 0.1, "counts": [1, 2], "values": [0.5, 0.25]}`, []float64{0.5, 0.25}},
+		{`{"epsilon": 0.1, "counts": [1, 2], "rewards": [0.5, 0.25]}`, []float64{0.5, 0.25}},
+		{`{"epsilon": 0.1, "counts": [1, 2], "values": [0.5, 0.25], "rewards": [1, 1]}`, []float64{0.5, 0.25}},
+		{`{"epsilon": 0.1, "counts": [1, 2]}`, nil},
+	}
+
+	for _, tt := range tests {
+		var b EpsilonGreedy
+		assert.Nil(json.Unmarshal([]byte(tt.data), &b))
+		assert.Equal(0.1, b.Epsilon)
+		assert.Equal([]int{1, 2}, b.Counts, "should load the counts")
+		assert.Equal(tt.rewards, b.Rewards, "should load the rewards for %s", tt.data)
+	}
+
+	var b EpsilonGreedy
+	assert.NotNil(json.Unmarshal([]byte(`{"rewards": "high"}`), &b), "should throw error for invalid rewards")
+}
+
+func TestEpsilonGreedy_JSONRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{1, 2}, []float64{0.5, 0.25})
+	assert.Nil(err)
+	data, err := json.Marshal(b)
+	assert.Nil(err)
+	assert.Contains(string(data), `"values":[0.5,0.25]`, "should keep writing the values key")
+
+	restored := &EpsilonGreedy{}
+	assert.Nil(json.Unmarshal(data, restored))
+	assert.Equal(b.GetCounts(), restored.GetCounts())
+	assert.Equal(b.GetRewards(), restored.GetRewards())
+}
@@ -0,0 +1,26 @@
+package bandit
+
+// EstimatedMeans returns the means the arms are exploited by, which are the
+// Laplace smoothed means when Laplace is set and the rewards otherwise
+func (b *EpsilonGreedy) EstimatedMeans() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.Laplace > 0 {
+		return b.laplaceMeans()
+	}
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// laplaceMeans returns the mean of each arm with Laplace pseudo-successes and
+// pseudo-failures added to its rewards
+func (b *EpsilonGreedy) laplaceMeans() []float64 {
+	means := make([]float64, len(b.Rewards))
+	for i, mean := range b.Rewards {
+		n := float64(b.observations(i))
+		means[i] = (mean*n + b.Laplace) / (n + 2*b.Laplace)
+	}
+	return means
+}
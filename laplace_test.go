@@ -0,0 +1,28 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_Laplace(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.Update(0, 1))
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(1, float64(i%10/9)))
+	}
+	assert.Equal([]float64{1, 0.1, 0}, b.EstimatedMeans(), "should not smooth by default")
+
+	b.Laplace = 1
+	means := b.EstimatedMeans()
+	assert.InDelta(2.0/3, means[0], 1e-9, "should pull a single success toward 0.5")
+	assert.InDelta(11.0/102, means[1], 1e-9, "should barely move a well pulled arm")
+	assert.Equal(0.5, means[2], "should start unplayed arms at 0.5")
+	assert.Equal([]float64{1, 0.1, 0}, b.GetRewards(), "should keep the raw means")
+	assert.Equal(1.0, b.Clone().Laplace)
+}
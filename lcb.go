@@ -0,0 +1,34 @@
+package bandit
+
+import "math"
+
+// SelectArmLCB returns the enabled arm with the highest lower confidence
+// bound, mean - z·sqrt(variance/n), for risk-averse serving that prefers arms
+// that are reliably good over arms that are sometimes great. Arms with fewer
+// than two rewards assume the variance of 0.25, the variance is most precise
+// with StableMean, and ties are broken randomly. Unlike SelectArm it never
+// explores and does not count as a selection. It returns -1 when no arm is
+// enabled.
+func (b *EpsilonGreedy) SelectArmLCB(z float64) int {
+	b.Lock()
+	defer b.Unlock()
+
+	b.scratch = b.appendEnabled(b.scratch[:0])
+	best, ties := -1, 0
+	value := math.Inf(-1)
+	for _, i := range b.scratch {
+		lcb := b.Rewards[i] - z*b.standardError(i)
+		switch {
+		case lcb > value:
+			best, ties, value = i, 1, lcb
+		case lcb == value:
+			// NOTE: Each tied arm replaces the best with probability 1/ties,
+			// which picks uniformly among them without a buffer
+			ties++
+			if b.intn(ties) == 0 {
+				best = i
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,48 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SelectArmLCB(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Equal(-1, b.SelectArmLCB(1), "should return -1 without arms")
+	assert.Nil(b.Init(2))
+	b.StableMean = true
+
+	// The first arm is steady at 0.5, the second swings around 0.6
+	for i := 0; i < 20; i++ {
+		assert.Nil(b.Update(0, 0.45+0.1*float64(i%2)))
+		assert.Nil(b.Update(1, 1.2*float64(i%2)))
+	}
+
+	assert.Equal(1, b.SelectArmLCB(0), "should select the best mean without risk aversion")
+	assert.Equal(0, b.SelectArmLCB(2), "should prefer the steady arm at high z")
+
+	assert.Nil(b.Disable(0))
+	assert.Equal(1, b.SelectArmLCB(2), "should skip disabled arms")
+	assert.Equal(20, b.GetCounts()[0])
+	assert.Equal(0, b.GetSelectionCount(), "should not count as a selection")
+}
+
+func TestEpsilonGreedy_SelectArmLCBTies(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, []int{2, 2, 2}, []float64{0.5, 0.5, 0.5})
+	b.M2 = []float64{0.1, 0.1, 0.1}
+	b.Rand = rand.New(rand.NewSource(1))
+
+	seen := make(map[int]int)
+	for i := 0; i < 300; i++ {
+		seen[b.SelectArmLCB(1)]++
+	}
+	assert.Equal(3, len(seen), "should break ties randomly")
+	for arm, n := range seen {
+		assert.InDelta(100, n, 30, "arm %d should win a third of the ties", arm)
+	}
+}
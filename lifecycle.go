@@ -0,0 +1,156 @@
+package bandit
+
+import "slices"
+
+// AddArm appends an unplayed arm, and returns its index. The arm is enabled,
+// costs one unit when costs are set, and is similar to no other arm when
+// similarities are set. The smoothed probabilities, the recent rewards of the
+// Winsorizer, Drift detector and BestWindow, and the distinct rewards start
+// over, since they depend on the number of arms. OnAddArm is called once the
+// lock is released.
+func (b *EpsilonGreedy) AddArm() (int, error) {
+	b.Lock()
+	index, err := b.addArm()
+	onAddArm := b.OnAddArm
+	b.Unlock()
+
+	if err != nil {
+		return -1, err
+	}
+	if onAddArm != nil {
+		onAddArm(index)
+	}
+	return index, nil
+}
+
+func (b *EpsilonGreedy) addArm() (int, error) {
+	if !b.initialized() {
+		return -1, ErrNotInitialized
+	}
+
+	nArms := len(b.Rewards)
+	appendIfSized(&b.Observations, nArms, 0)
+	appendIfSized(&b.M2, nArms, 0)
+	appendIfSized(&b.M2Counts, nArms, 0)
+	appendIfSized(&b.Weights, nArms, 0)
+	appendIfSized(&b.SquaredWeights, nArms, 0)
+	appendIfSized(&b.Precisions, nArms, 0)
+	appendIfSized(&b.PrecisionCounts, nArms, 0)
+	appendIfSized(&b.Disabled, nArms, false)
+	appendIfSized(&b.ObjectiveCounts, nArms, 0)
+	for objective := range b.ObjectiveMeans {
+		means := b.ObjectiveMeans[objective]
+		appendIfSized(&means, nArms, 0)
+		b.ObjectiveMeans[objective] = means
+	}
+	appendIfSized(&b.CooldownUntil, nArms, 0)
+	appendIfSized(&b.Costs, nArms, 1)
+	addSimilarArm(&b.SimilarityMatrix, nArms)
+	appendIfSized(&b.ZeroStreaks, nArms, 0)
+	appendIfSized(&b.Targets, nArms, 0)
+	appendIfSized(&b.Earned, nArms, 0)
+	b.Smoothed = nil
+
+	b.Counts = append(b.Counts, 0)
+	b.Rewards = append(b.Rewards, 0)
+	return nArms, nil
+}
+
+// RemoveArm removes an arm, and moves the arms after it down by one index.
+// The last arm cannot be removed, nor an arm that others are aliased to. The
+// smoothed probabilities, the recent rewards of the Winsorizer, Drift detector
+// and BestWindow, and the distinct rewards start over, while the Reservoir and
+// Trace keep the old indices. OnRemoveArm is called once the lock is released.
+func (b *EpsilonGreedy) RemoveArm(index int) error {
+	b.Lock()
+	err := b.removeArm(index)
+	onRemoveArm := b.OnRemoveArm
+	b.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if onRemoveArm != nil {
+		onRemoveArm(index)
+	}
+	return nil
+}
+
+func (b *EpsilonGreedy) removeArm(index int) error {
+	if !b.initialized() {
+		return ErrNotInitialized
+	}
+	if index < 0 || index >= len(b.Rewards) {
+		return &ArmIndexError{Index: index, NumArms: len(b.Rewards)}
+	}
+	if len(b.Rewards) == 1 {
+		return ErrInvalidArms
+	}
+	for _, canonical := range b.Aliases {
+		if canonical == index {
+			return ErrInvalidAlias
+		}
+	}
+
+	nArms := len(b.Rewards)
+	deleteIfSized(&b.Observations, nArms, index)
+	deleteIfSized(&b.M2, nArms, index)
+	deleteIfSized(&b.M2Counts, nArms, index)
+	deleteIfSized(&b.Weights, nArms, index)
+	deleteIfSized(&b.SquaredWeights, nArms, index)
+	deleteIfSized(&b.Precisions, nArms, index)
+	deleteIfSized(&b.PrecisionCounts, nArms, index)
+	deleteIfSized(&b.Disabled, nArms, index)
+	deleteIfSized(&b.ObjectiveCounts, nArms, index)
+	for objective := range b.ObjectiveMeans {
+		means := b.ObjectiveMeans[objective]
+		deleteIfSized(&means, nArms, index)
+		b.ObjectiveMeans[objective] = means
+	}
+	deleteIfSized(&b.CooldownUntil, nArms, index)
+	deleteIfSized(&b.Costs, nArms, index)
+	deleteSimilarArm(&b.SimilarityMatrix, nArms, index)
+	deleteIfSized(&b.ZeroStreaks, nArms, index)
+	deleteIfSized(&b.Targets, nArms, index)
+	deleteIfSized(&b.Earned, nArms, index)
+	b.Smoothed = nil
+
+	if b.Aliases != nil {
+		aliases := make(map[int]int, len(b.Aliases))
+		for from, to := range b.Aliases {
+			if from == index {
+				continue
+			}
+			aliases[shiftDown(from, index)] = shiftDown(to, index)
+		}
+		b.Aliases = aliases
+	}
+
+	b.Counts = slices.Delete(b.Counts, index, index+1)
+	b.Rewards = slices.Delete(b.Rewards, index, index+1)
+	return nil
+}
+
+// appendIfSized appends the value to the per-arm state, unless the state is
+// missing, i.e. not sized to the number of arms
+func appendIfSized[T any](values *[]T, nArms int, value T) {
+	if len(*values) == nArms {
+		*values = append(*values, value)
+	}
+}
+
+// deleteIfSized deletes the arm from the per-arm state, unless the state is
+// missing
+func deleteIfSized[T any](values *[]T, nArms, index int) {
+	if len(*values) == nArms {
+		*values = slices.Delete(*values, index, index+1)
+	}
+}
+
+// shiftDown returns the index of an arm once the arm at removed is gone
+func shiftDown(arm, removed int) int {
+	if arm > removed {
+		return arm - 1
+	}
+	return arm
+}
@@ -0,0 +1,74 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_AddArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &EpsilonGreedy{}
+	_, err := b.AddArm()
+	assert.Equal(ErrNotInitialized, err)
+
+	var added []int
+	b.OnAddArm = func(index int) {
+		added = append(added, index)
+		// The callback runs without the lock
+		assert.Equal(index+1, len(b.GetCounts()))
+	}
+	assert.Nil(b.Init(2))
+	assert.Nil(b.SetCosts([]float64{2, 3}))
+	assert.Nil(b.Update(1, 1.0))
+
+	index, err := b.AddArm()
+	assert.Nil(err)
+	assert.Equal(2, index)
+	index, err = b.AddArm()
+	assert.Nil(err)
+	assert.Equal(3, index)
+
+	assert.Equal([]int{2, 3}, added, "should call back with the new indices")
+	assert.Equal([]int{0, 1, 0, 0}, b.GetCounts())
+	assert.Equal([]float64{0, 1, 0, 0}, b.GetRewards())
+	assert.Equal([]int{0, 1, 0, 0}, b.GetObservations())
+	assert.Equal([]float64{2, 3, 1, 1}, b.GetConfig().Costs, "should give the new arms a unit cost")
+	assert.Nil(b.Update(3, 1.0))
+	assert.Equal(4, len(b.EnabledArms()))
+}
+
+func TestEpsilonGreedy_RemoveArm(t *testing.T) {
+	assert := assert.New(t)
+
+	var removed, nArms []int
+	b, _ := NewEpsilonGreedy(0.0, nil, nil)
+	b.OnRemoveArm = func(index int) {
+		removed = append(removed, index)
+		nArms = append(nArms, len(b.GetCounts()))
+	}
+	assert.Nil(b.Init(4))
+	for arm := 0; arm < 4; arm++ {
+		assert.Nil(b.Update(arm, float64(arm)/4))
+	}
+	assert.Nil(b.Disable(2))
+	assert.Nil(b.AliasArm(3, 0))
+
+	assert.Equal(ErrInvalidAlias, b.RemoveArm(0), "should not remove an arm with aliases")
+	assert.True(errors.Is(b.RemoveArm(4), ErrArmsIndexOutOfRange))
+	assert.Nil(removed)
+
+	assert.Nil(b.RemoveArm(1))
+	assert.Equal([]int{1}, removed, "should call back with the removed index")
+	assert.Equal([]int{2, 1, 0}, b.GetCounts())
+	assert.Equal([]int{0}, b.EnabledArms(), "should move the disabled arm and alias down")
+	assert.Equal(0, b.CanonicalArm(2))
+
+	assert.Nil(b.RemoveArm(2))
+	assert.Nil(b.RemoveArm(1))
+	assert.Equal(ErrInvalidArms, b.RemoveArm(0), "should keep the last arm")
+	assert.Equal([]int{1, 2, 1}, removed)
+	assert.Equal([]int{3, 2, 1}, nArms, "should call back after the state change")
+}
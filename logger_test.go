@@ -0,0 +1,61 @@
+package bandit
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_WithLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+	assert.Equal(b, b.WithLogger(logger), "should return the bandit")
+
+	arm, err := b.SelectArm(1.0)
+	assert.Nil(err)
+	assert.Nil(b.Update(arm, 1.0))
+	assert.NotNil(b.Update(5, 1.0))
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var record map[string]interface{}
+		assert.Nil(decoder.Decode(&record))
+		records = append(records, record)
+	}
+
+	assert.Equal(2, len(records), "should log the selection and the successful update")
+	assert.Equal("DEBUG", records[0]["level"])
+	assert.Equal(float64(arm), records[0]["arm"])
+	assert.Equal(false, records[0]["explored"])
+	assert.Equal(0.1, records[0]["epsilon"])
+	assert.InDelta(0.1/2+0.9, records[0]["propensity"], 1e-9)
+	assert.Equal(float64(arm), records[1]["arm"])
+	assert.Equal(1.0, records[1]["reward"])
+}
+
+func TestEpsilonGreedy_WithNilLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+	b.WithLogger(nil)
+
+	arm, err := b.SelectArm(0.0)
+	assert.Nil(err)
+	assert.Nil(b.Update(arm, 1.0))
+}
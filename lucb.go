@@ -0,0 +1,189 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// LUCB represents the lower-upper confidence bound algorithm for best-arm
+// identification at confidence 1-Delta. Each round pulls the arm with the
+// highest mean and the most confusing other arm, the one with the highest
+// upper confidence bound, so the pulls go to the top contenders until
+// ShouldStop. The confidence bounds are the ones of ShouldStop of epsilon
+// greedy, and the rewards must be in range 0 to 1.
+type LUCB struct {
+	sync.RWMutex
+	Delta   float64
+	Counts  []int
+	Rewards []float64
+
+	// M2 holds the running sum of squared deviations from the mean of each
+	// arm, from which the reward variance can be estimated. The confidence
+	// radius does not use it, since the Hoeffding bound assumes the largest
+	// variance.
+	M2 []float64
+
+	// challenger is selected next when pending, as the second pull of the
+	// round
+	challenger int
+	pending    bool
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *LUCB) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.M2 = make([]float64, nArms)
+	b.pending = false
+	return nil
+}
+
+// SelectArm returns the best arm and the challenger of a round on alternating
+// calls, after pulling every arm once. The probability is ignored.
+func (b *LUCB) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(b.Rewards) == 0 {
+		return -1, ErrNotInitialized
+	}
+	for i, count := range b.Counts {
+		if count == 0 {
+			return i, nil
+		}
+	}
+
+	if b.pending {
+		b.pending = false
+		return b.challenger, nil
+	}
+	best, challenger := b.selectPair()
+	if challenger >= 0 {
+		b.challenger, b.pending = challenger, true
+	}
+	return best, nil
+}
+
+// SelectPair returns the arm with the highest mean and the challenger with the
+// highest upper confidence bound among the other arms, to be pulled together.
+// The challenger is -1 with a single arm, and both are -1 without arms.
+func (b *LUCB) SelectPair() (best, challenger int) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(b.Rewards) == 0 {
+		return -1, -1
+	}
+	return b.selectPair()
+}
+
+func (b *LUCB) selectPair() (best, challenger int) {
+	best = max(b.Rewards...)
+	challenger = -1
+	upper := math.Inf(-1)
+	for i := range b.Rewards {
+		if i == best {
+			continue
+		}
+		if u := b.Rewards[i] + b.radius(i); challenger < 0 || u > upper {
+			challenger, upper = i, u
+		}
+	}
+	return best, challenger
+}
+
+// ShouldStop returns whether the best arm is identified with confidence
+// 1-Delta, once its lower confidence bound clears the upper confidence bound
+// of the challenger
+func (b *LUCB) ShouldStop() (stop bool, bestArm int) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(b.Rewards) == 0 {
+		return false, -1
+	}
+	best, challenger := b.selectPair()
+	if challenger < 0 {
+		return true, best
+	}
+	return b.Rewards[best]-b.radius(best) > b.Rewards[challenger]+b.radius(challenger), best
+}
+
+// radius returns the confidence radius of an arm, which is infinite for
+// unplayed arms
+func (b *LUCB) radius(arm int) float64 {
+	n := b.Counts[arm]
+	if n == 0 {
+		return math.Inf(1)
+	}
+	return lucbRadius(float64(n), hoeffdingVariance, float64(totalCounts(b.Counts)), len(b.Rewards), b.Delta)
+}
+
+// Update will update an arm with some reward value in range 0 to 1,
+// e.g. click = 1, no click = 0
+func (b *LUCB) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if err := validateUnitReward(reward); err != nil {
+		return err
+	}
+
+	// NOTE: M2 may be missing when the bandit was created from counts and
+	// rewards alone
+	if len(b.M2) != len(b.Rewards) {
+		b.M2 = make([]float64, len(b.Rewards))
+	}
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+	oldRewards := b.Rewards[chosenArm]
+	b.Rewards[chosenArm] += (reward - oldRewards) / n
+	b.M2[chosenArm] += (reward - oldRewards) * (reward - b.Rewards[chosenArm])
+	return nil
+}
+
+// GetCounts returns the counts
+func (b *LUCB) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *LUCB) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewLUCB returns a pointer to the LUCB struct identifying the best arm with
+// confidence 1-delta, where delta is in range 0 to 1 exclusive
+func NewLUCB(delta float64, counts []int, rewards []float64) (*LUCB, error) {
+	if !(delta > 0 && delta < 1) {
+		return nil, ErrInvalidProbability
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &LUCB{
+		Delta:   delta,
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
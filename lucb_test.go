@@ -0,0 +1,101 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLUCB(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewLUCB(0, nil, nil)
+	assert.Equal(ErrInvalidProbability, err)
+	_, err = NewLUCB(0.05, []int{1}, nil)
+	assert.Equal(ErrInvalidLength, err)
+
+	b, err := NewLUCB(0.05, nil, nil)
+	assert.Nil(err)
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	best, challenger := b.SelectPair()
+	assert.Equal(-1, best)
+	assert.Equal(-1, challenger)
+
+	assert.Nil(b.Init(1))
+	assert.Nil(b.Update(0, 1))
+	best, challenger = b.SelectPair()
+	assert.Equal(0, best)
+	assert.Equal(-1, challenger, "should have no challenger with a single arm")
+	stop, best := b.ShouldStop()
+	assert.True(stop)
+	assert.Equal(0, best)
+	assert.Equal(ErrInvalidReward, b.Update(0, 2))
+}
+
+func TestLUCB_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewLUCB(0.05, nil, nil)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.Update(0, 1))
+
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should pull the unplayed arms first")
+	assert.Nil(b.Update(1, 0))
+	assert.Nil(b.Update(2, 0.5))
+
+	best, challenger := b.SelectPair()
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(best, arm, "should pull the best arm first")
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(challenger, arm, "should pull the challenger second")
+	assert.NotEqual(best, challenger)
+}
+
+func TestLUCB_Concentrates(t *testing.T) {
+	assert := assert.New(t)
+
+	probabilities := []float64{0.1, 0.2, 0.3, 0.75, 0.8}
+	env, err := NewBernoulliEnv(probabilities, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	b, _ := NewLUCB(0.05, nil, nil)
+	assert.Nil(b.Init(len(probabilities)))
+
+	pulls := 4000
+	for i := 0; i < pulls; i++ {
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.Nil(b.Update(arm, env.Pull(arm)))
+	}
+
+	counts := b.GetCounts()
+	assert.Greater(counts[3]+counts[4], pulls*4/5, "should concentrate the pulls on the top two arms")
+	_, best := b.ShouldStop()
+	assert.Equal(4, best)
+}
+
+func TestLUCB_ShouldStopZeroVariance(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewLUCB(0.01, nil, nil)
+	assert.Nil(b.Init(2))
+	for i := 0; i < 2; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0))
+	}
+	stop, best := b.ShouldStop()
+	assert.False(stop, "should not trust a zero spread of a few rewards")
+	assert.Equal(0, best)
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0))
+	}
+	stop, _ = b.ShouldStop()
+	assert.True(stop, "should stop once the Hoeffding bounds separate")
+}
@@ -0,0 +1,112 @@
+package bandit
+
+// MergeWeighted pools the stats of bandits trained on separate shards of the
+// traffic into a new bandit, with the epsilon of the first shard. The counts
+// of each arm are summed, and its mean is weighted by the inverse variance of
+// each shard's estimate:
+//
+//	w_k = n_k / s²_k
+//	mean = Σ w_k·mean_k / Σ w_k
+//
+// where n_k is the number of rewards and s²_k the sample variance of the arm
+// in shard k, so a low-variance shard counts for more than a noisy one of the
+// same size. An arm falls back to the count-weighted mean, Σ n_k·mean_k / Σ
+// n_k, when any shard lacks its variance, i.e. without StableMean, with fewer
+// than two rewards, or with a zero variance.
+func MergeWeighted(shards ...*EpsilonGreedy) (*EpsilonGreedy, error) {
+	if len(shards) == 0 {
+		return nil, ErrInvalidSize
+	}
+
+	// NOTE: Each bandit is copied separately to avoid holding several locks
+	stats := make([]shardStats, len(shards))
+	for i, shard := range shards {
+		stats[i] = shard.shardStats()
+		if len(stats[i].counts) != len(stats[0].counts) {
+			return nil, ErrArmsMismatch
+		}
+	}
+
+	nArms := len(stats[0].counts)
+	counts := make([]int, nArms)
+	rewards := make([]float64, nArms)
+	for arm := range counts {
+		for _, s := range stats {
+			counts[arm] += s.counts[arm]
+		}
+		if mean, ok := inverseVarianceMean(stats, arm); ok {
+			rewards[arm] = mean
+		} else {
+			rewards[arm] = countWeightedMean(stats, arm)
+		}
+	}
+
+	shards[0].RLock()
+	epsilon := shards[0].Epsilon
+	shards[0].RUnlock()
+	return NewEpsilonGreedy(epsilon, counts, rewards)
+}
+
+// shardStats holds a copy of the per-arm stats of a shard, where variances is
+// nil without StableMean
+type shardStats struct {
+	counts       []int
+	rewards      []float64
+	observations []int
+	variances    []float64
+}
+
+func (b *EpsilonGreedy) shardStats() shardStats {
+	b.RLock()
+	defer b.RUnlock()
+
+	s := shardStats{
+		counts:       make([]int, len(b.Counts)),
+		rewards:      make([]float64, len(b.Rewards)),
+		observations: make([]int, len(b.Counts)),
+	}
+	copy(s.counts, b.Counts)
+	copy(s.rewards, b.Rewards)
+	for i := range s.observations {
+		s.observations[i] = b.observations(i)
+	}
+	if b.StableMean && len(b.M2) == len(b.Rewards) {
+		s.variances = make([]float64, len(b.Rewards))
+		for i, n := range s.observations {
+			if n > 1 {
+				s.variances[i] = b.M2[i] / float64(n-1)
+			}
+		}
+	}
+	return s
+}
+
+// inverseVarianceMean returns the inverse-variance weighted mean of the arm,
+// and whether every shard has a variance for it
+func inverseVarianceMean(stats []shardStats, arm int) (float64, bool) {
+	var sum, total float64
+	for _, s := range stats {
+		if s.variances == nil || s.observations[arm] < 2 || s.variances[arm] == 0 {
+			return 0, false
+		}
+		w := float64(s.observations[arm]) / s.variances[arm]
+		sum += w * s.rewards[arm]
+		total += w
+	}
+	return sum / total, true
+}
+
+// countWeightedMean returns the mean of the arm weighted by the number of
+// rewards of each shard
+func countWeightedMean(stats []shardStats, arm int) float64 {
+	var sum float64
+	var total int
+	for _, s := range stats {
+		sum += float64(s.observations[arm]) * s.rewards[arm]
+		total += s.observations[arm]
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / float64(total)
+}
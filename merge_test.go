@@ -0,0 +1,60 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newShard(t *testing.T, stableMean bool, rewards [][]float64) *EpsilonGreedy {
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(t, b.Init(len(rewards)))
+	b.StableMean = stableMean
+	for arm, values := range rewards {
+		for _, reward := range values {
+			assert.Nil(t, b.Update(arm, reward))
+		}
+	}
+	return b
+}
+
+func TestMergeWeighted(t *testing.T) {
+	assert := assert.New(t)
+
+	// The first shard is steady around 0.5, the second swings around 0.9
+	steady := newShard(t, true, [][]float64{{0.4, 0.6, 0.4, 0.6}, {1, 1}})
+	noisy := newShard(t, true, [][]float64{{0.1, 1.7, 0.1, 1.7}, {0}})
+
+	merged, err := MergeWeighted(steady, noisy)
+	assert.Nil(err)
+	assert.Equal(0.1, merged.Epsilon)
+	assert.Equal([]int{8, 3}, merged.GetCounts())
+
+	// Variances are 0.04/3 and 2.56/3, so the weights are 300 and 4.6875
+	w1, w2 := 4/(0.04/3), 4/(2.56/3)
+	rewards := merged.GetRewards()
+	assert.InDelta((w1*0.5+w2*0.9)/(w1+w2), rewards[0], 1e-12, "should weight by inverse variance")
+	assert.InDelta(2.0/3.0, rewards[1], 1e-12, "should fall back to counts without variance")
+
+	// Count weighting gives both shards an equal say
+	unweighted, err := MergeWeighted(newShard(t, false, [][]float64{{0.4, 0.6, 0.4, 0.6}, {1, 1}}), newShard(t, false, [][]float64{{0.1, 1.7, 0.1, 1.7}, {0}}))
+	assert.Nil(err)
+	assert.InDelta(0.7, unweighted.GetRewards()[0], 1e-12)
+	assert.Less(rewards[0]-0.5, unweighted.GetRewards()[0]-0.5, "should trust the steady shard more")
+}
+
+func TestMergeWeightedErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := MergeWeighted()
+	assert.Equal(ErrInvalidSize, err)
+
+	a, _ := NewEpsilonGreedy(0.1, []int{0, 0}, []float64{0, 0})
+	b, _ := NewEpsilonGreedy(0.1, []int{0, 0, 0}, []float64{0, 0, 0})
+	_, err = MergeWeighted(a, b)
+	assert.Equal(ErrArmsMismatch, err)
+
+	merged, err := MergeWeighted(a)
+	assert.Nil(err)
+	assert.Equal([]float64{0, 0}, merged.GetRewards())
+}
@@ -0,0 +1,58 @@
+package bandit
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Metrics represents a snapshot of the bandit stats
+type Metrics struct {
+	Epsilon float64      `json:"epsilon"`
+	Arms    []ArmMetrics `json:"arms"`
+}
+
+// ArmMetrics represents the stats of a single arm, where share is the
+// fraction of the pulls made on the arm
+type ArmMetrics struct {
+	Index int     `json:"index"`
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	Share float64 `json:"share"`
+}
+
+// Metrics returns a consistent snapshot of the bandit stats
+func (b *EpsilonGreedy) Metrics() Metrics {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.metrics()
+}
+
+func (b *EpsilonGreedy) metrics() Metrics {
+	total := totalCounts(b.Counts)
+	arms := make([]ArmMetrics, len(b.Counts))
+	for i, count := range b.Counts {
+		arms[i] = ArmMetrics{
+			Index: i,
+			Count: count,
+			Mean:  b.Rewards[i],
+		}
+		if total > 0 {
+			arms[i].Share = float64(count) / float64(total)
+		}
+	}
+
+	return Metrics{
+		Epsilon: b.epsilon(),
+		Arms:    arms,
+	}
+}
+
+// ServeMetrics writes the bandit stats as json, e.g.
+// http.HandleFunc("/metrics", b.ServeMetrics)
+func (b *EpsilonGreedy) ServeMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(b.Metrics()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,55 @@
+package bandit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ServeMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{1, 3}, []float64{0.0, 0.5})
+	assert.Nil(err)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	b.ServeMetrics(rec, req)
+
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	err = json.Unmarshal(rec.Body.Bytes(), &body)
+	assert.Nil(err)
+	assert.Equal(0.1, body["epsilon"], "should report the epsilon")
+
+	arms, ok := body["arms"].([]interface{})
+	assert.True(ok, "should report the arms")
+	assert.Equal(2, len(arms), "should report every arm")
+	assert.Equal(map[string]interface{}{
+		"index": 1.0,
+		"count": 3.0,
+		"mean":  0.5,
+		"share": 0.75,
+	}, arms[1], "should report the arm stats")
+}
+
+func TestEpsilonGreedy_MetricsWithoutPulls(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	metrics := b.Metrics()
+	assert.Equal(2, len(metrics.Arms), "should report every arm")
+	for _, arm := range metrics.Arms {
+		assert.Equal(0.0, arm.Share, "should report zero share without pulls")
+	}
+}
@@ -0,0 +1,72 @@
+package bandit
+
+import "encoding/json"
+
+// StateVersion is the version of the persisted state of EpsilonGreedy written
+// by json.Marshal. The versions are:
+//
+//   - 1, untagged: "epsilon", "counts", and the mean rewards under "values",
+//     or "rewards" for states stored under the field name
+//   - 2: "version", with "observations", the number of rewards averaged into
+//     each mean, and "m2", the sum of squared deviations of each arm, added
+//
+// The other fields are optional in every version.
+const StateVersion = 2
+
+// Migrate upgrades a persisted state of EpsilonGreedy to StateVersion. The
+// observations of older states are the counts, the variances restart at zero,
+// and the rewards move to the "values" key. States of the current version are
+// returned as is unless their rewards need to move, and newer ones are
+// rejected with ErrUnsupportedVersion.
+func Migrate(oldState []byte) (newState []byte, err error) {
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(oldState, &state); err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if raw, ok := state["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, err
+		}
+	}
+	if version < 1 || version > StateVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	rewards, renamed := state["rewards"]
+	if version == StateVersion && !renamed {
+		return oldState, nil
+	}
+
+	if renamed {
+		if _, ok := state["values"]; !ok {
+			state["values"] = rewards
+		}
+		delete(state, "rewards")
+	}
+	if version >= 2 {
+		return json.Marshal(state)
+	}
+
+	// Version 1 to 2
+	var counts []int
+	if raw, ok := state["counts"]; ok {
+		if err := json.Unmarshal(raw, &counts); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := state["observations"]; !ok && counts != nil {
+		if state["observations"], err = json.Marshal(counts); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := state["m2"]; !ok && counts != nil {
+		if state["m2"], err = json.Marshal(make([]float64, len(counts))); err != nil {
+			return nil, err
+		}
+	}
+	if state["version"], err = json.Marshal(StateVersion); err != nil {
+		return nil, err
+	}
+	return json.Marshal(state)
+}
@@ -0,0 +1,89 @@
+package bandit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		oldState string
+		newState string
+		err      error
+	}{
+		{
+			"v1",
+			`{"epsilon":0.1,"counts":[2,3],"values":[0.5,0.25]}`,
+			`{"counts":[2,3],"epsilon":0.1,"m2":[0,0],"observations":[2,3],"values":[0.5,0.25],"version":2}`,
+			nil,
+		},
+		{
+			"v1 with the rewards key",
+			`{"epsilon":0.1,"counts":[2,3],"rewards":[0.5,0.25]}`,
+			`{"counts":[2,3],"epsilon":0.1,"m2":[0,0],"observations":[2,3],"values":[0.5,0.25],"version":2}`,
+			nil,
+		},
+		{
+			"v1 with observations",
+			`{"epsilon":0.1,"counts":[2,3],"values":[0.5,0.25],"observations":[1,3]}`,
+			`{"counts":[2,3],"epsilon":0.1,"m2":[0,0],"observations":[1,3],"values":[0.5,0.25],"version":2}`,
+			nil,
+		},
+		{
+			"v2",
+			`{"version":2,"epsilon":0.1,"counts":[2,3],"values":[0.5,0.25]}`,
+			`{"version":2,"epsilon":0.1,"counts":[2,3],"values":[0.5,0.25]}`,
+			nil,
+		},
+		{
+			"v2 with the rewards key",
+			`{"version":2,"epsilon":0.1,"counts":[2,3],"rewards":[0.5,0.25]}`,
+			`{"counts":[2,3],"epsilon":0.1,"values":[0.5,0.25],"version":2}`,
+			nil,
+		},
+		{"newer", `{"version":3}`, "", ErrUnsupportedVersion},
+		{"invalid version", `{"version":0}`, "", ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		newState, err := Migrate([]byte(tt.oldState))
+		assert.Equal(tt.err, err, tt.name)
+		if tt.err == nil {
+			assert.Equal(tt.newState, string(newState), "should migrate the %s state", tt.name)
+		}
+	}
+
+	_, err := Migrate([]byte(`{"counts":"many"}`))
+	assert.NotNil(err, "should throw error for invalid counts")
+}
+
+func TestEpsilonGreedy_LoadV1(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &EpsilonGreedy{}
+	assert.Nil(json.Unmarshal([]byte(`{"epsilon":0.1,"counts":[2,3],"values":[0.5,0.25]}`), b))
+	assert.Equal([]int{2, 3}, b.GetCounts(), "should preserve the counts")
+	assert.Equal([]float64{0.5, 0.25}, b.GetRewards())
+	assert.Equal([]int{2, 3}, b.Observations)
+	assert.Equal([]float64{0, 0}, b.M2, "should restart the variances at zero")
+
+	b.StableMean = true
+	assert.Nil(b.Update(0, 0.5))
+	variances, err := b.GetVariances()
+	assert.Nil(err)
+	assert.Equal([]float64{0, 0}, variances)
+
+	data, err := json.Marshal(b)
+	assert.Nil(err)
+	assert.Contains(string(data), `"version":2`, "should tag the state with its version")
+	restored := &EpsilonGreedy{}
+	assert.Nil(json.Unmarshal(data, restored))
+	assert.Equal([]int{3, 3}, restored.GetCounts())
+
+	assert.Equal(ErrUnsupportedVersion, json.Unmarshal([]byte(`{"version":3}`), restored))
+}
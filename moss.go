@@ -0,0 +1,133 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// MOSS represents the minimax optimal strategy in the stochastic case, an
+// upper confidence bound algorithm whose bonus shrinks to zero once an arm is
+// pulled more than its share of the pulls, total/K for K arms. It explores
+// less than UCB1 when there are many arms.
+type MOSS struct {
+	sync.RWMutex
+	Counts  []int
+	Rewards []float64
+
+	// SelectionCount is the number of selections made, which is the time step
+	// of the exploration bonus
+	SelectionCount int
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *MOSS) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.SelectionCount = 0
+	return nil
+}
+
+// SelectArm chooses the arm with the highest upper confidence bound, and an
+// unplayed arm first. The probability is ignored.
+func (b *MOSS) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(b.Rewards) == 0 {
+		return -1, ErrNotInitialized
+	}
+	arm := b.selectArm()
+	b.SelectionCount++
+	return arm, nil
+}
+
+func (b *MOSS) selectArm() int {
+	nArms := len(b.Counts)
+	for i := 0; i < nArms; i++ {
+		if b.Counts[i] == 0 {
+			return i
+		}
+	}
+
+	totalCounts := timeStep(b.SelectionCount, b.Counts)
+	ucbValues := make([]float64, nArms)
+	for i := 0; i < nArms; i++ {
+		ucbValues[i] = b.Rewards[i] + mossBonus(b.Counts[i], totalCounts, nArms)
+	}
+	return max(ucbValues...)
+}
+
+// mossBonus returns the exploration bonus of MOSS,
+// sqrt(max(0, ln(total/(K·count)))/count), for an arm with count pulls out of
+// total pulls over K arms
+func mossBonus(count, total, nArms int) float64 {
+	n := float64(count)
+	return math.Sqrt(math.Max(0, math.Log(float64(total)/(float64(nArms)*n))) / n)
+}
+
+// GetSelectionCount returns the number of selections made
+func (b *MOSS) GetSelectionCount() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.SelectionCount
+}
+
+// Update will update an arm with some reward value in range 0 to 1,
+// e.g. click = 1, no click = 0
+func (b *MOSS) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if err := validateUnitReward(reward); err != nil {
+		return err
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+
+	oldRewards := b.Rewards[chosenArm]
+	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
+	return nil
+}
+
+// GetCounts returns the counts
+func (b *MOSS) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *MOSS) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewMOSS returns a pointer to the MOSS struct
+func NewMOSS(counts []int, rewards []float64) (*MOSS, error) {
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &MOSS{
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
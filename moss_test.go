@@ -0,0 +1,72 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMOSS_New(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewMOSS([]int{0}, []float64{})
+	assert.Equal(ErrInvalidLength, err)
+
+	b, err := NewMOSS(nil, nil)
+	assert.Nil(err)
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Equal(ErrInvalidArms, b.Init(0))
+	assert.Nil(b.Init(3))
+	assert.True(errors.Is(b.Update(3, 1), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidReward, b.Update(0, 1.5))
+}
+
+func TestMOSS_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewMOSS([]int{1, 0, 1}, []float64{1, 0, 1})
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should select the unplayed arm first")
+
+	// With 4 pulls over 2 arms, an arm pulled twice has no bonus left
+	b, _ = NewMOSS([]int{2, 1, 1}, []float64{0.6, 0.5, 0.1})
+	b.SelectionCount = 6
+	assert.Equal(0.0, mossBonus(2, 6, 3))
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should explore the arms below their share")
+	assert.Equal(7, b.GetSelectionCount())
+}
+
+func TestMOSS_Regret(t *testing.T) {
+	assert := assert.New(t)
+
+	// Many arms just below the best one, where UCB1 keeps exploring
+	nArms, pulls := 50, 20000
+	probabilities := make([]float64, nArms)
+	for i := range probabilities {
+		probabilities[i] = 0.45
+	}
+	probabilities[7] = 0.55
+
+	regret := func(b Bandit) float64 {
+		assert.Nil(b.Init(nArms))
+		env, err := NewBernoulliEnv(probabilities, rand.New(rand.NewSource(1)))
+		assert.Nil(err)
+		_, chosenArms, _, _ := Simulate(b, pulls, env)
+
+		var total float64
+		for _, arm := range chosenArms {
+			total += env.OptimalMean() - probabilities[arm]
+		}
+		return total
+	}
+
+	moss := regret(&MOSS{})
+	ucb := regret(&UCB{})
+	assert.Less(moss, ucb, "should have less regret than UCB1 with many arms")
+}
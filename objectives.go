@@ -0,0 +1,98 @@
+package bandit
+
+// UpdateMulti will update an arm with the value of each objective, e.g.
+// clicks and dwell time. The running mean of every objective is kept, and the
+// weighted sum of the values is applied as the reward. Objectives missing from
+// rewards count as zero.
+func (b *EpsilonGreedy) UpdateMulti(chosenArm int, rewards map[string]float64) error {
+	b.Lock()
+	callbacks, err := b.updateMulti(chosenArm, rewards)
+	b.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+	return err
+}
+
+func (b *EpsilonGreedy) updateMulti(chosenArm int, rewards map[string]float64) ([]func(), error) {
+	for _, reward := range rewards {
+		if reward < 0 {
+			return nil, ErrInvalidReward
+		}
+	}
+
+	var reward float64
+	for objective, value := range rewards {
+		reward += b.ObjectiveWeights[objective] * value
+	}
+	callbacks, err := b.update(chosenArm, reward)
+	if err != nil {
+		return nil, err
+	}
+	chosenArm = b.canonical(chosenArm)
+
+	nArms := len(b.Rewards)
+	if b.ObjectiveMeans == nil {
+		b.ObjectiveMeans = make(map[string][]float64)
+	}
+	if len(b.ObjectiveCounts) != nArms {
+		b.ObjectiveCounts = make([]int, nArms)
+	}
+	for objective := range rewards {
+		if len(b.ObjectiveMeans[objective]) != nArms {
+			b.ObjectiveMeans[objective] = make([]float64, nArms)
+		}
+	}
+
+	b.ObjectiveCounts[chosenArm]++
+	n := float64(b.ObjectiveCounts[chosenArm])
+	for objective, means := range b.ObjectiveMeans {
+		means[chosenArm] = (means[chosenArm]*(n-1) + rewards[objective]) / n
+	}
+	return callbacks, nil
+}
+
+// SetObjectiveWeights replaces the objective weights, and recomputes the
+// rewards of every arm that received multi-objective updates from the
+// objective means. This assumes such arms are only updated with UpdateMulti.
+func (b *EpsilonGreedy) SetObjectiveWeights(weights map[string]float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	sCopy := make(map[string]float64, len(weights))
+	for objective, weight := range weights {
+		if weight < 0 {
+			return ErrInvalidWeight
+		}
+		sCopy[objective] = weight
+	}
+	b.ObjectiveWeights = sCopy
+
+	for i, count := range b.ObjectiveCounts {
+		if count == 0 || i >= len(b.Rewards) {
+			continue
+		}
+		var reward float64
+		for objective, means := range b.ObjectiveMeans {
+			reward += b.ObjectiveWeights[objective] * means[i]
+		}
+		b.Rewards[i] = reward
+	}
+	b.invalidateBest()
+	return nil
+}
+
+// GetObjectiveMeans returns the running mean of each objective per arm
+func (b *EpsilonGreedy) GetObjectiveMeans() map[string][]float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	means := make(map[string][]float64, len(b.ObjectiveMeans))
+	for objective, m := range b.ObjectiveMeans {
+		sCopy := make([]float64, len(m))
+		copy(sCopy, m)
+		means[objective] = sCopy
+	}
+	return means
+}
@@ -0,0 +1,64 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_UpdateMulti(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+	assert.Nil(b.SetObjectiveWeights(map[string]float64{"clicks": 1.0, "dwell": 0.0}))
+
+	for i := 0; i < 4; i++ {
+		assert.Nil(b.UpdateMulti(0, map[string]float64{"clicks": 1.0, "dwell": 10.0}))
+		assert.Nil(b.UpdateMulti(1, map[string]float64{"clicks": 0.5, "dwell": 60.0}))
+	}
+	assert.Equal(map[string][]float64{
+		"clicks": {1.0, 0.5},
+		"dwell":  {10.0, 60.0},
+	}, b.GetObjectiveMeans(), "should track the mean of each objective")
+	assert.Equal([]float64{1.0, 0.5}, b.GetRewards(), "should combine the objectives by weight")
+
+	arm, err := b.SelectArm(1.0)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should favour clicks")
+
+	// Shift the weights towards dwell time
+	assert.Nil(b.SetObjectiveWeights(map[string]float64{"clicks": 1.0, "dwell": 0.1}))
+	assert.Equal([]float64{2.0, 6.5}, b.GetRewards(), "should recompute the rewards from the objective means")
+
+	arm, err = b.SelectArm(1.0)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should favour dwell time")
+}
+
+func TestEpsilonGreedy_UpdateMultiWithInvalidParams(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	assert.Equal(ErrInvalidWeight, b.SetObjectiveWeights(map[string]float64{"clicks": -1.0}))
+	assert.True(errors.Is(b.UpdateMulti(2, map[string]float64{"clicks": 1.0}), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidReward, b.UpdateMulti(0, map[string]float64{"clicks": -1.0}))
+	assert.Empty(b.GetObjectiveMeans(), "should not track invalid updates")
+
+	// Objectives missing from an update count as zero
+	assert.Nil(b.UpdateMulti(0, map[string]float64{"clicks": 1.0}))
+	assert.Nil(b.UpdateMulti(0, map[string]float64{"dwell": 4.0}))
+	assert.Equal(map[string][]float64{
+		"clicks": {0.5, 0.0},
+		"dwell":  {2.0, 0.0},
+	}, b.GetObjectiveMeans())
+}
@@ -0,0 +1,74 @@
+package bandit
+
+// LoggedEvent represents a logged selection, with the propensity of the
+// logging policy selecting the arm
+type LoggedEvent struct {
+	Arm        int     `json:"arm"`
+	Reward     float64 `json:"reward"`
+	Propensity float64 `json:"propensity"`
+}
+
+// OffPolicyEstimate holds the estimates of the mean reward of a target policy
+// from logged events. IPS is the inverse propensity score estimate
+// sum(w·r)/n, and WIS the weighted importance sampling estimate
+// sum(w·r)/sum(w), where w is the target propensity over the logged one. WIS
+// is biased but has a much lower variance when the weights are uneven.
+type OffPolicyEstimate struct {
+	IPS    float64 `json:"ips"`
+	WIS    float64 `json:"wis"`
+	Events int     `json:"events"`
+}
+
+// EvaluateOffPolicy estimates the mean reward of the target policy, which
+// selects each arm with the target probability, e.g. from Propensities, out of
+// the events logged by another policy. The logged propensities must be in
+// range 0 to 1, exclusive of 0. WIS is zero when the target selects none of
+// the logged arms.
+func EvaluateOffPolicy(events []LoggedEvent, target []float64) (OffPolicyEstimate, error) {
+	var weighted, weights float64
+	for _, event := range events {
+		if event.Arm < 0 || event.Arm >= len(target) {
+			return OffPolicyEstimate{}, &ArmIndexError{Index: event.Arm, NumArms: len(target)}
+		}
+		if !(event.Propensity > 0 && event.Propensity <= 1) {
+			return OffPolicyEstimate{}, ErrInvalidProbability
+		}
+		w := target[event.Arm] / event.Propensity
+		weighted += w * event.Reward
+		weights += w
+	}
+
+	estimate := OffPolicyEstimate{Events: len(events)}
+	if len(events) > 0 {
+		estimate.IPS = weighted / float64(len(events))
+	}
+	if weights > 0 {
+		estimate.WIS = weighted / weights
+	}
+	return estimate, nil
+}
+
+// Propensities returns the probability of the next selection choosing each
+// arm, including the smoothing of the probabilities when set, e.g. as the
+// target of EvaluateOffPolicy or to log with the selections
+func (b *EpsilonGreedy) Propensities() ([]float64, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.initialized() {
+		return nil, ErrNotInitialized
+	}
+	if err := b.loadPrior(); err != nil {
+		return nil, err
+	}
+	probs, _, err := b.policyProbabilities()
+	if err != nil {
+		return nil, err
+	}
+	if b.Smoothing > 0 && len(b.Smoothed) == len(probs) {
+		smoothed := make([]float64, len(probs))
+		b.blendSmoothed(smoothed, probs)
+		return smoothed, nil
+	}
+	return probs, nil
+}
@@ -0,0 +1,90 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateOffPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	estimate, err := EvaluateOffPolicy(nil, []float64{0.5, 0.5})
+	assert.Nil(err)
+	assert.Equal(OffPolicyEstimate{}, estimate)
+
+	_, err = EvaluateOffPolicy([]LoggedEvent{{Arm: 2, Reward: 1, Propensity: 0.5}}, []float64{0.5, 0.5})
+	assert.True(errors.Is(err, ErrArmsIndexOutOfRange))
+	_, err = EvaluateOffPolicy([]LoggedEvent{{Arm: 0, Reward: 1, Propensity: 0}}, []float64{0.5, 0.5})
+	assert.Equal(ErrInvalidProbability, err)
+
+	events := []LoggedEvent{
+		{Arm: 0, Reward: 1, Propensity: 0.5},
+		{Arm: 1, Reward: 0, Propensity: 0.5},
+	}
+	estimate, err = EvaluateOffPolicy(events, []float64{1, 0})
+	assert.Nil(err)
+	assert.Equal(OffPolicyEstimate{IPS: 1, WIS: 1, Events: 2}, estimate)
+}
+
+func TestEvaluateOffPolicy_Variance(t *testing.T) {
+	assert := assert.New(t)
+
+	// The logging policy rarely selects the arm the target policy favors, so
+	// the rare events carry large weights
+	logging := []float64{0.95, 0.05}
+	target := []float64{0.1, 0.9}
+	means := []float64{0.5, 0.8}
+	value := target[0]*means[0] + target[1]*means[1]
+
+	r := rand.New(rand.NewSource(1))
+	trials, n := 500, 50
+	var ipsErr, wisErr float64
+	for trial := 0; trial < trials; trial++ {
+		events := make([]LoggedEvent, n)
+		for i := range events {
+			arm := categoricalProb(r.Float64(), logging...)
+			reward := 0.0
+			if r.Float64() < means[arm] {
+				reward = 1
+			}
+			events[i] = LoggedEvent{Arm: arm, Reward: reward, Propensity: logging[arm]}
+		}
+		estimate, err := EvaluateOffPolicy(events, target)
+		assert.Nil(err)
+		assert.LessOrEqual(estimate.WIS, 1.0, "should keep the WIS estimate in the reward range")
+		ipsErr += (estimate.IPS - value) * (estimate.IPS - value) / float64(trials)
+		wisErr += (estimate.WIS - value) * (estimate.WIS - value) / float64(trials)
+	}
+	assert.Less(wisErr, ipsErr/2, "should have a lower error with WIS than IPS")
+}
+
+func TestEpsilonGreedy_Propensities(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.2, nil, nil)
+	_, err := b.Propensities()
+	assert.Equal(ErrNotInitialized, err)
+
+	b, err = NewEpsilonGreedy(0.2, []int{1, 1, 1}, []float64{0.1, 0.9, 0.5})
+	assert.Nil(err)
+	probs, err := b.Propensities()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.2 / 3, 0.2/3 + 0.8, 0.2 / 3}, probs, 1e-9)
+
+	b.Smoothing = 0.5
+	b.Smoothed = []float64{1, 0, 0}
+	probs, err = b.Propensities()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.5 + 0.1/3, 0.1/3 + 0.4, 0.1 / 3}, probs, 1e-9, "should include the smoothing")
+
+	assert.Nil(b.Disable(0))
+	probs, err = b.Propensities()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0, 0.9, 0.1}, probs, 1e-9, "should drop the disabled arm like SelectArm")
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.InDeltaSlice(probs, b.SmoothedProbabilities(), 1e-9, "should match the smoothed probabilities of SelectArm")
+}
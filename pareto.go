@@ -0,0 +1,185 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// ParetoBandit represents a multi-objective bandit without a scalarization,
+// which keeps the mean of every objective for each arm and selects uniformly
+// among the Pareto set, the arms no other arm dominates. An arm dominates
+// another when it is at least as good on every objective and better on one,
+// where higher is better, so costs and latencies are to be negated. Unplayed
+// arms are selected first.
+type ParetoBandit struct {
+	sync.RWMutex
+	Counts []int
+
+	// Means holds the mean of each objective for each arm
+	Means [][]float64
+
+	// Objectives is the number of objectives, which NewParetoBandit sets, and
+	// which a struct literal must set before Init
+	Objectives int
+}
+
+// Init will initialise the counts and means with the provided number of arms,
+// and the number of Objectives
+func (b *ParetoBandit) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	if b.Objectives < 1 {
+		return ErrInvalidObjectives
+	}
+	b.Counts = make([]int, nArms)
+	b.Means = make([][]float64, nArms)
+	for i := range b.Means {
+		b.Means[i] = make([]float64, b.Objectives)
+	}
+	return nil
+}
+
+// SelectArm chooses an arm of the Pareto set uniformly by the probability, in
+// range 0 to 1. Probabilities outside the range, or NaN, are clamped into it.
+func (b *ParetoBandit) SelectArm(probability float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(b.Counts) == 0 {
+		return -1, ErrNotInitialized
+	}
+	for i, count := range b.Counts {
+		if count == 0 {
+			return i, nil
+		}
+	}
+
+	front := b.paretoSet()
+	switch {
+	case !(probability > 0):
+		return front[0], nil
+	case probability >= 1:
+		return front[len(front)-1], nil
+	}
+	return front[min(int(probability*float64(len(front))), len(front)-1)], nil
+}
+
+// ParetoSet returns the arms that no other arm dominates, in increasing order
+func (b *ParetoBandit) ParetoSet() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.paretoSet()
+}
+
+func (b *ParetoBandit) paretoSet() []int {
+	var front []int
+	for i := range b.Means {
+		dominated := false
+		for j := range b.Means {
+			if j != i && dominates(b.Means[j], b.Means[i]) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, i)
+		}
+	}
+	return front
+}
+
+// dominates returns whether a is at least as good as b on every objective and
+// better on at least one
+func dominates(a, b []float64) bool {
+	better := false
+	for k := range a {
+		if a[k] < b[k] {
+			return false
+		}
+		if a[k] > b[k] {
+			better = true
+		}
+	}
+	return better
+}
+
+// UpdateObjectives will update an arm with one finite value per objective
+func (b *ParetoBandit) UpdateObjectives(chosenArm int, values []float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Counts) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Counts)}
+	}
+	if len(values) != b.Objectives {
+		return ErrInvalidLength
+	}
+	for _, value := range values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return ErrInvalidReward
+		}
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+	means := b.Means[chosenArm]
+	for k, value := range values {
+		means[k] += (value - means[k]) / n
+	}
+	return nil
+}
+
+// ObjectiveCount returns the number of objectives
+func (b *ParetoBandit) ObjectiveCount() int {
+	return b.Objectives
+}
+
+// GetCounts returns the counts
+func (b *ParetoBandit) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetMeans returns the mean of each objective for each arm
+func (b *ParetoBandit) GetMeans() [][]float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([][]float64, len(b.Means))
+	for i, means := range b.Means {
+		sCopy[i] = make([]float64, len(means))
+		copy(sCopy[i], means)
+	}
+	return sCopy
+}
+
+// NewParetoBandit returns a pointer to the ParetoBandit struct with the number
+// of objectives, and the means of each objective for each arm
+func NewParetoBandit(objectives int, counts []int, means [][]float64) (*ParetoBandit, error) {
+	if objectives < 1 {
+		return nil, ErrInvalidObjectives
+	}
+	if len(counts) != len(means) {
+		return nil, ErrInvalidLength
+	}
+	for _, m := range means {
+		if len(m) != objectives {
+			return nil, ErrInvalidLength
+		}
+	}
+
+	return &ParetoBandit{
+		Counts:     counts,
+		Means:      means,
+		Objectives: objectives,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package bandit
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewParetoBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		objectives int
+		counts     []int
+		means      [][]float64
+		err        error
+	}{
+		{2, nil, nil, nil},
+		{0, nil, nil, ErrInvalidObjectives},
+		{2, []int{1}, nil, ErrInvalidLength},
+		{2, []int{1}, [][]float64{{1}}, ErrInvalidLength},
+	}
+
+	for i, tt := range tests {
+		_, err := NewParetoBandit(tt.objectives, tt.counts, tt.means)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+
+	b, _ := NewParetoBandit(2, nil, nil)
+	_, err := b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Nil(b.Init(2))
+	assert.Equal(2, b.ObjectiveCount())
+	assert.True(errors.Is(b.UpdateObjectives(2, []float64{1, 1}), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidLength, b.UpdateObjectives(0, []float64{1}))
+	assert.Equal(ErrInvalidReward, b.UpdateObjectives(0, []float64{1, math.NaN()}))
+
+	literal := &ParetoBandit{Objectives: 2}
+	assert.Nil(literal.Init(2))
+	assert.Nil(literal.UpdateObjectives(0, []float64{1, 1}), "should update a struct literal with objectives")
+	assert.Equal(ErrInvalidObjectives, (&ParetoBandit{}).Init(2), "should reject a struct literal without objectives")
+}
+
+func TestParetoBandit_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	// Reward and negated cost: arms 0 and 1 trade off, arm 2 is dominated by
+	// arm 0 and arm 3 by arm 1
+	b, _ := NewParetoBandit(2, nil, nil)
+	assert.Nil(b.Init(4))
+	values := [][]float64{{0.9, -2}, {0.5, -1}, {0.8, -2}, {0.5, -1.5}}
+	for i := 0; i < 4; i++ {
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.Equal(i, arm, "should select unplayed arms first")
+		assert.Nil(b.UpdateObjectives(arm, values[arm]))
+	}
+	assert.Equal([]int{0, 1}, b.ParetoSet(), "should keep the non-dominated arms")
+
+	selected := make(map[int]int)
+	for i := 0; i < 100; i++ {
+		arm, err := b.SelectArm(float64(i) / 100)
+		assert.Nil(err)
+		selected[arm]++
+	}
+	assert.Equal(map[int]int{0: 50, 1: 50}, selected, "should only select the front uniformly")
+
+	tests := []struct {
+		probability float64
+		expected    int
+	}{
+		{-0.6, 0},
+		{math.NaN(), 0},
+		{math.Inf(-1), 0},
+		{1, 1},
+		{1.5, 1},
+		{math.Inf(1), 1},
+	}
+
+	for i, tt := range tests {
+		arm, err := b.SelectArm(tt.probability)
+		assert.Nil(err)
+		assert.Equal(tt.expected, arm, "should clamp the probability for test %d", i+1)
+	}
+
+	assert.Nil(b.UpdateObjectives(2, []float64{1, -2}))
+	assert.Equal([]float64{0.9, -2}, b.GetMeans()[2], "should average the objectives")
+	assert.Equal([]int{0, 1, 2}, b.ParetoSet(), "should admit an arm once it ties the front")
+}
@@ -0,0 +1,143 @@
+package bandit
+
+import (
+	"container/list"
+	"sync"
+)
+
+// OverflowPolicy decides what SelectPending does when the pending selections
+// are at their maximum
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until a pending selection is resolved or cancelled
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest forgets the oldest pending selection, whose reward
+	// is then rejected
+	OverflowDropOldest
+	// OverflowError returns ErrPendingFull
+	OverflowError
+)
+
+// pendingSelection is a selection waiting for its reward
+type pendingSelection struct {
+	id  uint64
+	arm int
+}
+
+// PendingBandit wraps a bandit, and keeps track of the selections waiting for
+// their delayed reward, up to a maximum so that the memory stays bounded when
+// the rewards stop arriving
+type PendingBandit struct {
+	Bandit Bandit
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	max     int
+	policy  OverflowPolicy
+	next    uint64
+	order   *list.List
+	pending map[uint64]*list.Element
+}
+
+// SelectPending atomically selects an arm with the bandit and marks it as
+// pending, returning the id to resolve it with. When the pending selections
+// are at their maximum, the overflow policy applies.
+func (p *PendingBandit) SelectPending(probability float64) (id uint64, arm int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.order.Len() >= p.max {
+		switch p.policy {
+		case OverflowBlock:
+			p.cond.Wait()
+		case OverflowDropOldest:
+			p.remove(p.order.Front())
+		default:
+			return 0, -1, ErrPendingFull
+		}
+	}
+
+	if arm, err = p.Bandit.SelectArm(probability); err != nil {
+		return 0, -1, err
+	}
+	p.next++
+	p.pending[p.next] = p.order.PushBack(pendingSelection{id: p.next, arm: arm})
+	return p.next, arm, nil
+}
+
+// Resolve updates the arm of a pending selection with its reward. Selections
+// already resolved, cancelled or dropped are rejected with ErrUnknownPending.
+func (p *PendingBandit) Resolve(id uint64, reward float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.pending[id]
+	if !ok {
+		return ErrUnknownPending
+	}
+	if err := p.Bandit.Update(e.Value.(pendingSelection).arm, reward); err != nil {
+		return err
+	}
+	p.remove(e)
+	return nil
+}
+
+// Cancel forgets a pending selection without a reward
+func (p *PendingBandit) Cancel(id uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e, ok := p.pending[id]
+	if !ok {
+		return ErrUnknownPending
+	}
+	p.remove(e)
+	return nil
+}
+
+// remove forgets a pending selection, and wakes up a blocked selection
+func (p *PendingBandit) remove(e *list.Element) {
+	delete(p.pending, e.Value.(pendingSelection).id)
+	p.order.Remove(e)
+	p.cond.Signal()
+}
+
+// PendingCount returns the number of pending selections
+func (p *PendingBandit) PendingCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.order.Len()
+}
+
+// MaxPending returns the maximum number of pending selections
+func (p *PendingBandit) MaxPending() int {
+	return p.max
+}
+
+// Policy returns the overflow policy
+func (p *PendingBandit) Policy() OverflowPolicy {
+	return p.policy
+}
+
+// NewPendingBandit returns a pointer to the PendingBandit struct, keeping up
+// to maxPending selections of b pending with the overflow policy
+func NewPendingBandit(b Bandit, maxPending int, policy OverflowPolicy) (*PendingBandit, error) {
+	if maxPending < 1 {
+		return nil, ErrInvalidSize
+	}
+	if policy < OverflowBlock || policy > OverflowError {
+		return nil, ErrInvalidPolicy
+	}
+
+	p := &PendingBandit{
+		Bandit:  b,
+		max:     maxPending,
+		policy:  policy,
+		order:   list.New(),
+		pending: make(map[uint64]*list.Element),
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p, nil
+}
@@ -0,0 +1,116 @@
+package bandit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPendingBandit(t *testing.T, policy OverflowPolicy) *PendingBandit {
+	b, _ := NewEpsilonGreedy(0, nil, nil)
+	assert.Nil(t, b.Init(2))
+	p, err := NewPendingBandit(b, 2, policy)
+	assert.Nil(t, err)
+	return p
+}
+
+func TestNewPendingBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0, nil, nil)
+	_, err := NewPendingBandit(b, 0, OverflowError)
+	assert.Equal(ErrInvalidSize, err)
+	_, err = NewPendingBandit(b, 1, OverflowPolicy(3))
+	assert.Equal(ErrInvalidPolicy, err)
+
+	p, err := NewPendingBandit(b, 1, OverflowError)
+	assert.Nil(err)
+	_, _, err = p.SelectPending(0.5)
+	assert.Equal(ErrNotInitialized, err, "should throw the errors of the bandit")
+	assert.Equal(0, p.PendingCount())
+}
+
+func TestPendingBandit_Resolve(t *testing.T) {
+	assert := assert.New(t)
+
+	p := newTestPendingBandit(t, OverflowError)
+	assert.Equal(2, p.MaxPending())
+	assert.Equal(OverflowError, p.Policy())
+
+	id, arm, err := p.SelectPending(0.5)
+	assert.Nil(err)
+	assert.Equal(1, p.PendingCount())
+	assert.Equal(ErrInvalidReward, p.Resolve(id, -1))
+	assert.Equal(1, p.PendingCount(), "should stay pending after an invalid reward")
+	assert.Nil(p.Resolve(id, 1))
+	assert.Equal(0, p.PendingCount())
+	assert.Equal(1, p.Bandit.GetCounts()[arm], "should update the selected arm")
+	assert.Equal(ErrUnknownPending, p.Resolve(id, 1), "should not resolve twice")
+
+	id, _, err = p.SelectPending(0.5)
+	assert.Nil(err)
+	assert.Nil(p.Cancel(id))
+	assert.Equal(ErrUnknownPending, p.Cancel(id))
+	assert.Equal(0, p.PendingCount())
+}
+
+func TestPendingBandit_OverflowError(t *testing.T) {
+	assert := assert.New(t)
+
+	p := newTestPendingBandit(t, OverflowError)
+	for i := 0; i < 2; i++ {
+		_, _, err := p.SelectPending(0.5)
+		assert.Nil(err)
+	}
+	_, _, err := p.SelectPending(0.5)
+	assert.Equal(ErrPendingFull, err)
+	assert.Equal(2, p.PendingCount())
+}
+
+func TestPendingBandit_OverflowDropOldest(t *testing.T) {
+	assert := assert.New(t)
+
+	p := newTestPendingBandit(t, OverflowDropOldest)
+	oldest, _, err := p.SelectPending(0.5)
+	assert.Nil(err)
+	second, _, err := p.SelectPending(0.5)
+	assert.Nil(err)
+	third, _, err := p.SelectPending(0.5)
+	assert.Nil(err)
+
+	assert.Equal(2, p.PendingCount(), "should stay at the maximum")
+	assert.Equal(ErrUnknownPending, p.Resolve(oldest, 1), "should drop the oldest selection")
+	assert.Nil(p.Resolve(second, 1))
+	assert.Nil(p.Resolve(third, 1))
+}
+
+func TestPendingBandit_OverflowBlock(t *testing.T) {
+	assert := assert.New(t)
+
+	p := newTestPendingBandit(t, OverflowBlock)
+	first, _, err := p.SelectPending(0.5)
+	assert.Nil(err)
+	_, _, err = p.SelectPending(0.5)
+	assert.Nil(err)
+
+	selected := make(chan error)
+	go func() {
+		_, _, err := p.SelectPending(0.5)
+		selected <- err
+	}()
+
+	select {
+	case <-selected:
+		t.Fatal("should block while full")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Nil(p.Resolve(first, 1))
+	select {
+	case err := <-selected:
+		assert.Nil(err)
+	case <-time.After(time.Second):
+		t.Fatal("should unblock once a selection is resolved")
+	}
+	assert.Equal(2, p.PendingCount())
+}
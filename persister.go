@@ -0,0 +1,118 @@
+package bandit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Persister periodically saves a snapshot of a bandit. The snapshot is a
+// clone, so the bandit is only locked while it is copied and not while it is
+// saved.
+type Persister struct {
+	Bandit   Bandit
+	Interval time.Duration
+
+	// Save writes the snapshot to the storage
+	Save func(ctx context.Context, snapshot Bandit) error
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	// ticks replaces the ticker of the interval in tests
+	ticks <-chan time.Time
+
+	mu       sync.Mutex
+	lastSave time.Time
+	lastErr  error
+}
+
+// Run saves a snapshot every interval until the context is done, and then
+// saves a final snapshot. Ticks while a save is in progress are coalesced
+// into a single save once it completes.
+func (p *Persister) Run(ctx context.Context) error {
+	ticks := p.ticks
+	if ticks == nil {
+		ticker := time.NewTicker(p.Interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	done := make(chan struct{})
+	save := func() {
+		p.SaveNow(ctx)
+		done <- struct{}{}
+	}
+
+	var saving, pending bool
+	for {
+		select {
+		case <-ticks:
+			if saving {
+				pending = true
+				continue
+			}
+			saving = true
+			go save()
+		case <-done:
+			saving = false
+			if pending {
+				pending = false
+				saving = true
+				go save()
+			}
+		case <-ctx.Done():
+			if saving {
+				<-done
+			}
+			return p.SaveNow(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+// SaveNow saves a snapshot of the bandit immediately
+func (p *Persister) SaveNow(ctx context.Context) error {
+	snapshot, err := clone(p.Bandit)
+	if err == nil {
+		err = p.Save(ctx, snapshot)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastErr = err
+	if err == nil {
+		now := time.Now
+		if p.Now != nil {
+			now = p.Now
+		}
+		p.lastSave = now()
+	}
+	return err
+}
+
+// LastSave returns the time of the last successful save, and the error of
+// the last save attempted
+func (p *Persister) LastSave() (time.Time, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.lastSave, p.lastErr
+}
+
+// NewPersister returns a pointer to the Persister struct, saving the bandit
+// every interval
+func NewPersister(b Bandit, interval time.Duration, save func(ctx context.Context, snapshot Bandit) error) (*Persister, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	if _, err := clone(b); err != nil {
+		return nil, err
+	}
+
+	return &Persister{
+		Bandit:   b,
+		Interval: interval,
+		Save:     save,
+	}, nil
+}
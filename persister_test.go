@@ -0,0 +1,144 @@
+package bandit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memorySink keeps the saved snapshots in memory, and signals every save
+type memorySink struct {
+	sync.Mutex
+	saved [][]byte
+	saves chan struct{}
+	block chan struct{}
+}
+
+func (s *memorySink) Save(ctx context.Context, snapshot Bandit) error {
+	if s.block != nil {
+		<-s.block
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	s.saved = append(s.saved, data)
+	s.Unlock()
+	if s.saves != nil {
+		s.saves <- struct{}{}
+	}
+	return nil
+}
+
+func (s *memorySink) count() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return len(s.saved)
+}
+
+func TestNewPersister(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	sink := &memorySink{}
+
+	_, err := NewPersister(b, time.Minute, sink.Save)
+	assert.Nil(err)
+	_, err = NewPersister(b, 0, sink.Save)
+	assert.Equal(ErrInvalidDuration, err, "should throw error for invalid interval")
+	_, err = NewPersister(struct{ Bandit }{b}, time.Minute, sink.Save)
+	assert.Equal(ErrNotCloneable, err, "should throw error for bandits that cannot be cloned")
+}
+
+func TestPersister_SaveNow(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	assert.Nil(b.Update(1, 1.0))
+
+	sink := &memorySink{}
+	p, err := NewPersister(b, time.Minute, sink.Save)
+	assert.Nil(err)
+	p.Now = clock.Now
+
+	saved, err := p.LastSave()
+	assert.True(saved.IsZero(), "should not report a save before the first one")
+	assert.Nil(err)
+
+	assert.Nil(p.SaveNow(context.Background()))
+	saved, err = p.LastSave()
+	assert.Equal(clock.Now(), saved, "should report the time of the save")
+	assert.Nil(err)
+
+	var restored EpsilonGreedy
+	assert.Nil(json.Unmarshal(sink.saved[0], &restored))
+	assert.Equal([]int{0, 1}, restored.Counts, "should save the counts")
+	assert.Equal([]float64{0, 1}, restored.Rewards, "should save the rewards")
+
+	clock.Advance(time.Minute)
+	errSave := errors.New("storage unavailable")
+	p.Save = func(ctx context.Context, snapshot Bandit) error {
+		return errSave
+	}
+	assert.Equal(errSave, p.SaveNow(context.Background()))
+	saved, err = p.LastSave()
+	assert.Equal(clock.Now().Add(-time.Minute), saved, "should keep the time of the last successful save")
+	assert.Equal(errSave, err, "should report the error of the last save")
+}
+
+func TestPersister_Run(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+
+	sink := &memorySink{saves: make(chan struct{}), block: make(chan struct{})}
+	p, err := NewPersister(b, time.Minute, sink.Save)
+	assert.Nil(err)
+	p.Now = clock.Now
+	ticks := make(chan time.Time)
+	p.ticks = ticks
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		result <- p.Run(ctx)
+	}()
+
+	// The first tick starts a slow save, and the ticks during it coalesce
+	// into a single save
+	for i := 0; i < 3; i++ {
+		ticks <- clock.Now()
+	}
+	sink.block <- struct{}{}
+	<-sink.saves
+	sink.block <- struct{}{}
+	<-sink.saves
+	assert.Equal(2, sink.count(), "should coalesce the ticks during a save")
+
+	ticks <- clock.Now()
+	sink.block <- struct{}{}
+	<-sink.saves
+	assert.Equal(3, sink.count(), "should save on every tick")
+
+	cancel()
+	sink.block <- struct{}{}
+	<-sink.saves
+	assert.Nil(<-result)
+	assert.Equal(4, sink.count(), "should save on shutdown")
+
+	saved, err := p.LastSave()
+	assert.Equal(clock.Now(), saved)
+	assert.Nil(err)
+}
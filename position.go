@@ -0,0 +1,81 @@
+package bandit
+
+import (
+	"slices"
+	"time"
+)
+
+// SetPositionBias sets the probability of a user examining each position of a
+// ranked display, from the top position 0 down, which must be in range 0
+// exclusive to 1. Passing nil restores the default of 1/(position+1).
+func (b *EpsilonGreedy) SetPositionBias(bias []float64) error {
+	for _, p := range bias {
+		if !(p > 0 && p <= 1) {
+			return ErrInvalidProbability
+		}
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	if len(bias) == 0 {
+		b.PositionBias = nil
+		return nil
+	}
+	b.PositionBias = slices.Clone(bias)
+	return nil
+}
+
+// PositionBiasWeights returns the examination probabilities of the first n
+// positions, which UpdatePositionAware divides the rewards by
+func (b *EpsilonGreedy) PositionBiasWeights(n int) ([]float64, error) {
+	if n < 0 {
+		return nil, ErrInvalidSize
+	}
+
+	b.RLock()
+	defer b.RUnlock()
+
+	weights := make([]float64, n)
+	for position := range weights {
+		p, err := b.examination(position)
+		if err != nil {
+			return nil, err
+		}
+		weights[position] = p
+	}
+	return weights, nil
+}
+
+// UpdatePositionAware will update an arm with some reward value like Update,
+// for an arm shown at a position of a ranked display, e.g. the slot of
+// SelectSlate. The reward is divided by the examination probability of the
+// position, the inverse propensity weighting that credits arms shown lower
+// down for the attention they did not get, so the corrected rewards can
+// exceed the raw ones.
+func (b *EpsilonGreedy) UpdatePositionAware(chosenArm int, reward float64, position int) error {
+	b.Lock()
+	p, err := b.examination(position)
+	var callbacks []func()
+	if err == nil {
+		reward /= p
+		callbacks, err = b.updateAt(chosenArm, reward, 1, time.Time{})
+	}
+	logger := b.logger
+	b.Unlock()
+
+	return b.updated(logger, chosenArm, reward, callbacks, err)
+}
+
+// examination returns the examination probability of a position
+func (b *EpsilonGreedy) examination(position int) (float64, error) {
+	switch {
+	case position < 0:
+		return 0, ErrPositionOutOfRange
+	case b.PositionBias == nil:
+		return 1 / float64(position+1), nil
+	case position >= len(b.PositionBias):
+		return 0, ErrPositionOutOfRange
+	}
+	return b.PositionBias[position], nil
+}
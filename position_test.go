@@ -0,0 +1,76 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SetPositionBias(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	tests := []struct {
+		bias []float64
+		err  error
+	}{
+		{[]float64{1, 0.5}, nil},
+		{[]float64{1, 0}, ErrInvalidProbability},
+		{[]float64{1.1}, ErrInvalidProbability},
+		{[]float64{math.NaN()}, ErrInvalidProbability},
+		{nil, nil},
+	}
+
+	for i, tt := range tests {
+		assert.Equal(tt.err, b.SetPositionBias(tt.bias), "should validate the bias for test %d", i+1)
+	}
+
+	weights, err := b.PositionBiasWeights(4)
+	assert.Nil(err)
+	assert.Equal([]float64{1, 0.5, 1.0 / 3, 0.25}, weights, "should default to the inverse rank")
+
+	assert.Nil(b.SetPositionBias([]float64{1, 0.6}))
+	weights, err = b.PositionBiasWeights(2)
+	assert.Nil(err)
+	assert.Equal([]float64{1, 0.6}, weights)
+	_, err = b.PositionBiasWeights(3)
+	assert.Equal(ErrPositionOutOfRange, err)
+	_, err = b.PositionBiasWeights(-1)
+	assert.Equal(ErrInvalidSize, err)
+}
+
+func TestEpsilonGreedy_UpdatePositionAware(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+
+	assert.Nil(b.UpdatePositionAware(0, 0.2, 0))
+	assert.Nil(b.UpdatePositionAware(1, 0.2, 1))
+	assert.Nil(b.Update(2, 0.2))
+	assert.InDeltaSlice([]float64{0.2, 0.4, 0.2}, b.GetRewards(), 1e-9, "should credit the lower position more")
+
+	assert.Nil(b.SetPositionBias([]float64{1, 0.8}))
+	assert.Nil(b.UpdatePositionAware(1, 0.2, 1))
+	assert.InDelta((0.4+0.25)/2, b.GetRewards()[1], 1e-9, "should use the configured bias")
+
+	tests := []struct {
+		arm      int
+		position int
+		err      error
+	}{
+		{0, -1, ErrPositionOutOfRange},
+		{0, 2, ErrPositionOutOfRange},
+		{0, 0, nil},
+	}
+
+	for i, tt := range tests {
+		assert.Equal(tt.err, b.UpdatePositionAware(tt.arm, 0.5, tt.position), "should throw the correct error for test %d", i+1)
+	}
+	assert.Equal([]int{2, 2, 1}, b.GetCounts(), "should not count the rejected updates")
+}
@@ -0,0 +1,43 @@
+package bandit
+
+import "math"
+
+// SeedFromPrior starts every arm warm from historical means, treating each
+// prior mean as if it had been observed priorStrength times. The strength is
+// rounded to whole pulls and shows up in the counts, so online updates
+// override a weak prior quickly and a strong prior slowly.
+func (b *EpsilonGreedy) SeedFromPrior(priorMeans []float64, priorStrength float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.initialized() {
+		return ErrNotInitialized
+	}
+	if len(priorMeans) != len(b.Rewards) {
+		return ErrInvalidLength
+	}
+	if priorStrength < 0 || math.IsNaN(priorStrength) || math.IsInf(priorStrength, 0) {
+		return ErrInvalidStrength
+	}
+	for _, mean := range priorMeans {
+		if mean < 0 {
+			return ErrInvalidReward
+		}
+	}
+
+	count := int(math.Round(priorStrength))
+	b.Observations = make([]int, len(b.Rewards))
+	for i, mean := range priorMeans {
+		b.Counts[i] = count
+		b.Observations[i] = count
+		b.Rewards[i] = mean
+	}
+	b.M2 = make([]float64, len(b.Rewards))
+	b.M2Counts = make([]int, len(b.Rewards))
+	b.Weights = nil
+	b.SquaredWeights = nil
+	b.Precisions = nil
+	b.PrecisionCounts = nil
+	b.invalidateBest()
+	return nil
+}
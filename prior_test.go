@@ -0,0 +1,89 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SeedFromPrior(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	tests := []struct {
+		means    []float64
+		strength float64
+		err      error
+	}{
+		{[]float64{0.1}, 1.0, ErrInvalidLength},
+		{[]float64{0.1, 0.2, 0.3}, 1.0, ErrInvalidLength},
+		{[]float64{0.1, 0.2}, -1.0, ErrInvalidStrength},
+		{[]float64{0.1, 0.2}, math.NaN(), ErrInvalidStrength},
+		{[]float64{0.1, -0.2}, 1.0, ErrInvalidReward},
+		{[]float64{0.1, 0.2}, 10.0, nil},
+	}
+
+	for i, tt := range tests {
+		err := b.SeedFromPrior(tt.means, tt.strength)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+	assert.Equal([]int{10, 10}, b.GetCounts(), "should set the counts to the prior strength")
+	assert.Equal([]float64{0.1, 0.2}, b.GetRewards(), "should set the rewards to the prior means")
+}
+
+func TestEpsilonGreedy_SeedFromPriorIsOverridden(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		strength float64
+		expected float64
+	}{
+		{5, (0.9*5 + 0.1*1000) / 1005},
+		{5000, (0.9*5000 + 0.1*1000) / 6000},
+	}
+
+	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0.1, nil, nil)
+		assert.Nil(err)
+
+		err = b.Init(1)
+		assert.Nil(err)
+
+		err = b.SeedFromPrior([]float64{0.9}, tt.strength)
+		assert.Nil(err)
+
+		for i := 0; i < 1000; i++ {
+			err = b.Update(0, 0.1)
+			assert.Nil(err)
+		}
+		assert.InDelta(tt.expected, b.GetRewards()[0], 1e-9, "should blend the prior of strength %v with the online updates", tt.strength)
+	}
+}
+
+func TestEpsilonGreedy_SeedFromPriorWeakPriorIsDominated(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	// The prior favours the first arm, while the online data favours the second
+	err = b.SeedFromPrior([]float64{0.9, 0.1}, 2)
+	assert.Nil(err)
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(0, 0.2))
+		assert.Nil(b.Update(1, 0.8))
+	}
+	rewards := b.GetRewards()
+	assert.True(rewards[1] > rewards[0], "online updates should override the weak prior")
+	assert.InDelta(0.8, rewards[1], 0.02, "should follow the online mean")
+}
@@ -0,0 +1,76 @@
+package bandit
+
+import (
+	"math"
+	"math/rand"
+)
+
+// defaultBestArmSamples is the number of samples drawn by
+// ProbabilityBestIsBest when none is set
+const defaultBestArmSamples = 1000
+
+// ProbabilityBestIsBest estimates the probability that the arm with the
+// highest mean reward truly has the highest mean, e.g. as a stopping signal.
+// The mean of each arm is sampled BestArmSamples times from a normal
+// approximation with its standard error, where arms with fewer than two
+// rewards assume the variance of 0.25, the largest for rewards in range 0 to
+// 1. Normal numbers are drawn from Rand when it is a NormRand.
+func (b *EpsilonGreedy) ProbabilityBestIsBest() float64 {
+	b.Lock()
+	defer b.Unlock()
+
+	nArms := len(b.Rewards)
+	if nArms == 0 {
+		return 0
+	}
+	if nArms == 1 {
+		return 1
+	}
+
+	errs := make([]float64, nArms)
+	for i := range errs {
+		errs[i] = b.standardError(i)
+	}
+
+	normal := rand.NormFloat64
+	if r, ok := b.Rand.(NormRand); ok {
+		normal = r.NormFloat64
+	}
+
+	samples := b.BestArmSamples
+	if samples < 1 {
+		samples = defaultBestArmSamples
+	}
+	best := max(b.Rewards...)
+	sampled := make([]float64, nArms)
+	wins := 0
+	for s := 0; s < samples; s++ {
+		for i := range sampled {
+			sampled[i] = b.Rewards[i] + errs[i]*normal()
+		}
+		if max(sampled...) == best {
+			wins++
+		}
+	}
+	return float64(wins) / float64(samples)
+}
+
+// standardError returns the standard error of the mean reward of an arm,
+// where arms with fewer than two rewards assume the variance of 0.25, unless
+// every reward came with its variance
+func (b *EpsilonGreedy) standardError(arm int) float64 {
+	if se, ok := b.knownStandardError(arm); ok {
+		return se
+	}
+	return math.Sqrt(b.rewardVariance(arm) / math.Max(b.effectiveObservations(arm), 1))
+}
+
+// rewardVariance returns the sample variance of the rewards of an arm, where
+// arms with fewer than two rewards assume the variance of 0.25, the largest
+// for rewards in range 0 to 1
+func (b *EpsilonGreedy) rewardVariance(arm int) float64 {
+	if n := b.observations(arm); n > 1 && len(b.M2) == len(b.Rewards) {
+		return b.M2[arm] / b.varianceDenominator(arm)
+	}
+	return 0.25
+}
@@ -0,0 +1,48 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ProbabilityBestIsBest(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		payout   []float64
+		expected float64
+		delta    float64
+	}{
+		{"dominant", []float64{0.1, 0.9, 0.2}, 1.0, 0.01},
+		{"tied", []float64{0.5, 0.5, 0.5, 0.5}, 0.25, 0.05},
+		{"close", []float64{0.5, 0.52}, 0.65, 0.1},
+	}
+
+	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0.1, nil, nil)
+		assert.Nil(err)
+		assert.Nil(b.Init(len(tt.payout)))
+		b.Rand = rand.New(rand.NewSource(1))
+		b.BestArmSamples = 5000
+
+		for arm, p := range tt.payout {
+			for i := 0; i < 200; i++ {
+				// Deterministic rewards so that tied arms share the same stats
+				reward := 0.0
+				if float64(i%100) < p*100 {
+					reward = 1.0
+				}
+				assert.Nil(b.Update(arm, reward))
+			}
+		}
+		assert.InDelta(tt.expected, b.ProbabilityBestIsBest(), tt.delta, tt.name)
+	}
+
+	b, _ := NewEpsilonGreedy(0.1, []int{3}, []float64{0.5})
+	assert.Equal(1.0, b.ProbabilityBestIsBest(), "the only arm is the best")
+	b, _ = NewEpsilonGreedy(0.1, nil, nil)
+	assert.Equal(0.0, b.ProbabilityBestIsBest(), "should have no best arm without arms")
+}
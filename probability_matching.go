@@ -0,0 +1,131 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+)
+
+// ProbabilityMatching represents a lightweight approximation of Thompson
+// sampling, which selects each arm with the softmax probability of its mean
+// reward scaled by the square root of its count, so that confident arms with
+// a high mean get more of the mass
+type ProbabilityMatching struct {
+	sync.RWMutex
+	Scale   float64
+	Counts  []int
+	Rewards []float64
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *ProbabilityMatching) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm chooses an arm by the cumulative probability of the arms
+func (b *ProbabilityMatching) SelectArm(probability float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	nArms := len(b.Rewards)
+	if nArms == 1 {
+		return 0, nil
+	}
+	return categoricalProb(probability, b.probabilities()...), nil
+}
+
+// Probabilities returns the selection probability of each arm
+func (b *ProbabilityMatching) Probabilities() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.probabilities()
+}
+
+func (b *ProbabilityMatching) probabilities() []float64 {
+	nArms := len(b.Rewards)
+	scores := make([]float64, nArms)
+	best := math.Inf(-1)
+	for i := 0; i < nArms; i++ {
+		scores[i] = b.Scale * b.Rewards[i] * math.Sqrt(float64(b.Counts[i]))
+		best = math.Max(best, scores[i])
+	}
+
+	// Shift by the best score so the exponent cannot overflow as the counts
+	// grow
+	var z float64
+	probs := make([]float64, nArms)
+	for i := 0; i < nArms; i++ {
+		probs[i] = math.Exp(scores[i] - best)
+		z += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= z
+	}
+	return probs
+}
+
+// GetCounts returns the counts
+func (b *ProbabilityMatching) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *ProbabilityMatching) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// Update will update an arm with some reward value,
+// e.g. click = 1, no click = 0
+func (b *ProbabilityMatching) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+
+	b.Counts[chosenArm]++
+	n := float64(b.Counts[chosenArm])
+
+	oldRewards := b.Rewards[chosenArm]
+	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
+
+	return nil
+}
+
+// NewProbabilityMatching returns a pointer to the ProbabilityMatching struct
+func NewProbabilityMatching(scale float64, counts []int, rewards []float64) (*ProbabilityMatching, error) {
+	if scale <= 0 {
+		return nil, ErrInvalidScale
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &ProbabilityMatching{
+		Scale:   scale,
+		Counts:  counts,
+		Rewards: rewards,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbabilityMatching_New(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		scale   float64
+		counts  []int
+		rewards []float64
+		err     error
+	}{
+		{1, nil, nil, nil},
+		{0.5, make([]int, 3), make([]float64, 3), nil},
+		{0, nil, nil, ErrInvalidScale},
+		{-1, nil, nil, ErrInvalidScale},
+		{1, make([]int, 3), nil, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		pm, err := NewProbabilityMatching(tt.scale, tt.counts, tt.rewards)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.scale, pm.Scale, "scale should be equal")
+		}
+	}
+}
+
+func TestProbabilityMatching_Probabilities(t *testing.T) {
+	assert := assert.New(t)
+
+	pm, _ := NewProbabilityMatching(1, []int{0, 0, 0}, []float64{0, 0, 0})
+	assert.InDeltaSlice([]float64{1.0 / 3, 1.0 / 3, 1.0 / 3}, pm.Probabilities(), 1e-9, "should select uniformly without pulls")
+
+	previous := 0.0
+	for _, n := range []int{1, 10, 100, 1000} {
+		pm.Counts = []int{n, n, n}
+		pm.Rewards = []float64{0.2, 0.8, 0.5}
+
+		probs := pm.Probabilities()
+		assert.InDelta(1.0, probs[0]+probs[1]+probs[2], 1e-9, "probabilities should sum to one")
+		assert.Greater(probs[1], previous, "mass should concentrate on the best arm as counts grow")
+		assert.Greater(probs[0], 0.0, "should never starve the worst arm")
+		assert.Greater(probs[2], 0.0, "should never starve the other arms")
+		previous = probs[1]
+	}
+}
+
+func TestProbabilityMatching_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	pm, _ := NewProbabilityMatching(1, []int{100, 100}, []float64{0.1, 0.9})
+	probs := pm.Probabilities()
+
+	tests := []struct {
+		probability float64
+		arm         int
+	}{
+		{0, 0},
+		{probs[0] / 2, 0},
+		{probs[0], 1},
+		{0.99, 1},
+	}
+
+	for _, tt := range tests {
+		arm, err := pm.SelectArm(tt.probability)
+		assert.Nil(err)
+		assert.Equal(tt.arm, arm, "should select by cumulative probability")
+	}
+
+	assert.Nil(pm.Init(1))
+	arm, err := pm.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should select the single arm")
+}
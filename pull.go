@@ -0,0 +1,59 @@
+package bandit
+
+// RecordPull counts a pull of an arm for which no reward will arrive, e.g.
+// the user bounced. The pull raises the count used for exploration, and since
+// the time step never falls behind the counts it advances the schedule too,
+// but the mean reward is unchanged. A later Update averages its reward over
+// the rewarded pulls only, so recording pulls never dilutes the mean.
+func (b *EpsilonGreedy) RecordPull(arm int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.initialized() {
+		return ErrNotInitialized
+	}
+	if b.Frozen {
+		return ErrFrozen
+	}
+	if arm < 0 || arm >= len(b.Counts) {
+		return &ArmIndexError{Index: arm, NumArms: len(b.Counts)}
+	}
+
+	b.ensureObservations()
+	arm = b.canonical(arm)
+	b.Counts[arm]++
+	b.fixBest(arm)
+	return nil
+}
+
+// GetObservations returns the number of rewards averaged into the mean of
+// each arm
+func (b *EpsilonGreedy) GetObservations() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	for i := range sCopy {
+		sCopy[i] = b.observations(i)
+	}
+	return sCopy
+}
+
+// ensureObservations assumes every pull was rewarded when the observations
+// are missing, e.g. for a bandit created from counts and rewards alone
+func (b *EpsilonGreedy) ensureObservations() {
+	if len(b.Observations) == len(b.Counts) {
+		return
+	}
+	b.Observations = make([]int, len(b.Counts))
+	copy(b.Observations, b.Counts)
+}
+
+// observations returns the number of rewards averaged into the mean of an
+// arm
+func (b *EpsilonGreedy) observations(arm int) int {
+	if len(b.Observations) != len(b.Counts) {
+		return b.Counts[arm]
+	}
+	return b.Observations[arm]
+}
@@ -0,0 +1,60 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_RecordPull(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(2)
+	assert.Nil(err)
+
+	assert.True(errors.Is(b.RecordPull(-1), ErrArmsIndexOutOfRange), "should throw error for invalid arm")
+	assert.True(errors.Is(b.RecordPull(2), ErrArmsIndexOutOfRange), "should throw error for invalid arm")
+
+	tests := []struct {
+		pull                 bool
+		reward               float64
+		expectedCounts       int
+		expectedObservations int
+		expectedReward       float64
+	}{
+		{false, 1.0, 1, 1, 1.0},
+		{true, 0.0, 2, 1, 1.0},
+		{true, 0.0, 3, 1, 1.0},
+		{false, 0.0, 4, 2, 0.5},
+		{true, 0.0, 5, 2, 0.5},
+		{false, 1.0, 6, 3, 2.0 / 3.0},
+	}
+
+	for i, tt := range tests {
+		if tt.pull {
+			assert.Nil(b.RecordPull(0))
+		} else {
+			assert.Nil(b.Update(0, tt.reward))
+		}
+		assert.Equal(tt.expectedCounts, b.GetCounts()[0], "should count every pull for test %d", i+1)
+		assert.Equal(tt.expectedObservations, b.GetObservations()[0], "should count the rewarded pulls for test %d", i+1)
+		assert.InDelta(tt.expectedReward, b.GetRewards()[0], 1e-9, "should average the rewarded pulls only for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_RecordPullOnRestoredState(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{2, 0}, []float64{0.5, 0.0})
+	assert.Nil(err)
+
+	assert.Nil(b.RecordPull(0))
+	assert.Nil(b.Update(0, 1.0))
+	assert.Equal([]int{4, 0}, b.GetCounts())
+	assert.Equal([]int{3, 0}, b.GetObservations(), "should assume the restored pulls were rewarded")
+	assert.InDelta(2.0/3.0, b.GetRewards()[0], 1e-9)
+}
@@ -0,0 +1,212 @@
+package bandit
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// QuantileBandit represents an epsilon greedy algorithm that maximises a
+// quantile of the rewards of each arm instead of the mean, e.g. the p90. The
+// quantile of each arm is estimated in constant memory with the P² algorithm,
+// and Rewards holds the estimates.
+type QuantileBandit struct {
+	sync.RWMutex
+	Quantile float64
+	Epsilon  float64
+	Counts   []int
+	Rewards  []float64
+
+	// Rand is used for exploration, and defaults to the math/rand source
+	Rand Rand
+
+	estimators []p2Estimator
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *QuantileBandit) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.estimators = make([]p2Estimator, nArms)
+	return nil
+}
+
+// SelectArm chooses the arm with the highest quantile if the value is more
+// than the epsilon threshold, and explores if the value is less than epsilon.
+// Unplayed arms are selected first.
+func (b *QuantileBandit) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	nArms := len(b.Rewards)
+	if nArms == 0 {
+		return -1, ErrNotInitialized
+	}
+	for i, count := range b.Counts {
+		if count == 0 {
+			return i, nil
+		}
+	}
+
+	// Exploit
+	if b.Epsilon == 0 || probability > b.Epsilon {
+		return max(b.Rewards...), nil
+	}
+
+	// Explore
+	return randIntn(b.Rand, nArms), nil
+}
+
+// EffectiveEpsilon returns the exploration rate the next selection uses, which
+// is the fixed Epsilon
+func (b *QuantileBandit) EffectiveEpsilon() float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Epsilon
+}
+
+// Update will update the quantile estimate of an arm with some reward value
+func (b *QuantileBandit) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if !(reward >= 0) || math.IsInf(reward, 1) {
+		return ErrInvalidReward
+	}
+
+	// NOTE: The estimators are missing for a bandit created from counts and
+	// quantiles alone, and start over from the next reward
+	if len(b.estimators) != len(b.Rewards) {
+		b.estimators = make([]p2Estimator, len(b.Rewards))
+	}
+	b.Counts[chosenArm]++
+	b.Rewards[chosenArm] = b.estimators[chosenArm].add(b.Quantile, reward)
+	return nil
+}
+
+// GetCounts returns the counts
+func (b *QuantileBandit) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the estimated quantile of each arm
+func (b *QuantileBandit) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewQuantileBandit returns a pointer to the QuantileBandit struct, where the
+// quantile is in range 0 to 1 exclusive, e.g. 0.9 for the p90
+func NewQuantileBandit(quantile, epsilon float64, counts []int, rewards []float64) (*QuantileBandit, error) {
+	if !(quantile > 0 && quantile < 1) {
+		return nil, ErrInvalidFraction
+	}
+	if epsilon < 0 || epsilon > 1 {
+		return nil, ErrInvalidEpsilon
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &QuantileBandit{
+		Quantile: quantile,
+		Epsilon:  epsilon,
+		Counts:   counts,
+		Rewards:  rewards,
+	}, nil
+}
+
+// p2Estimator estimates the p-quantile of a stream with the P² algorithm of
+// Jain and Chlamtac, which keeps five markers at the minimum, the p/2, p and
+// (1+p)/2 quantiles, and the maximum
+type p2Estimator struct {
+	count   int
+	heights [5]float64
+	pos     [5]float64
+	desired [5]float64
+}
+
+// add observes the value, and returns the quantile estimate. The first five
+// values are kept, and the estimate is their nearest-rank quantile.
+func (e *p2Estimator) add(p, x float64) float64 {
+	if e.count < 5 {
+		e.heights[e.count] = x
+		e.count++
+		sorted := e.heights[:e.count]
+		sort.Float64s(sorted)
+		if e.count == 5 {
+			e.pos = [5]float64{0, 1, 2, 3, 4}
+			e.desired = [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4}
+		}
+		return percentile(sorted, p)
+	}
+	e.count++
+
+	// Find the cell of the value, extending the extremes
+	var k int
+	switch {
+	case x < e.heights[0]:
+		e.heights[0] = x
+	case x >= e.heights[4]:
+		e.heights[4] = x
+		k = 3
+	default:
+		for x >= e.heights[k+1] {
+			k++
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.pos[i]++
+	}
+	increments := [5]float64{0, p / 2, p, (1 + p) / 2, 1}
+	for i := range e.desired {
+		e.desired[i] += increments[i]
+	}
+
+	// Move the middle markers towards their desired positions
+	for i := 1; i < 4; i++ {
+		d := e.desired[i] - e.pos[i]
+		if d >= 1 && e.pos[i+1]-e.pos[i] > 1 || d <= -1 && e.pos[i-1]-e.pos[i] < -1 {
+			sign := math.Copysign(1, d)
+			height := e.parabolic(i, sign)
+			if !(e.heights[i-1] < height && height < e.heights[i+1]) {
+				height = e.linear(i, sign)
+			}
+			e.heights[i] = height
+			e.pos[i] += sign
+		}
+	}
+	return e.heights[2]
+}
+
+// parabolic returns the piecewise-parabolic prediction of the height of the
+// marker moved by d
+func (e *p2Estimator) parabolic(i int, d float64) float64 {
+	q, n := e.heights, e.pos
+	return q[i] + d/(n[i+1]-n[i-1])*((n[i]-n[i-1]+d)*(q[i+1]-q[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-d)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+// linear returns the linear prediction of the height of the marker moved by d
+func (e *p2Estimator) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return e.heights[i] + d*(e.heights[j]-e.heights[i])/(e.pos[j]-e.pos[i])
+}
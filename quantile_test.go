@@ -0,0 +1,96 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuantileBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		quantile float64
+		epsilon  float64
+		err      error
+	}{
+		{0.9, 0.1, nil},
+		{0, 0.1, ErrInvalidFraction},
+		{1, 0.1, ErrInvalidFraction},
+		{0.9, 1.1, ErrInvalidEpsilon},
+	}
+	for _, tt := range tests {
+		_, err := NewQuantileBandit(tt.quantile, tt.epsilon, nil, nil)
+		assert.Equal(tt.err, err)
+	}
+
+	b, _ := NewQuantileBandit(0.9, 0.1, nil, nil)
+	_, err := b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Nil(b.Init(2))
+	assert.True(errors.Is(b.Update(2, 1), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidReward, b.Update(0, -1))
+}
+
+func TestP2Estimator(t *testing.T) {
+	assert := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	for _, p := range []float64{0.1, 0.5, 0.9, 0.99} {
+		var e p2Estimator
+		values := make([]float64, 20000)
+		var estimate float64
+		for i := range values {
+			values[i] = r.ExpFloat64()
+			estimate = e.add(p, values[i])
+		}
+		assert.InDelta(percentile(values, p), estimate, 0.05*percentile(values, p)+0.01, "should estimate the %v quantile", p)
+	}
+
+	var e p2Estimator
+	assert.Equal(3.0, e.add(0.5, 3))
+	assert.Equal(1.0, e.add(0.5, 1))
+	assert.Equal(2.0, e.add(0.5, 2), "should use the exact quantile of the first values")
+}
+
+func TestQuantileBandit_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	// The steady arm has the best mean, the volatile arm the best p90
+	env, err := NewGaussianEnv([]float64{1.0, 0.9}, []float64{0.05, 0.5}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	pull := func(arm int) float64 {
+		reward := env.Pull(arm)
+		if reward < 0 {
+			return 0
+		}
+		return reward
+	}
+
+	b, _ := NewQuantileBandit(0.9, 0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	b.Rand = rand.New(rand.NewSource(2))
+	means, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(means.Init(2))
+	means.Rand = rand.New(rand.NewSource(2))
+
+	r := rand.New(rand.NewSource(3))
+	pulls := 5000
+	for i := 0; i < pulls; i++ {
+		arm, err := b.SelectArm(r.Float64())
+		assert.Nil(err)
+		assert.Nil(b.Update(arm, pull(arm)))
+
+		arm, err = means.SelectArm(r.Float64())
+		assert.Nil(err)
+		assert.Nil(means.Update(arm, pull(arm)))
+	}
+
+	quantiles := b.GetRewards()
+	assert.InDelta(1.064, quantiles[0], 0.02, "should estimate the p90 of the steady arm")
+	assert.InDelta(1.54, quantiles[1], 0.1, "should estimate the p90 of the volatile arm")
+	assert.Greater(b.GetCounts()[1], pulls*3/4, "should favor the best p90")
+	assert.Greater(means.GetRewards()[0], means.GetRewards()[1], "the means rank the arms the other way")
+}
@@ -0,0 +1,128 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// TrafficRamp wraps a bandit for a gradual rollout over a fixed allocation,
+// and lets the bandit select the arm for a fraction of the calls only, while
+// the rest go to the DefaultArm, e.g. the arm served before the bandit. The
+// bandit keeps learning from every reward, including the ones of the default
+// arm.
+type TrafficRamp struct {
+	sync.Mutex
+	Bandit     Bandit
+	DefaultArm int
+
+	// TrafficFraction is the fraction of the calls the bandit controls, in
+	// the range 0 to 1
+	TrafficFraction float64
+
+	// RampDuration, when greater than zero, raises the fraction linearly from
+	// TrafficFraction at the start of the ramp to 1 once RampDuration passed
+	RampDuration time.Duration
+
+	// Rand is used to split the calls, and defaults to the math/rand source
+	Rand Rand
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	start time.Time
+}
+
+// Init will initialise the bandit with the provided number of arms, starting it
+// from scratch when it can start over, e.g. EpsilonGreedy, and keeps the ramp
+// going
+func (r *TrafficRamp) Init(nArms int) error {
+	return resetBandit(r.Bandit, nArms)
+}
+
+// SelectArm asks the bandit for the fraction of the calls it controls, and
+// returns the DefaultArm otherwise
+func (r *TrafficRamp) SelectArm(probability float64) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	nArms := len(r.Bandit.GetCounts())
+	if nArms == 0 {
+		return -1, ErrNotInitialized
+	}
+	if r.DefaultArm < 0 || r.DefaultArm >= nArms {
+		return -1, &ArmIndexError{Index: r.DefaultArm, NumArms: nArms}
+	}
+
+	// NOTE: The split draws its own number, so that it does not correlate
+	// with the exploration of the bandit drawn from the probability
+	if randFloat64(r.Rand) < r.fraction() {
+		return r.Bandit.SelectArm(probability)
+	}
+	return r.DefaultArm, nil
+}
+
+// Fraction returns the fraction of the calls the bandit currently controls
+func (r *TrafficRamp) Fraction() float64 {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.fraction()
+}
+
+func (r *TrafficRamp) fraction() float64 {
+	if r.RampDuration <= 0 {
+		return r.TrafficFraction
+	}
+	elapsed := math.Min(float64(r.now().Sub(r.start))/float64(r.RampDuration), 1)
+	return r.TrafficFraction + (1-r.TrafficFraction)*math.Max(elapsed, 0)
+}
+
+func (r *TrafficRamp) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// Update will update the bandit with some reward value
+func (r *TrafficRamp) Update(chosenArm int, reward float64) error {
+	return r.Bandit.Update(chosenArm, reward)
+}
+
+// GetCounts returns the counts of the bandit
+func (r *TrafficRamp) GetCounts() []int {
+	return r.Bandit.GetCounts()
+}
+
+// GetRewards returns the rewards of the bandit
+func (r *TrafficRamp) GetRewards() []float64 {
+	return r.Bandit.GetRewards()
+}
+
+// NewTrafficRamp returns a pointer to the TrafficRamp struct, letting b
+// control the fraction of the calls and sending the rest to the default arm.
+// A duration greater than zero ramps the fraction up to 1 over the duration of
+// the provided clock, starting from its current time, where a nil clock
+// defaults to time.Now.
+func NewTrafficRamp(b Bandit, defaultArm int, fraction float64, duration time.Duration, now func() time.Time) (*TrafficRamp, error) {
+	if !(fraction >= 0 && fraction <= 1) {
+		return nil, ErrInvalidFraction
+	}
+	if defaultArm < 0 {
+		return nil, &ArmIndexError{Index: defaultArm, NumArms: len(b.GetCounts())}
+	}
+	if duration < 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	r := &TrafficRamp{
+		Bandit:          b,
+		DefaultArm:      defaultArm,
+		TrafficFraction: fraction,
+		RampDuration:    duration,
+		Now:             now,
+	}
+	r.start = r.now()
+	return r, nil
+}
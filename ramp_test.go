@@ -0,0 +1,90 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTrafficRamp(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		defaultArm int
+		fraction   float64
+		duration   time.Duration
+		err        error
+	}{
+		{0, 0, 0, nil},
+		{1, 0.5, time.Hour, nil},
+		{0, 1, 0, nil},
+		{0, -0.1, 0, ErrInvalidFraction},
+		{0, 1.1, 0, ErrInvalidFraction},
+		{-1, 0.5, 0, &ArmIndexError{Index: -1, NumArms: 0}},
+		{0, 0.5, -time.Hour, ErrInvalidDuration},
+	}
+
+	for i, tt := range tests {
+		_, err := NewTrafficRamp(&EpsilonGreedy{}, tt.defaultArm, tt.fraction, tt.duration, nil)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestTrafficRamp_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0, []int{1, 1, 1}, []float64{0.1, 0.2, 0.9})
+	assert.Nil(err)
+	r, err := NewTrafficRamp(b, 0, 0.3, 0, nil)
+	assert.Nil(err)
+	r.Rand = rand.New(rand.NewSource(1))
+
+	const calls = 10000
+	controlled := 0
+	for i := 0; i < calls; i++ {
+		arm, err := r.SelectArm(0.5)
+		assert.Nil(err)
+		if arm == 2 {
+			controlled++
+		} else {
+			assert.Equal(0, arm, "should send the rest to the default arm")
+		}
+	}
+	assert.InDelta(0.3, float64(controlled)/calls, 0.02, "should let the bandit control the fraction")
+
+	r.DefaultArm = 3
+	_, err = r.SelectArm(0.5)
+	assert.Equal(&ArmIndexError{Index: 3, NumArms: 3}, err)
+}
+
+func TestTrafficRamp_Fraction(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b, err := NewEpsilonGreedy(0, []int{1, 1}, []float64{0.1, 0.9})
+	assert.Nil(err)
+	r, err := NewTrafficRamp(b, 0, 0.2, 10*time.Hour, clock.Now)
+	assert.Nil(err)
+
+	tests := []struct {
+		advance  time.Duration
+		expected float64
+	}{
+		{0, 0.2},
+		{5 * time.Hour, 0.6},
+		{5 * time.Hour, 1},
+		{time.Hour, 1},
+	}
+
+	for i, tt := range tests {
+		clock.Advance(tt.advance)
+		assert.InDelta(tt.expected, r.Fraction(), 1e-9, "should ramp the fraction for test %d", i+1)
+	}
+	for i := 0; i < 100; i++ {
+		arm, err := r.SelectArm(0.5)
+		assert.Nil(err)
+		assert.Equal(1, arm, "should let the bandit control every call after the ramp")
+	}
+}
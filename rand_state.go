@@ -0,0 +1,111 @@
+package bandit
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/rand"
+)
+
+// ReplayableRand is a seeded random source whose state can be serialized with
+// gob, so that a restored bandit continues the same selection sequence. The
+// state is the seed and the number of values drawn, and restoring replays the
+// draws, which takes time linear in their number. Like *rand.Rand, it is not
+// safe for concurrent use on its own, and relies on the lock of the bandit
+// using it.
+type ReplayableRand struct {
+	*rand.Rand
+	src *replaySource
+}
+
+// replaySource counts the values drawn from the math/rand source, which
+// advances one step for every value
+type replaySource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func (s *replaySource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *replaySource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *replaySource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
+
+// replayState is the serialized state of a ReplayableRand
+type replayState struct {
+	Seed  int64
+	Draws uint64
+}
+
+// GobEncode returns the seed and the number of values drawn
+func (r *ReplayableRand) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(replayState{Seed: r.src.seed, Draws: r.src.draws})
+	return buf.Bytes(), err
+}
+
+// GobDecode reseeds the source and replays the values drawn
+func (r *ReplayableRand) GobDecode(data []byte) error {
+	var state replayState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state); err != nil {
+		return err
+	}
+
+	restored := NewReplayableRand(state.Seed)
+	for i := uint64(0); i < state.Draws; i++ {
+		restored.src.src.Int63()
+	}
+	restored.src.draws = state.Draws
+	*r = *restored
+	return nil
+}
+
+// NewReplayableRand returns a pointer to the ReplayableRand struct seeded
+// with seed
+func NewReplayableRand(seed int64) *ReplayableRand {
+	src := &replaySource{
+		src:  rand.NewSource(seed).(rand.Source64),
+		seed: seed,
+	}
+	return &ReplayableRand{
+		Rand: rand.New(src),
+		src:  src,
+	}
+}
+
+// RandState returns the serialized state of the random source, to be saved
+// alongside the bandit. The source must implement gob.GobEncoder, e.g.
+// ReplayableRand, and the default math/rand source cannot be captured.
+func (b *EpsilonGreedy) RandState() ([]byte, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	encoder, ok := b.Rand.(gob.GobEncoder)
+	if !ok {
+		return nil, ErrRandNotSerializable
+	}
+	return encoder.GobEncode()
+}
+
+// SetRandState restores the state returned by RandState into the random
+// source, which must implement gob.GobDecoder, e.g. a ReplayableRand
+func (b *EpsilonGreedy) SetRandState(data []byte) error {
+	b.Lock()
+	defer b.Unlock()
+
+	decoder, ok := b.Rand.(gob.GobDecoder)
+	if !ok {
+		return ErrRandNotSerializable
+	}
+	return decoder.GobDecode(data)
+}
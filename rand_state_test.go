@@ -0,0 +1,82 @@
+package bandit
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_RandState(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.5, nil, nil)
+	assert.Nil(b.Init(5))
+	b.Rand = NewReplayableRand(42)
+	env, err := NewBernoulliEnv([]float64{0.1, 0.3, 0.5, 0.7, 0.9}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+
+	play := func(b *EpsilonGreedy, pulls int) []int {
+		arms := make([]int, pulls)
+		for i := range arms {
+			arm, err := b.SelectArm(b.float64())
+			assert.Nil(err)
+			assert.Nil(b.Update(arm, env.Pull(arm)))
+			arms[i] = arm
+		}
+		return arms
+	}
+	play(b, 100)
+
+	state, err := json.Marshal(b)
+	assert.Nil(err)
+	randState, err := b.RandState()
+	assert.Nil(err)
+
+	// The rewards are replayed from the same seed after the reload
+	env.Rand = rand.New(rand.NewSource(2))
+	expected := play(b, 100)
+
+	restored := &EpsilonGreedy{}
+	assert.Nil(json.Unmarshal(state, restored))
+	restored.Rand = NewReplayableRand(0)
+	assert.Nil(restored.SetRandState(randState))
+	env.Rand = rand.New(rand.NewSource(2))
+	assert.Equal(expected, play(restored, 100), "should continue the selections after a reload")
+}
+
+func TestEpsilonGreedy_RandStateNotSerializable(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.5, nil, nil)
+	_, err := b.RandState()
+	assert.Equal(ErrRandNotSerializable, err, "should not capture the global source")
+	assert.Equal(ErrRandNotSerializable, b.SetRandState(nil))
+
+	b.Rand = rand.New(rand.NewSource(1))
+	_, err = b.RandState()
+	assert.Equal(ErrRandNotSerializable, err)
+
+	b.Rand = NewReplayableRand(1)
+	assert.NotNil(b.SetRandState([]byte("invalid")))
+}
+
+func TestReplayableRand(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewReplayableRand(7)
+	r.Float64()
+	r.Intn(10)
+	r.NormFloat64()
+	r.Uint64()
+
+	data, err := r.GobEncode()
+	assert.Nil(err)
+	restored := NewReplayableRand(0)
+	assert.Nil(restored.GobDecode(data))
+	for i := 0; i < 10; i++ {
+		assert.Equal(r.Float64(), restored.Float64())
+		assert.Equal(r.Intn(100), restored.Intn(100))
+	}
+}
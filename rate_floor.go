@@ -0,0 +1,187 @@
+package bandit
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// rateBuckets is the number of one minute buckets of the trailing hour
+const rateBuckets = 60
+
+// RateFloor wraps a bandit, and guarantees each arm a minimum number of
+// selections per hour, e.g. so that every creative stays fresh, regardless of
+// the policy of the bandit. An arm falls behind when its selections within
+// the trailing hour are at least one short of its floor, prorated during the
+// first hour, and SelectArm then selects the arm furthest behind instead of
+// asking the bandit. The selections are counted in one minute buckets.
+type RateFloor struct {
+	sync.Mutex
+	Bandit Bandit
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	floors  []float64
+	start   time.Time
+	buckets [rateBuckets]rateBucket
+}
+
+// rateBucket counts the selections of each arm within one minute
+type rateBucket struct {
+	minute int64
+	counts []int
+}
+
+// Init will initialise the bandit with the provided number of arms, starting it
+// from scratch when it can start over, e.g. EpsilonGreedy, and reset the
+// selections counted
+func (r *RateFloor) Init(nArms int) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if err := resetBandit(r.Bandit, nArms); err != nil {
+		return err
+	}
+	r.buckets = [rateBuckets]rateBucket{}
+	r.start = r.now()
+	return nil
+}
+
+// SelectArm selects the arm furthest behind its floor, or asks the bandit
+// when no arm is behind
+func (r *RateFloor) SelectArm(probability float64) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+	nArms := len(r.Bandit.GetCounts())
+	if r.floors != nil && len(r.floors) != nArms {
+		return -1, ErrInvalidLength
+	}
+
+	arm := r.behind(now, nArms)
+	if arm < 0 {
+		var err error
+		if arm, err = r.Bandit.SelectArm(probability); err != nil {
+			return -1, err
+		}
+	}
+	r.record(now, arm, nArms)
+	return arm, nil
+}
+
+// behind returns the arm furthest behind its floor, or -1 when none is
+func (r *RateFloor) behind(now time.Time, nArms int) int {
+	// NOTE: The trailing hour only fills up once the first hour passed
+	covered := math.Min(float64(now.Sub(r.start))/float64(time.Hour), 1)
+	realized := r.realized(now, nArms)
+
+	arm := -1
+	deficit := 1.0
+	for i, floor := range r.floors {
+		if d := floor*covered - float64(realized[i]); d >= deficit {
+			arm, deficit = i, d
+		}
+	}
+	return arm
+}
+
+// record counts the selection of the arm in the bucket of the current minute
+func (r *RateFloor) record(now time.Time, arm, nArms int) {
+	minute := now.Unix() / 60
+	bucket := &r.buckets[minute%rateBuckets]
+	if bucket.minute != minute || len(bucket.counts) != nArms {
+		bucket.minute = minute
+		bucket.counts = make([]int, nArms)
+	}
+	if arm >= 0 && arm < nArms {
+		bucket.counts[arm]++
+	}
+}
+
+// realized returns the selections of each arm within the trailing hour
+func (r *RateFloor) realized(now time.Time, nArms int) []int {
+	minute := now.Unix() / 60
+	realized := make([]int, nArms)
+	for _, bucket := range r.buckets {
+		if minute-bucket.minute >= rateBuckets || len(bucket.counts) != nArms {
+			continue
+		}
+		for i, count := range bucket.counts {
+			realized[i] += count
+		}
+	}
+	return realized
+}
+
+// now returns the current time of the clock
+func (r *RateFloor) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// SetFloors sets the minimum selections per hour of each arm, which must not
+// be negative, where zero is no floor. Passing nil removes the floors.
+func (r *RateFloor) SetFloors(floors []float64) error {
+	for _, floor := range floors {
+		if !(floor >= 0) || math.IsInf(floor, 1) {
+			return ErrInvalidRate
+		}
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	r.floors = slices.Clone(floors)
+	return nil
+}
+
+// Floors returns the minimum selections per hour of each arm
+func (r *RateFloor) Floors() []float64 {
+	r.Lock()
+	defer r.Unlock()
+
+	return slices.Clone(r.floors)
+}
+
+// RealizedRates returns the selections of each arm within the trailing hour
+func (r *RateFloor) RealizedRates() []int {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.realized(r.now(), len(r.Bandit.GetCounts()))
+}
+
+// Update will update the bandit with some reward value
+func (r *RateFloor) Update(chosenArm int, reward float64) error {
+	return r.Bandit.Update(chosenArm, reward)
+}
+
+// GetCounts returns the counts of the bandit
+func (r *RateFloor) GetCounts() []int {
+	return r.Bandit.GetCounts()
+}
+
+// GetRewards returns the rewards of the bandit
+func (r *RateFloor) GetRewards() []float64 {
+	return r.Bandit.GetRewards()
+}
+
+// NewRateFloor returns a pointer to the RateFloor struct, guaranteeing each
+// arm of b its floor of selections per hour of the provided clock, starting
+// from its current time. A nil clock defaults to time.Now.
+func NewRateFloor(b Bandit, floors []float64, now func() time.Time) (*RateFloor, error) {
+	r := &RateFloor{
+		Bandit: b,
+		Now:    now,
+	}
+	if err := r.SetFloors(floors); err != nil {
+		return nil, err
+	}
+	r.start = r.now()
+	return r, nil
+}
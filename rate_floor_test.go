@@ -0,0 +1,62 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRateFloor(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		floors []float64
+		err    error
+	}{
+		{[]float64{0, 10}, nil},
+		{nil, nil},
+		{[]float64{-1, 10}, ErrInvalidRate},
+		{[]float64{math.NaN()}, ErrInvalidRate},
+		{[]float64{math.Inf(1)}, ErrInvalidRate},
+	}
+
+	for i, tt := range tests {
+		b, _ := NewEpsilonGreedy(0, nil, nil)
+		_, err := NewRateFloor(b, tt.floors, nil)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestRateFloor_SelectArm(t *testing.T) {
+	assert := assert.New(t)
+
+	// Arm 0 is always exploited, so arm 1 is only selected by its floor
+	b, _ := NewEpsilonGreedy(0, []int{1, 1, 1}, []float64{1, 0, 0})
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r, err := NewRateFloor(b, []float64{0, 10}, clock.Now)
+	assert.Nil(err)
+
+	_, err = r.SelectArm(0.5)
+	assert.Equal(ErrInvalidLength, err, "should throw error for floors of another length")
+	assert.Nil(r.SetFloors([]float64{0, 10, 0}))
+	assert.Equal([]float64{0, 10, 0}, r.Floors())
+
+	forced := 0
+	for minute := 0; minute < 120; minute++ {
+		clock.Advance(time.Minute)
+		arm, err := r.SelectArm(0.5)
+		assert.Nil(err)
+		if arm == 1 {
+			forced++
+		}
+		assert.NotEqual(2, arm, "should not select an arm without a floor")
+	}
+
+	rates := r.RealizedRates()
+	assert.GreaterOrEqual(rates[1], 9, "should keep the neglected arm near its floor")
+	assert.LessOrEqual(rates[1], 10, "should not select the arm beyond its floor")
+	assert.Equal(60, rates[0]+rates[1]+rates[2], "should count the trailing hour")
+	assert.InDelta(20, forced, 1, "should force about the floor every hour")
+}
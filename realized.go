@@ -0,0 +1,63 @@
+package bandit
+
+import "math"
+
+// RewardQuantiles estimates quantiles of the realized rewards across all the
+// arms, e.g. the p50 and p99 for SLA dashboards, in constant memory with one
+// P² estimator per quantile. It is guarded by the lock of the bandit it
+// belongs to.
+type RewardQuantiles struct {
+	quantiles  []float64
+	estimators []p2Estimator
+	estimates  []float64
+}
+
+// observe records a realized reward
+func (r *RewardQuantiles) observe(reward float64) {
+	for i, q := range r.quantiles {
+		r.estimates[i] = r.estimators[i].add(q, reward)
+	}
+}
+
+// quantile returns the estimate of the tracked quantile q, or NaN when q is
+// not tracked or no reward was observed
+func (r *RewardQuantiles) quantile(q float64) float64 {
+	for i, tracked := range r.quantiles {
+		if tracked == q && r.estimators[i].count > 0 {
+			return r.estimates[i]
+		}
+	}
+	return math.NaN()
+}
+
+// RealizedRewardQuantile returns the estimated q-quantile of the rewards
+// received by the bandit before any RewardTransform, which is NaN without
+// Realized, before any reward, or when Realized does not track q
+func (b *EpsilonGreedy) RealizedRewardQuantile(q float64) float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.Realized == nil {
+		return math.NaN()
+	}
+	return b.Realized.quantile(q)
+}
+
+// NewRewardQuantiles returns a pointer to the RewardQuantiles struct tracking
+// the quantiles, each in range 0 to 1 exclusive
+func NewRewardQuantiles(quantiles ...float64) (*RewardQuantiles, error) {
+	if len(quantiles) == 0 {
+		return nil, ErrInvalidSize
+	}
+	for _, q := range quantiles {
+		if !(q > 0 && q < 1) {
+			return nil, ErrInvalidFraction
+		}
+	}
+
+	return &RewardQuantiles{
+		quantiles:  append([]float64(nil), quantiles...),
+		estimators: make([]p2Estimator, len(quantiles)),
+		estimates:  make([]float64, len(quantiles)),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package bandit
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRewardQuantiles(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		quantiles []float64
+		err       error
+	}{
+		{[]float64{0.5, 0.99}, nil},
+		{nil, ErrInvalidSize},
+		{[]float64{0.5, 1}, ErrInvalidFraction},
+		{[]float64{0}, ErrInvalidFraction},
+		{[]float64{math.NaN()}, ErrInvalidFraction},
+	}
+
+	for i, tt := range tests {
+		_, err := NewRewardQuantiles(tt.quantiles...)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_RealizedRewardQuantile(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(3))
+	assert.True(math.IsNaN(b.RealizedRewardQuantile(0.5)), "should be off by default")
+
+	var err error
+	b.Realized, err = NewRewardQuantiles(0.5, 0.9)
+	assert.Nil(err)
+	assert.True(math.IsNaN(b.RealizedRewardQuantile(0.5)), "should have no estimate before any reward")
+
+	// Exponential rewards with a rate of 1, with the median ln 2 and the p90
+	// ln 10
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		assert.Nil(b.Update(i%3, r.ExpFloat64()))
+	}
+	assert.InDelta(math.Ln2, b.RealizedRewardQuantile(0.5), 0.05, "should estimate the median")
+	assert.InDelta(math.Log(10), b.RealizedRewardQuantile(0.9), 0.1, "should estimate the p90")
+	assert.True(math.IsNaN(b.RealizedRewardQuantile(0.99)), "should not estimate untracked quantiles")
+}
@@ -0,0 +1,67 @@
+package bandit
+
+// Remap returns a new bandit with a reshaped arm set, where mapping[i] is the
+// old arm that new arm i copies its stats from, or -1 for an unplayed arm. An
+// old arm may be copied to several new arms, e.g. when splitting a creative,
+// or to none. Fresh arms cost one unit when costs are set, and are similar to
+// no other arm when similarities are set. The aliases and the smoothed
+// probabilities are left out since they depend on the old arm set.
+func (b *EpsilonGreedy) Remap(mapping []int) (*EpsilonGreedy, error) {
+	remapped := b.Clone()
+	if !remapped.initialized() {
+		return nil, ErrNotInitialized
+	}
+	if len(mapping) == 0 {
+		return nil, ErrInvalidArms
+	}
+
+	nArms := len(remapped.Rewards)
+	for _, arm := range mapping {
+		if arm < -1 || arm >= nArms {
+			return nil, &ArmIndexError{Index: arm, NumArms: nArms}
+		}
+	}
+
+	remapIfSized(&remapped.Counts, nArms, mapping, 0)
+	remapIfSized(&remapped.Rewards, nArms, mapping, 0)
+	remapIfSized(&remapped.Observations, nArms, mapping, 0)
+	remapIfSized(&remapped.M2, nArms, mapping, 0)
+	remapIfSized(&remapped.M2Counts, nArms, mapping, 0)
+	remapIfSized(&remapped.Weights, nArms, mapping, 0)
+	remapIfSized(&remapped.SquaredWeights, nArms, mapping, 0)
+	remapIfSized(&remapped.Precisions, nArms, mapping, 0)
+	remapIfSized(&remapped.PrecisionCounts, nArms, mapping, 0)
+	remapIfSized(&remapped.Disabled, nArms, mapping, false)
+	remapIfSized(&remapped.ObjectiveCounts, nArms, mapping, 0)
+	for objective := range remapped.ObjectiveMeans {
+		means := remapped.ObjectiveMeans[objective]
+		remapIfSized(&means, nArms, mapping, 0)
+		remapped.ObjectiveMeans[objective] = means
+	}
+	remapIfSized(&remapped.CooldownUntil, nArms, mapping, 0)
+	remapIfSized(&remapped.Costs, nArms, mapping, 1)
+	remapSimilarity(&remapped.SimilarityMatrix, nArms, mapping)
+	remapIfSized(&remapped.ZeroStreaks, nArms, mapping, 0)
+	remapIfSized(&remapped.Targets, nArms, mapping, 0)
+	remapIfSized(&remapped.Earned, nArms, mapping, 0)
+	remapped.Aliases = nil
+	remapped.Smoothed = nil
+	return remapped, nil
+}
+
+// remapIfSized reshapes the per-arm state with the mapping, unless the state
+// is missing
+func remapIfSized[T any](values *[]T, nArms int, mapping []int, fresh T) {
+	if len(*values) != nArms {
+		return
+	}
+	remapped := make([]T, len(mapping))
+	for i, arm := range mapping {
+		if arm < 0 {
+			remapped[i] = fresh
+			continue
+		}
+		remapped[i] = (*values)[arm]
+	}
+	*values = remapped
+}
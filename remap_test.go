@@ -0,0 +1,43 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_Remap(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, []int{10, 20, 30}, []float64{0.1, 0.2, 0.3})
+	b.Costs = []float64{2, 3, 4}
+	assert.Nil(b.Disable(0))
+
+	remapped, err := b.Remap([]int{2, 0, -1, 2})
+	assert.Nil(err)
+	assert.Equal([]int{30, 10, 0, 30}, remapped.GetCounts(), "should copy the counts")
+	assert.Equal([]float64{0.3, 0.1, 0, 0.3}, remapped.GetRewards(), "should copy the rewards")
+	assert.Equal([]float64{4, 2, 1, 4}, remapped.Costs, "should cost one unit for the fresh arm")
+	assert.Equal([]bool{false, true, false, false}, remapped.Disabled)
+	assert.Equal(0.1, remapped.Epsilon)
+
+	assert.Nil(remapped.Update(2, 1))
+	assert.Equal([]int{10, 20, 30}, b.GetCounts(), "should not change the old bandit")
+
+	tests := []struct {
+		mapping []int
+		err     error
+	}{
+		{nil, ErrInvalidArms},
+		{[]int{0, 3}, ErrArmsIndexOutOfRange},
+		{[]int{-2}, ErrArmsIndexOutOfRange},
+	}
+	for _, tt := range tests {
+		_, err := b.Remap(tt.mapping)
+		assert.True(errors.Is(err, tt.err), "should validate mapping %v", tt.mapping)
+	}
+
+	_, err = (&EpsilonGreedy{}).Remap([]int{-1})
+	assert.Equal(ErrNotInitialized, err)
+}
@@ -0,0 +1,36 @@
+package bandit
+
+// SetReport sets the callback that receives a snapshot of the stats every
+// given number of updates, e.g. to emit metrics in batches. A nil callback
+// disables reporting.
+func (b *EpsilonGreedy) SetReport(every int, onReport func(Metrics)) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if every < 1 {
+		return ErrInvalidSize
+	}
+	b.ReportEvery = every
+	b.OnReport = onReport
+	b.sinceReport = 0
+	return nil
+}
+
+// report counts an update, and returns the callback to run with the snapshot
+// once ReportEvery updates are made. The snapshot is taken under the lock and
+// owned by the callback.
+func (b *EpsilonGreedy) report() func() {
+	if b.ReportEvery < 1 || b.OnReport == nil {
+		return nil
+	}
+	b.sinceReport++
+	if b.sinceReport < b.ReportEvery {
+		return nil
+	}
+	b.sinceReport = 0
+
+	onReport, snapshot := b.OnReport, b.metrics()
+	return func() {
+		onReport(snapshot)
+	}
+}
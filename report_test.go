@@ -0,0 +1,36 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SetReport(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	assert.Equal(ErrInvalidSize, b.SetReport(0, func(Metrics) {}), "should throw error for invalid interval")
+
+	var reports []Metrics
+	assert.Nil(b.SetReport(3, func(m Metrics) {
+		reports = append(reports, m)
+	}))
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(b.Update(i%2, 1.0))
+	}
+	assert.NotNil(b.Update(5, 1.0))
+	assert.Equal(3, len(reports), "should report every 3 successful updates")
+
+	assert.Equal(0.1, reports[0].Epsilon)
+	assert.Equal(2, reports[0].Arms[0].Count, "should report the stats as of the report")
+	assert.Equal(1, reports[0].Arms[1].Count, "should report the stats as of the report")
+	assert.Equal(9, reports[2].Arms[0].Count+reports[2].Arms[1].Count)
+
+	reports[0].Arms[0].Count = 100
+	assert.Equal([]int{5, 5}, b.GetCounts(), "should report a copy of the stats")
+}
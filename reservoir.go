@@ -0,0 +1,67 @@
+package bandit
+
+import "time"
+
+// Interaction represents a single update received by the bandit
+type Interaction struct {
+	Arm       int       `json:"arm"`
+	Reward    float64   `json:"reward"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Reservoir keeps a bounded, uniform sample of the interactions using
+// reservoir sampling. It is guarded by the lock of the bandit it belongs to.
+type Reservoir struct {
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	size  int
+	seen  int
+	items []Interaction
+}
+
+// add offers an interaction to the reservoir, where intn returns a random
+// number in the range 0 to n
+func (r *Reservoir) add(arm int, reward float64, intn func(n int) int) {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	interaction := Interaction{Arm: arm, Reward: reward, Timestamp: now()}
+
+	r.seen++
+	if len(r.items) < r.size {
+		r.items = append(r.items, interaction)
+		return
+	}
+	if j := intn(r.seen); j < r.size {
+		r.items[j] = interaction
+	}
+}
+
+// NewReservoir returns a pointer to the Reservoir struct holding up to size
+// interactions
+func NewReservoir(size int) (*Reservoir, error) {
+	if size < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &Reservoir{
+		size:  size,
+		items: make([]Interaction, 0, size),
+	}, nil
+}
+
+// Sample returns a copy of the sampled interactions, or nil when the
+// reservoir is not enabled
+func (b *EpsilonGreedy) Sample() []Interaction {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.Reservoir == nil {
+		return nil
+	}
+	sCopy := make([]Interaction, len(b.Reservoir.items))
+	copy(sCopy, b.Reservoir.items)
+	return sCopy
+}
@@ -0,0 +1,64 @@
+package bandit
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReservoir(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		size int
+		err  error
+	}{
+		{-1, ErrInvalidSize},
+		{0, ErrInvalidSize},
+		{1, nil},
+		{100, nil},
+	}
+
+	for _, tt := range tests {
+		_, err := NewReservoir(tt.size)
+		assert.Equal(tt.err, err, "should throw the correct error")
+	}
+}
+
+func TestEpsilonGreedy_Sample(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(3)
+	assert.Nil(err)
+	assert.Nil(b.Sample(), "should be disabled by default")
+
+	b.Rand = rand.New(rand.NewSource(1))
+	b.Reservoir, err = NewReservoir(10)
+	assert.Nil(err)
+
+	var wg sync.WaitGroup
+	wg.Add(4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 250; j++ {
+				b.Update(j%3, float64(j%2))
+				b.Sample()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sample := b.Sample()
+	assert.Equal(10, len(sample), "should stay bounded")
+	for _, interaction := range sample {
+		assert.True(interaction.Arm >= 0 && interaction.Arm < 3, "should hold a valid arm")
+		assert.True(interaction.Reward == 0.0 || interaction.Reward == 1.0, "should hold a valid reward")
+		assert.False(interaction.Timestamp.IsZero(), "should hold the timestamp")
+	}
+}
@@ -0,0 +1,172 @@
+package bandit
+
+import (
+	"math"
+	"time"
+)
+
+// Schedule computes the exploration rate used by epsilon greedy, given the
+// number of selections made so far
+type Schedule interface {
+	Epsilon(pulls int) float64
+}
+
+// TimeSchedule anneals epsilon linearly from EpsilonMax to EpsilonMin over a
+// wall-clock campaign duration, regardless of the traffic the bandit receives
+type TimeSchedule struct {
+	EpsilonMax float64
+	EpsilonMin float64
+	Start      time.Time
+	Duration   time.Duration
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+}
+
+// Epsilon returns the exploration rate at the current time. The number of
+// selections is ignored.
+func (s *TimeSchedule) Epsilon(pulls int) float64 {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+
+	progress := float64(now().Sub(s.Start)) / float64(s.Duration)
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 1 {
+		progress = 1
+	}
+	return s.EpsilonMax - (s.EpsilonMax-s.EpsilonMin)*progress
+}
+
+// NewTimeSchedule returns a pointer to the TimeSchedule struct starting at
+// the current time of the provided clock. A nil clock defaults to time.Now.
+func NewTimeSchedule(epsilonMax, epsilonMin float64, duration time.Duration, now func() time.Time) (*TimeSchedule, error) {
+	if epsilonMax < 0 || epsilonMax > 1 || epsilonMin < 0 || epsilonMin > epsilonMax {
+		return nil, ErrInvalidEpsilon
+	}
+	if duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
+	if now == nil {
+		now = time.Now
+	}
+
+	return &TimeSchedule{
+		EpsilonMax: epsilonMax,
+		EpsilonMin: epsilonMin,
+		Start:      now(),
+		Duration:   duration,
+		Now:        now,
+	}, nil
+}
+
+// AnnealingSchedule anneals epsilon from EpsilonMax as EpsilonMax/ln(pulls+e),
+// and clamps it at the EpsilonMin floor so the bandit keeps exploring enough
+// to notice the arms drifting
+type AnnealingSchedule struct {
+	EpsilonMax float64
+	EpsilonMin float64
+}
+
+// Epsilon returns the exploration rate after the number of selections
+func (s *AnnealingSchedule) Epsilon(pulls int) float64 {
+	if pulls < 0 {
+		pulls = 0
+	}
+	epsilon := s.EpsilonMax / math.Log(float64(pulls)+math.E)
+	return math.Max(epsilon, s.EpsilonMin)
+}
+
+// NewAnnealingSchedule returns a pointer to the AnnealingSchedule struct,
+// where the floor must be in range 0 to epsilonMax
+func NewAnnealingSchedule(epsilonMax, epsilonMin float64) (*AnnealingSchedule, error) {
+	if epsilonMax < 0 || epsilonMax > 1 || epsilonMin < 0 || epsilonMin > epsilonMax {
+		return nil, ErrInvalidEpsilon
+	}
+
+	return &AnnealingSchedule{
+		EpsilonMax: epsilonMax,
+		EpsilonMin: epsilonMin,
+	}, nil
+}
+
+// BudgetDecay is the shape of the decay of a BudgetSchedule
+type BudgetDecay int
+
+const (
+	// LinearDecay decays epsilon at a constant rate over the budget
+	LinearDecay BudgetDecay = iota
+	// CosineDecay decays epsilon along half a cosine over the budget, slowly
+	// at first and at the end, which keeps more of the exploration early
+	CosineDecay
+)
+
+// BudgetSchedule decays epsilon from EpsilonMax to EpsilonMin over a known
+// Budget of selections, e.g. the impressions of a fixed-budget campaign, so
+// that the decay is planned against the total rather than the pulls alone.
+// Epsilon stays at EpsilonMin once the budget is consumed.
+type BudgetSchedule struct {
+	EpsilonMax float64
+	EpsilonMin float64
+	Budget     int
+	Decay      BudgetDecay
+}
+
+// Epsilon returns the exploration rate after the number of selections
+func (s *BudgetSchedule) Epsilon(pulls int) float64 {
+	progress := s.Consumed(pulls)
+	if s.Decay == CosineDecay {
+		progress = (1 - math.Cos(math.Pi*progress)) / 2
+	}
+	return s.EpsilonMax - (s.EpsilonMax-s.EpsilonMin)*progress
+}
+
+// Consumed returns the fraction of the budget consumed by the number of
+// selections, in range 0 to 1
+func (s *BudgetSchedule) Consumed(pulls int) float64 {
+	if pulls <= 0 {
+		return 0
+	}
+	if pulls >= s.Budget {
+		return 1
+	}
+	return float64(pulls) / float64(s.Budget)
+}
+
+// BudgetConsumed returns the fraction of the budget of the BudgetSchedule
+// consumed at the current time step, or NaN with another Schedule
+func (b *EpsilonGreedy) BudgetConsumed() float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	s, ok := b.Schedule.(*BudgetSchedule)
+	if !ok {
+		return math.NaN()
+	}
+	return s.Consumed(timeStep(b.SelectionCount, b.Counts))
+}
+
+// NewBudgetSchedule returns a pointer to the BudgetSchedule struct decaying
+// over the budget of selections, which must be greater than zero, where the
+// floor must be in range 0 to epsilonMax
+func NewBudgetSchedule(epsilonMax, epsilonMin float64, budget int, decay BudgetDecay) (*BudgetSchedule, error) {
+	if epsilonMax < 0 || epsilonMax > 1 || epsilonMin < 0 || epsilonMin > epsilonMax {
+		return nil, ErrInvalidEpsilon
+	}
+	if budget < 1 {
+		return nil, ErrInvalidPulls
+	}
+	if decay != LinearDecay && decay != CosineDecay {
+		return nil, ErrInvalidSchedule
+	}
+
+	return &BudgetSchedule{
+		EpsilonMax: epsilonMax,
+		EpsilonMin: epsilonMin,
+		Budget:     budget,
+		Decay:      decay,
+	}, nil
+}
@@ -0,0 +1,232 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestNewTimeSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		epsilonMax float64
+		epsilonMin float64
+		duration   time.Duration
+		err        error
+	}{
+		{0.5, 0.1, time.Hour, nil},
+		{0.5, 0.5, time.Hour, nil},
+		{1.1, 0.1, time.Hour, ErrInvalidEpsilon},
+		{0.5, -0.1, time.Hour, ErrInvalidEpsilon},
+		{0.1, 0.5, time.Hour, ErrInvalidEpsilon},
+		{0.5, 0.1, 0, ErrInvalidDuration},
+		{0.5, 0.1, -time.Hour, ErrInvalidDuration},
+	}
+
+	for i, tt := range tests {
+		_, err := NewTimeSchedule(tt.epsilonMax, tt.epsilonMin, tt.duration, nil)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestTimeSchedule_Epsilon(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s, err := NewTimeSchedule(0.5, 0.1, 10*24*time.Hour, clock.Now)
+	assert.Nil(err)
+
+	tests := []struct {
+		advance  time.Duration
+		expected float64
+	}{
+		{0, 0.5},
+		{5 * 24 * time.Hour, 0.3},
+		{5 * 24 * time.Hour, 0.1},
+		{24 * time.Hour, 0.1},
+	}
+
+	for i, tt := range tests {
+		clock.Advance(tt.advance)
+		assert.InDelta(tt.expected, s.Epsilon(0), 1e-9, "should anneal epsilon for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_SelectArmWithTimeSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s, err := NewTimeSchedule(1.0, 0.0, time.Hour, clock.Now)
+	assert.Nil(err)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1}, []float64{0.0, 1.0})
+	assert.Nil(err)
+	b.Schedule = s
+
+	// At the midpoint epsilon is 0.5, so a probability of 0.6 exploits
+	clock.Advance(30 * time.Minute)
+	arm, err := b.SelectArm(0.6)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should exploit the best arm at the midpoint")
+}
+
+func TestNewAnnealingSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		epsilonMax float64
+		epsilonMin float64
+		err        error
+	}{
+		{0.5, 0.01, nil},
+		{0.5, 0, nil},
+		{0.5, 0.5, nil},
+		{0.5, 0.6, ErrInvalidEpsilon},
+		{0.5, -0.1, ErrInvalidEpsilon},
+		{1.1, 0.1, ErrInvalidEpsilon},
+	}
+
+	for _, tt := range tests {
+		s, err := NewAnnealingSchedule(tt.epsilonMax, tt.epsilonMin)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw error for invalid epsilon")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.epsilonMin, s.EpsilonMin, "floor should be equal")
+		}
+	}
+}
+
+func TestAnnealingSchedule_Epsilon(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := NewAnnealingSchedule(0.5, 0.05)
+	assert.Nil(err)
+
+	assert.InDelta(0.5, s.Epsilon(0), 1e-9, "should start at epsilon")
+	previous := s.Epsilon(0)
+	for _, pulls := range []int{10, 100, 1000} {
+		epsilon := s.Epsilon(pulls)
+		assert.Less(epsilon, previous, "should decay with the pulls")
+		previous = epsilon
+	}
+
+	b, err := NewEpsilonGreedy(0.5, []int{1e9, 1e9}, []float64{0.1, 0.2})
+	assert.Nil(err)
+	b.Schedule = s
+	assert.Equal(0.05, b.Metrics().Epsilon, "should plateau at the floor")
+	assert.Equal(0.05, s.Epsilon(math.MaxInt), "should never fall below the floor")
+}
+
+func TestEpsilonGreedy_EffectiveEpsilon(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.3, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+	assert.Equal(0.3, b.EffectiveEpsilon(), "should use the fixed epsilon")
+	assert.Nil(b.Update(0, 1))
+	assert.Equal(0.3, b.EffectiveEpsilon(), "should not change the fixed epsilon with updates")
+
+	s, err := NewAnnealingSchedule(0.5, 0.05)
+	assert.Nil(err)
+	b.Schedule = s
+	assert.InDelta(s.Epsilon(1), b.EffectiveEpsilon(), 1e-9, "should anneal with the counts")
+
+	previous := b.EffectiveEpsilon()
+	for i := 0; i < 100; i++ {
+		_, err := b.SelectArm(0.5)
+		assert.Nil(err)
+	}
+	assert.InDelta(s.Epsilon(100), b.EffectiveEpsilon(), 1e-9, "should anneal with the selections")
+	assert.Less(b.EffectiveEpsilon(), previous)
+	assert.Equal(0.3, b.Epsilon, "should keep the nominal epsilon")
+}
+
+func TestNewBudgetSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		epsilonMax float64
+		epsilonMin float64
+		budget     int
+		decay      BudgetDecay
+		err        error
+	}{
+		{0.5, 0.05, 1000, LinearDecay, nil},
+		{0.5, 0.05, 1000, CosineDecay, nil},
+		{0.5, 0.6, 1000, LinearDecay, ErrInvalidEpsilon},
+		{1.1, 0.1, 1000, LinearDecay, ErrInvalidEpsilon},
+		{0.5, 0.05, 0, LinearDecay, ErrInvalidPulls},
+		{0.5, 0.05, 1000, BudgetDecay(2), ErrInvalidSchedule},
+	}
+
+	for i, tt := range tests {
+		_, err := NewBudgetSchedule(tt.epsilonMax, tt.epsilonMin, tt.budget, tt.decay)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestBudgetSchedule_Epsilon(t *testing.T) {
+	assert := assert.New(t)
+
+	linear, err := NewBudgetSchedule(0.5, 0.1, 1000, LinearDecay)
+	assert.Nil(err)
+	cosine, err := NewBudgetSchedule(0.5, 0.1, 1000, CosineDecay)
+	assert.Nil(err)
+
+	tests := []struct {
+		pulls    int
+		consumed float64
+		linear   float64
+		cosine   float64
+	}{
+		{0, 0, 0.5, 0.5},
+		{250, 0.25, 0.4, 0.3 + 0.2*math.Sqrt2/2},
+		{500, 0.5, 0.3, 0.3},
+		{1000, 1, 0.1, 0.1},
+		{2000, 1, 0.1, 0.1},
+	}
+
+	for i, tt := range tests {
+		assert.InDelta(tt.consumed, linear.Consumed(tt.pulls), 1e-9, "should consume the budget for test %d", i+1)
+		assert.InDelta(tt.linear, linear.Epsilon(tt.pulls), 1e-9, "should decay linearly for test %d", i+1)
+		assert.InDelta(tt.cosine, cosine.Epsilon(tt.pulls), 1e-9, "should decay along the cosine for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_BudgetConsumed(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.5, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+	assert.True(math.IsNaN(b.BudgetConsumed()), "should have no budget without a BudgetSchedule")
+
+	b.Schedule, err = NewBudgetSchedule(0.5, 0.1, 100, CosineDecay)
+	assert.Nil(err)
+	previous := b.EffectiveEpsilon()
+	for i := 1; i <= 100; i++ {
+		_, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.InDelta(float64(i)/100, b.BudgetConsumed(), 1e-9)
+		assert.LessOrEqual(b.EffectiveEpsilon(), previous, "should never increase epsilon")
+		previous = b.EffectiveEpsilon()
+	}
+	assert.InDelta(0.1, b.EffectiveEpsilon(), 1e-9, "should end at the floor")
+}
@@ -0,0 +1,56 @@
+package bandit
+
+import "hash/fnv"
+
+// SelectArmForSession selects an arm as a deterministic function of the
+// session salt and the current selection probabilities, e.g. to keep a user
+// on the same arm without storing the assignment. The salt is hashed to a
+// point in range 0 to 1, which lands in the interval of one arm when the
+// probabilities are laid out in index order, so the salts are spread over the
+// arms by their probabilities, and a session keeps its arm while the
+// probabilities barely change. The selection is recorded like with SelectArm.
+func (b *EpsilonGreedy) SelectArmForSession(salt string) (int, error) {
+	b.Lock()
+	d, err := b.selectSession(salt)
+	if err == nil {
+		b.record(d)
+	}
+	logger := b.logger
+	b.Unlock()
+
+	return selected(logger, d, err)
+}
+
+// selectSession selects the arm of the session under the lock
+func (b *EpsilonGreedy) selectSession(salt string) (decision, error) {
+	if !b.initialized() {
+		return decision{}, ErrNotInitialized
+	}
+	if err := b.loadPrior(); err != nil {
+		return decision{}, err
+	}
+	probs, best, err := b.policyProbabilities()
+	if err != nil {
+		return decision{}, err
+	}
+	if b.Smoothing > 0 && len(b.Smoothed) == len(probs) {
+		smoothed := make([]float64, len(probs))
+		b.blendSmoothed(smoothed, probs)
+		probs = smoothed
+	}
+
+	arm := categoricalProb(sessionPoint(salt), probs...)
+	// NOTE: Rounding can leave the point past the last interval, which must
+	// not land on an arm that cannot be selected
+	for arm > 0 && probs[arm] == 0 {
+		arm--
+	}
+	return decision{arm: arm, explored: arm != best, epsilon: b.epsilon(), propensity: probs[arm]}, nil
+}
+
+// sessionPoint hashes the salt to a point in range 0 to 1 exclusive
+func sessionPoint(salt string) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(salt))
+	return float64(splitmix64(h.Sum64())>>11) / (1 << 53)
+}
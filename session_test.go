@@ -0,0 +1,59 @@
+package bandit
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SelectArmForSession(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.3, []int{1, 1, 1}, []float64{0.2, 0.8, 0.5})
+	assert.Nil(err)
+	probs, err := b.Propensities()
+	assert.Nil(err)
+
+	n := 30000
+	counts := make([]int, 3)
+	for i := 0; i < n; i++ {
+		arm, err := b.SelectArmForSession("session-" + strconv.Itoa(i))
+		assert.Nil(err)
+		counts[arm]++
+	}
+	for i, p := range probs {
+		assert.InDelta(p, float64(counts[i])/float64(n), 0.01, "should match the selection probability of arm %d", i)
+	}
+
+	first, err := b.SelectArmForSession("sticky")
+	assert.Nil(err)
+	for i := 0; i < 10; i++ {
+		arm, err := b.SelectArmForSession("sticky")
+		assert.Nil(err)
+		assert.Equal(first, arm, "should keep the session on its arm")
+	}
+	assert.Equal(n+11, b.GetSelectionCount(), "should record the selections")
+
+	assert.Nil(b.Disable(first))
+	arm, err := b.SelectArmForSession("sticky")
+	assert.Nil(err)
+	assert.NotEqual(first, arm, "should move the session off a disabled arm")
+}
+
+func TestEpsilonGreedy_SelectArmForSessionWithSmoothing(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1, 1}, []float64{1.0, 0.5, 0.0})
+	assert.Nil(err)
+	b.Smoothing = 0.1
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+
+	assert.Nil(b.Disable(0))
+	for i := 0; i < 1000; i++ {
+		arm, err := b.SelectArmForSession("session-" + strconv.Itoa(i))
+		assert.Nil(err)
+		assert.NotEqual(0, arm, "should not select the disabled arm")
+	}
+}
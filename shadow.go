@@ -0,0 +1,164 @@
+package bandit
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// ShadowBandit serves the decisions of the Primary bandit, while asking the
+// Shadow bandit what it would have selected, e.g. to compare a candidate
+// policy against production on live traffic. Both bandits learn from every
+// reward, and errors of the shadow never affect the primary.
+type ShadowBandit struct {
+	sync.RWMutex
+	Primary Bandit
+	Shadow  Bandit
+
+	stats  ShadowStats
+	logger *slog.Logger
+}
+
+// ShadowStats represents how the decisions of the shadow compare against the
+// primary, where the counts hold the number of selections of each arm by
+// either bandit
+type ShadowStats struct {
+	Selections    int   `json:"selections"`
+	Agreements    int   `json:"agreements"`
+	ShadowErrors  int   `json:"shadow_errors"`
+	PrimaryCounts []int `json:"primary_counts"`
+	ShadowCounts  []int `json:"shadow_counts"`
+}
+
+// AgreementRate returns the fraction of the selections where the shadow
+// selected the same arm as the primary
+func (s ShadowStats) AgreementRate() float64 {
+	if s.Selections == 0 {
+		return 0
+	}
+	return float64(s.Agreements) / float64(s.Selections)
+}
+
+// Init will initialise both bandits with the provided number of arms, starting
+// the ones that can start over, e.g. EpsilonGreedy, from scratch, and reset the
+// stats
+func (b *ShadowBandit) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if err := resetBandit(b.Primary, nArms); err != nil {
+		return err
+	}
+	if err := resetBandit(b.Shadow, nArms); err != nil {
+		return err
+	}
+	b.stats = ShadowStats{
+		PrimaryCounts: make([]int, nArms),
+		ShadowCounts:  make([]int, nArms),
+	}
+	return nil
+}
+
+// SelectArm returns the arm selected by the primary, and records the arm the
+// shadow would have selected for the same probability
+func (b *ShadowBandit) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	arm, err := b.Primary.SelectArm(probability)
+	if err != nil {
+		b.Unlock()
+		return arm, err
+	}
+	shadowArm, shadowErr := b.Shadow.SelectArm(probability)
+	b.record(arm, shadowArm, shadowErr)
+	logger := b.logger
+	b.Unlock()
+
+	if logger != nil {
+		if shadowErr != nil {
+			logger.Debug("bandit: shadow select arm", "arm", arm, "error", shadowErr)
+		} else {
+			logger.Debug("bandit: shadow select arm", "arm", arm, "shadow_arm", shadowArm, "agreed", arm == shadowArm)
+		}
+	}
+	return arm, nil
+}
+
+func (b *ShadowBandit) record(arm, shadowArm int, shadowErr error) {
+	b.stats.Selections++
+	if arm >= 0 && arm < len(b.stats.PrimaryCounts) {
+		b.stats.PrimaryCounts[arm]++
+	}
+	if shadowErr != nil {
+		b.stats.ShadowErrors++
+		return
+	}
+	if shadowArm >= 0 && shadowArm < len(b.stats.ShadowCounts) {
+		b.stats.ShadowCounts[shadowArm]++
+	}
+	if arm == shadowArm {
+		b.stats.Agreements++
+	}
+}
+
+// Update will update both bandits with some reward value, and returns the
+// error of the primary
+func (b *ShadowBandit) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if err := b.Primary.Update(chosenArm, reward); err != nil {
+		return err
+	}
+	if err := b.Shadow.Update(chosenArm, reward); err != nil {
+		b.stats.ShadowErrors++
+	}
+	return nil
+}
+
+// Stats returns a copy of the comparison stats
+func (b *ShadowBandit) Stats() ShadowStats {
+	b.RLock()
+	defer b.RUnlock()
+
+	stats := b.stats
+	stats.PrimaryCounts = append([]int(nil), b.stats.PrimaryCounts...)
+	stats.ShadowCounts = append([]int(nil), b.stats.ShadowCounts...)
+	return stats
+}
+
+// WithLogger sets the logger that receives a debug record comparing every
+// selection. A nil logger disables logging.
+func (b *ShadowBandit) WithLogger(logger *slog.Logger) *ShadowBandit {
+	b.Lock()
+	defer b.Unlock()
+
+	b.logger = logger
+	return b
+}
+
+// GetCounts returns the counts of the primary
+func (b *ShadowBandit) GetCounts() []int {
+	return b.Primary.GetCounts()
+}
+
+// GetRewards returns the rewards of the primary
+func (b *ShadowBandit) GetRewards() []float64 {
+	return b.Primary.GetRewards()
+}
+
+// NewShadowBandit returns a pointer to the ShadowBandit struct. Both bandits
+// must have the same number of arms.
+func NewShadowBandit(primary, shadow Bandit) (*ShadowBandit, error) {
+	nArms := len(primary.GetCounts())
+	if nArms != len(shadow.GetCounts()) {
+		return nil, ErrArmsMismatch
+	}
+
+	return &ShadowBandit{
+		Primary: primary,
+		Shadow:  shadow,
+		stats: ShadowStats{
+			PrimaryCounts: make([]int, nArms),
+			ShadowCounts:  make([]int, nArms),
+		},
+	}, nil
+}
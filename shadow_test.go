@@ -0,0 +1,71 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShadowBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	primary, _ := NewEpsilonGreedy(0.1, make([]int, 2), make([]float64, 2))
+	shadow, _ := NewUCB(make([]int, 3), make([]float64, 3))
+	_, err := NewShadowBandit(primary, shadow)
+	assert.Equal(ErrArmsMismatch, err, "should throw error for different arms")
+
+	shadow, _ = NewUCB(make([]int, 2), make([]float64, 2))
+	b, err := NewShadowBandit(primary, shadow)
+	assert.Nil(err)
+	assert.Equal(0.0, b.Stats().AgreementRate(), "should not agree before selections")
+}
+
+func TestShadowBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	primary, _ := NewEpsilonGreedy(0.0, nil, nil)
+	shadow, _ := NewUCB(nil, nil)
+	b, err := NewShadowBandit(primary, shadow)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		arm, err := b.SelectArm(rnd.Float64())
+		assert.Nil(err)
+		assert.Equal(0, arm, "should serve the primary, which never explores from zero means")
+		assert.Nil(b.Update(arm, 1.0))
+	}
+
+	assert.Equal([]int{100, 0, 0}, b.GetCounts(), "primary should learn")
+	assert.Equal([]int{100, 0, 0}, shadow.GetCounts(), "shadow should learn from the served arms")
+
+	stats := b.Stats()
+	assert.Equal(100, stats.Selections)
+	assert.Equal([]int{100, 0, 0}, stats.PrimaryCounts)
+	assert.Equal([]int{1, 99, 0}, stats.ShadowCounts, "shadow should keep selecting the arm it never learns about")
+	assert.Equal(1, stats.Agreements, "should agree on the first selection only")
+	assert.Equal(0.01, stats.AgreementRate())
+
+	assert.True(errors.Is(b.Update(5, 1.0), ErrArmsIndexOutOfRange), "should return the error of the primary")
+
+	stats.PrimaryCounts[0] = 0
+	assert.Equal(100, b.Stats().PrimaryCounts[0], "should return a copy of the stats")
+}
+
+func TestShadowBandit_InitResets(t *testing.T) {
+	assert := assert.New(t)
+
+	primary, _ := NewEpsilonGreedy(0.1, nil, nil)
+	shadow, _ := NewEpsilonGreedy(0.1, nil, nil)
+	b, err := NewShadowBandit(primary, shadow)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+	assert.Nil(b.Update(0, 1))
+
+	assert.Nil(b.Init(2))
+	assert.Equal([]int{0, 0}, primary.GetCounts(), "should reset the stats of the primary")
+	assert.Equal([]int{0, 0}, shadow.GetCounts(), "should reset the stats of the shadow")
+}
@@ -0,0 +1,159 @@
+package bandit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// propensityBandit is a bandit that reports the probability of its next
+// selection choosing each arm, e.g. EpsilonGreedy
+type propensityBandit interface {
+	Propensities() ([]float64, error)
+}
+
+// ShiftLimiter wraps a bandit, and limits how fast the share of the
+// selections served to each arm changes, e.g. so that downstream caches and
+// measurements are not confused by the policy jumping. The served shares move
+// toward the selection probabilities of the bandit, or toward its selected arm
+// when it does not report them, by at most MaxChange per Window for any arm,
+// and the served arm is sampled from them.
+type ShiftLimiter struct {
+	sync.RWMutex
+	Bandit    Bandit
+	MaxChange float64
+	Window    time.Duration
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	// Rand is used to sample the served arm, and defaults to the math/rand
+	// source
+	Rand Rand
+
+	served  []float64
+	shifted time.Time
+}
+
+// Init will initialise the bandit with the provided number of arms, starting it
+// from scratch when it can start over, e.g. EpsilonGreedy, and reset the
+// served shares
+func (s *ShiftLimiter) Init(nArms int) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := resetBandit(s.Bandit, nArms); err != nil {
+		return err
+	}
+	s.served = nil
+	return nil
+}
+
+// SelectArm selects an arm with the bandit, moves the served shares toward
+// its policy, and samples the served arm from them
+func (s *ShiftLimiter) SelectArm(probability float64) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var target []float64
+	if b, ok := s.Bandit.(propensityBandit); ok {
+		var err error
+		if target, err = b.Propensities(); err != nil {
+			return -1, err
+		}
+	}
+	arm, err := s.Bandit.SelectArm(probability)
+	if err != nil {
+		return -1, err
+	}
+	if target == nil {
+		target = make([]float64, len(s.Bandit.GetCounts()))
+		if arm < 0 || arm >= len(target) {
+			return arm, nil
+		}
+		target[arm] = 1
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	s.shift(target, now())
+	return categoricalProb(randFloat64(s.Rand), s.served...), nil
+}
+
+// shift moves the served shares toward the target by the change allowed since
+// the last shift, keeping them a probability distribution
+func (s *ShiftLimiter) shift(target []float64, now time.Time) {
+	if len(s.served) != len(target) {
+		s.served = target
+		s.shifted = now
+		return
+	}
+
+	var gap float64
+	for i := range target {
+		gap = math.Max(gap, math.Abs(target[i]-s.served[i]))
+	}
+	if gap == 0 {
+		// NOTE: The allowed change does not build up while the policy holds
+		s.shifted = now
+		return
+	}
+	budget := s.MaxChange * float64(now.Sub(s.shifted)) / float64(s.Window)
+	if budget <= 0 {
+		return
+	}
+
+	// NOTE: Moving every share by the same fraction of its gap keeps the
+	// shares summing to one
+	step := math.Min(budget/gap, 1)
+	for i := range s.served {
+		s.served[i] += step * (target[i] - s.served[i])
+	}
+	s.shifted = now
+}
+
+// ServedShares returns the shares the arms are currently served with
+func (s *ShiftLimiter) ServedShares() []float64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	sCopy := make([]float64, len(s.served))
+	copy(sCopy, s.served)
+	return sCopy
+}
+
+// Update will update the bandit with some reward value
+func (s *ShiftLimiter) Update(chosenArm int, reward float64) error {
+	return s.Bandit.Update(chosenArm, reward)
+}
+
+// GetCounts returns the counts of the bandit
+func (s *ShiftLimiter) GetCounts() []int {
+	return s.Bandit.GetCounts()
+}
+
+// GetRewards returns the rewards of the bandit
+func (s *ShiftLimiter) GetRewards() []float64 {
+	return s.Bandit.GetRewards()
+}
+
+// NewShiftLimiter returns a pointer to the ShiftLimiter struct, moving the
+// share of any arm by at most maxChange, in range 0 to 1, per window of the
+// provided clock. A nil clock defaults to time.Now.
+func NewShiftLimiter(b Bandit, maxChange float64, window time.Duration, now func() time.Time) (*ShiftLimiter, error) {
+	if !(maxChange > 0 && maxChange <= 1) {
+		return nil, ErrInvalidFraction
+	}
+	if window <= 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	return &ShiftLimiter{
+		Bandit:    b,
+		MaxChange: maxChange,
+		Window:    window,
+		Now:       now,
+	}, nil
+}
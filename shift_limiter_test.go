@@ -0,0 +1,107 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// policyBandit always selects arm
+type policyBandit struct {
+	arm   int
+	probs []float64
+	nArms int
+}
+
+func (b *policyBandit) Init(nArms int) error                       { b.nArms = nArms; return nil }
+func (b *policyBandit) SelectArm(probability float64) (int, error) { return b.arm, nil }
+func (b *policyBandit) Update(chosenArm int, reward float64) error { return nil }
+func (b *policyBandit) GetCounts() []int                           { return make([]int, b.nArms) }
+func (b *policyBandit) GetRewards() []float64                      { return make([]float64, b.nArms) }
+
+// propensityPolicyBandit also reports probs as its propensities
+type propensityPolicyBandit struct {
+	policyBandit
+}
+
+func (b *propensityPolicyBandit) Propensities() ([]float64, error) {
+	return append([]float64(nil), b.probs...), nil
+}
+
+func TestNewShiftLimiter(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &policyBandit{}
+	_, err := NewShiftLimiter(b, 0, time.Minute, nil)
+	assert.Equal(ErrInvalidFraction, err)
+	_, err = NewShiftLimiter(b, 1.5, time.Minute, nil)
+	assert.Equal(ErrInvalidFraction, err)
+	_, err = NewShiftLimiter(b, 0.1, 0, nil)
+	assert.Equal(ErrInvalidDuration, err)
+
+	e, _ := NewEpsilonGreedy(0.1, nil, nil)
+	s, err := NewShiftLimiter(e, 0.1, time.Minute, nil)
+	assert.Nil(err)
+	_, err = s.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err, "should throw the errors of the bandit")
+}
+
+func TestShiftLimiter_Ramp(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := &propensityPolicyBandit{policyBandit{probs: []float64{1, 0}}}
+	s, err := NewShiftLimiter(b, 0.1, time.Minute, clock.Now)
+	assert.Nil(err)
+	s.Rand = rand.New(rand.NewSource(1))
+	assert.Nil(s.Init(2))
+
+	arm, err := s.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm)
+	assert.Equal([]float64{1, 0}, s.ServedShares(), "should start at the policy")
+
+	// The policy jumps to the other arm after holding for an hour
+	clock.now = clock.now.Add(time.Hour)
+	_, err = s.SelectArm(0.5)
+	assert.Nil(err)
+	b.probs = []float64{0, 1}
+	_, err = s.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal([]float64{1, 0}, s.ServedShares(), "should not move without time passing")
+
+	for minute := 1; minute <= 10; minute++ {
+		clock.now = clock.now.Add(time.Minute)
+		_, err := s.SelectArm(0.5)
+		assert.Nil(err)
+		shares := s.ServedShares()
+		assert.InDelta(1-0.1*float64(minute), shares[0], 1e-9, "should ramp the share down after %d minutes", minute)
+		assert.InDelta(1, shares[0]+shares[1], 1e-9, "should keep the shares summing to one")
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	arm, err = s.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should serve the policy once caught up")
+	assert.InDeltaSlice([]float64{0, 1}, s.ServedShares(), 1e-9)
+}
+
+func TestShiftLimiter_SelectedArm(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := &policyBandit{arm: 2}
+	s, err := NewShiftLimiter(b, 0.25, time.Minute, clock.Now)
+	assert.Nil(err)
+	assert.Nil(s.Init(3))
+
+	_, err = s.SelectArm(0.5)
+	assert.Nil(err)
+	b.arm = 0
+	clock.now = clock.now.Add(time.Minute)
+	_, err = s.SelectArm(0.5)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.25, 0, 0.75}, s.ServedShares(), 1e-9, "should move toward the selected arm")
+}
@@ -0,0 +1,36 @@
+package bandit
+
+import "math"
+
+// RewardRange returns the range of the rewards accepted by Update, which is
+// any finite reward with SignedRewards, and any non-negative one otherwise
+func (b *EpsilonGreedy) RewardRange() (min, max float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return rewardRange(b.SignedRewards)
+}
+
+// rewardRange returns the range of the rewards accepted with or without
+// signed rewards
+func rewardRange(signed bool) (min, max float64) {
+	if signed {
+		return math.Inf(-1), math.Inf(1)
+	}
+	return 0, math.Inf(1)
+}
+
+// validateReward returns ErrInvalidReward for negative rewards, or for
+// rewards that are not finite when signed
+func validateReward(reward float64, signed bool) error {
+	if !signed {
+		if reward < 0 {
+			return ErrInvalidReward
+		}
+		return nil
+	}
+	if math.IsNaN(reward) || math.IsInf(reward, 0) {
+		return ErrInvalidReward
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SignedRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0, nil, nil)
+	assert.Nil(b.Init(2))
+	assert.Equal(ErrInvalidReward, b.Update(0, -1), "should reject penalties by default")
+	min, max := b.RewardRange()
+	assert.Equal(0.0, min)
+	assert.True(math.IsInf(max, 1))
+
+	b.SignedRewards = true
+	min, _ = b.RewardRange()
+	assert.True(math.IsInf(min, -1), "should accept any finite reward")
+	assert.Equal(ErrInvalidReward, b.Update(0, math.NaN()))
+	assert.Equal(ErrInvalidReward, b.Update(0, math.Inf(-1)))
+
+	for i := 0; i < 5; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0.5))
+	}
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should exploit the good arm")
+
+	for i := 0; i < 5; i++ {
+		assert.Nil(b.Update(0, -2))
+	}
+	assert.InDelta(-0.5, b.GetRewards()[0], 1e-9, "should decrease the mean with the penalties")
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should exploit the rival once penalized")
+}
+
+func TestUCB_SignedRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewUCB(nil, nil)
+	assert.Nil(b.Init(2))
+	assert.Equal(ErrInvalidReward, b.Update(0, -1), "should reject penalties by default")
+
+	b.SignedRewards = true
+	for i := 0; i < 5; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0.5))
+	}
+	assert.Equal([]float64{1, 0}, b.SelectionProbabilities(), "should select the good arm")
+
+	for i := 0; i < 5; i++ {
+		assert.Nil(b.Update(0, -2))
+	}
+	min, max := b.RewardRange()
+	assert.Equal(-2.0, min, "should offset by the lowest reward observed")
+	assert.Equal(1.0, max)
+	assert.Equal([]float64{0, 1}, b.SelectionProbabilities(), "should select the rival once penalized")
+}
+
+func TestSoftmax_SignedRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewSoftmax(1, []int{10, 10}, []float64{-1000, -999})
+	b.SignedRewards = true
+	arm, err := b.SelectArm(0.2)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should offset the very negative means")
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm)
+
+	assert.Nil(b.Update(1, -1100))
+	assert.Less(b.GetRewards()[1], b.GetRewards()[0], "should drive the arm below the rival")
+	min, _ := b.RewardRange()
+	assert.True(math.IsInf(min, -1))
+}
@@ -0,0 +1,126 @@
+package bandit
+
+import (
+	"math"
+	"slices"
+)
+
+// similarityTolerance is the largest difference allowed between the
+// similarities of a pair of arms in either order
+const similarityTolerance = 1e-6
+
+// SetSimilarityMatrix sets the similarity between each pair of arms, in range
+// 0 to 1, e.g. from the features the creatives share. Every reward then also
+// updates the other arms with a weight of their similarity to the rewarded
+// arm, without counting a pull, so that related arms learn from each other.
+// The matrix must be square, sized to the arms and symmetric up to rounding,
+// and its diagonal is ignored. Passing nil removes the similarities.
+func (b *EpsilonGreedy) SetSimilarityMatrix(similarity [][]float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if similarity == nil {
+		b.SimilarityMatrix = nil
+		return nil
+	}
+	if len(similarity) != len(b.Rewards) {
+		return ErrInvalidLength
+	}
+	for _, row := range similarity {
+		if len(row) != len(b.Rewards) {
+			return ErrInvalidLength
+		}
+	}
+	for i, row := range similarity {
+		for j, s := range row {
+			if !(s >= 0 && s <= 1) || math.Abs(s-similarity[j][i]) > similarityTolerance {
+				return ErrInvalidSimilarity
+			}
+		}
+	}
+
+	b.SimilarityMatrix = cloneMatrix(similarity)
+	return nil
+}
+
+// shareReward applies the reward of an arm to the arms similar to it, each
+// weighted by its similarity times the weight of the reward
+func (b *EpsilonGreedy) shareReward(arm int, reward, weight float64) {
+	if len(b.SimilarityMatrix) != len(b.Rewards) {
+		return
+	}
+
+	b.ensureWeights(-1)
+	for i, s := range b.SimilarityMatrix[arm] {
+		if i == arm || s == 0 || b.canonical(i) != i {
+			continue
+		}
+		w := s * weight
+		old := b.Rewards[i]
+		b.Rewards[i] += w / b.addWeight(i, w) * (reward - old)
+		b.M2[i] += w * (reward - old) * (reward - b.Rewards[i])
+		b.fixBest(i)
+	}
+}
+
+// cloneMatrix returns a deep copy of the matrix
+func cloneMatrix(matrix [][]float64) [][]float64 {
+	if matrix == nil {
+		return nil
+	}
+	cloned := make([][]float64, len(matrix))
+	for i, row := range matrix {
+		cloned[i] = slices.Clone(row)
+	}
+	return cloned
+}
+
+// addSimilarArm appends an arm similar to no other arm, unless the matrix is
+// missing
+func addSimilarArm(matrix *[][]float64, nArms int) {
+	if len(*matrix) != nArms {
+		return
+	}
+	for i := range *matrix {
+		(*matrix)[i] = append((*matrix)[i], 0)
+	}
+	*matrix = append(*matrix, make([]float64, nArms+1))
+}
+
+// deleteSimilarArm deletes the row and column of the arm, unless the matrix is
+// missing
+func deleteSimilarArm(matrix *[][]float64, nArms, index int) {
+	if len(*matrix) != nArms {
+		return
+	}
+	*matrix = slices.Delete(*matrix, index, index+1)
+	for i := range *matrix {
+		(*matrix)[i] = slices.Delete((*matrix)[i], index, index+1)
+	}
+}
+
+// remapSimilarity reshapes the matrix with the mapping of Remap, where fresh
+// arms are similar to no other arm, and the copies of an arm to each other,
+// unless the matrix is missing
+func remapSimilarity(matrix *[][]float64, nArms int, mapping []int) {
+	if len(*matrix) != nArms {
+		return
+	}
+	remapped := make([][]float64, len(mapping))
+	for i, from := range mapping {
+		remapped[i] = make([]float64, len(mapping))
+		if from < 0 {
+			continue
+		}
+		for j, to := range mapping {
+			switch {
+			case to < 0:
+			case from == to:
+				remapped[i][j] = 1
+			default:
+				remapped[i][j] = (*matrix)[from][to]
+			}
+		}
+	}
+	*matrix = remapped
+}
@@ -0,0 +1,60 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SetSimilarityMatrix(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+
+	tests := []struct {
+		similarity [][]float64
+		err        error
+	}{
+		{[][]float64{{1, 0.5}, {0.5, 1}}, nil},
+		{[][]float64{{1, 0.5}, {0.5 + 1e-9, 1}}, nil},
+		{nil, nil},
+		{[][]float64{{1, 0.5}}, ErrInvalidLength},
+		{[][]float64{{1, 0.5}, {0.5}}, ErrInvalidLength},
+		{[][]float64{{1, 0.5}, {0.2, 1}}, ErrInvalidSimilarity},
+		{[][]float64{{1, 1.5}, {1.5, 1}}, ErrInvalidSimilarity},
+		{[][]float64{{1, math.NaN()}, {math.NaN(), 1}}, ErrInvalidSimilarity},
+	}
+
+	for i, tt := range tests {
+		assert.Equal(tt.err, b.SetSimilarityMatrix(tt.similarity), "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_SimilarityMatrix(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.Update(1, 0))
+	assert.Nil(b.SetSimilarityMatrix([][]float64{
+		{1, 0.9, 0},
+		{0.9, 1, 0},
+		{0, 0, 1},
+	}))
+
+	assert.Nil(b.Update(0, 1))
+	rewards := b.GetRewards()
+	assert.Equal(1.0, rewards[0])
+	assert.InDelta(0.9/1.9, rewards[1], 1e-9, "should move the similar arm toward the reward")
+	assert.Equal(0.0, rewards[2], "should not move a dissimilar arm")
+	assert.Equal([]int{1, 1, 0}, b.GetCounts(), "should not count a pull for the similar arm")
+	assert.Equal([]float64{1, 1.9, 0}, b.Weights, "should weigh the shared reward by the similarity")
+
+	index, err := b.AddArm()
+	assert.Nil(err)
+	assert.Equal([]float64{0, 0, 0, 0}, b.SimilarityMatrix[index], "should add a dissimilar arm")
+	assert.Nil(b.RemoveArm(0))
+	assert.Equal([][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 0}}, b.SimilarityMatrix, "should remove the row and column")
+}
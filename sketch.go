@@ -0,0 +1,181 @@
+package bandit
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// SketchBandit represents an epsilon greedy algorithm over string-keyed arms,
+// which keeps the counts and reward sums of the arms in count-min sketches of
+// Depth rows of Width cells, so the memory is bounded however many arms there
+// are.
+//
+// The estimates never fall below the true values. With a width of e/ε and a
+// depth of ln(1/δ), see SketchDimensions, the count of an arm exceeds its true
+// count by at most ε times the total count with probability 1-δ, and likewise
+// for the reward sum. The mean of an arm is the ratio of its estimates, and
+// is biased where colliding arms differ in mean.
+type SketchBandit struct {
+	sync.RWMutex
+	Epsilon float64
+	Width   int
+	Depth   int
+
+	// Rand is used for exploration, and defaults to the math/rand source
+	Rand Rand
+
+	counts [][]int
+	sums   [][]float64
+	total  int
+}
+
+// SketchDimensions returns the width and depth of a sketch that overestimates
+// by at most errorRate times the total with the probability 1-failure
+func SketchDimensions(errorRate, failure float64) (width, depth int) {
+	width = int(math.Ceil(math.E / errorRate))
+	depth = int(math.Ceil(math.Log(1 / failure)))
+	return
+}
+
+// ensureRows returns ErrInvalidSize for a Width or Depth below one, and
+// otherwise allocates the rows when they do not match them, e.g. on the first
+// use of a struct literal, starting the sketch over
+func (b *SketchBandit) ensureRows() error {
+	if b.Width < 1 || b.Depth < 1 {
+		return ErrInvalidSize
+	}
+	if b.hasRows() {
+		return nil
+	}
+	b.counts = make([][]int, b.Depth)
+	b.sums = make([][]float64, b.Depth)
+	for i := range b.counts {
+		b.counts[i] = make([]int, b.Width)
+		b.sums[i] = make([]float64, b.Width)
+	}
+	b.total = 0
+	return nil
+}
+
+// hasRows returns whether the rows match the Width and Depth
+func (b *SketchBandit) hasRows() bool {
+	return b.Width > 0 && b.Depth > 0 && len(b.counts) == b.Depth && len(b.counts[0]) == b.Width
+}
+
+// cells returns the column of the key in each row, using double hashing
+func (b *SketchBandit) cells(key string) []int {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	h1, h2 := sum&math.MaxUint32, sum>>32|1
+
+	columns := make([]int, b.Depth)
+	for i := range columns {
+		columns[i] = int((h1 + uint64(i)*h2) % uint64(b.Width))
+	}
+	return columns
+}
+
+// SelectKey chooses among the keys, exploiting the key with the highest
+// estimated mean if the probability is more than the epsilon threshold, and
+// exploring a random key otherwise. It takes the write lock, since Rand is
+// not safe for concurrent use.
+func (b *SketchBandit) SelectKey(keys []string, probability float64) (string, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(keys) == 0 {
+		return "", ErrInvalidArms
+	}
+	if err := b.ensureRows(); err != nil {
+		return "", err
+	}
+
+	// Explore
+	if b.Epsilon > 0 && probability <= b.Epsilon {
+		return keys[randIntn(b.Rand, len(keys))], nil
+	}
+
+	// Exploit
+	means := make([]float64, len(keys))
+	for i, key := range keys {
+		_, means[i] = b.estimate(key)
+	}
+	return keys[max(means...)], nil
+}
+
+// UpdateKey will update the arm of the key with some finite, non-negative
+// reward value, e.g. click = 1, no click = 0
+func (b *SketchBandit) UpdateKey(key string, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if !(reward >= 0) || math.IsInf(reward, 1) {
+		return ErrInvalidReward
+	}
+	if err := b.ensureRows(); err != nil {
+		return err
+	}
+
+	for row, column := range b.cells(key) {
+		b.counts[row][column]++
+		b.sums[row][column] += reward
+	}
+	b.total++
+	return nil
+}
+
+// Estimate returns the estimated count and mean reward of the arm of the key,
+// which are zero before the first update
+func (b *SketchBandit) Estimate(key string) (count int, mean float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.estimate(key)
+}
+
+func (b *SketchBandit) estimate(key string) (count int, mean float64) {
+	if !b.hasRows() {
+		return 0, 0
+	}
+	count = math.MaxInt
+	sum := math.Inf(1)
+	for row, column := range b.cells(key) {
+		if c := b.counts[row][column]; c < count {
+			count = c
+		}
+		sum = math.Min(sum, b.sums[row][column])
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return count, sum / float64(count)
+}
+
+// Total returns the number of updates, which scales the error bound of the
+// estimates
+func (b *SketchBandit) Total() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.total
+}
+
+// NewSketchBandit returns a pointer to the SketchBandit struct
+func NewSketchBandit(epsilon float64, width, depth int) (*SketchBandit, error) {
+	if epsilon < 0 || epsilon > 1 {
+		return nil, ErrInvalidEpsilon
+	}
+	if width < 1 || depth < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	b := &SketchBandit{
+		Epsilon: epsilon,
+		Width:   width,
+		Depth:   depth,
+	}
+	_ = b.ensureRows()
+	return b, nil
+}
@@ -0,0 +1,167 @@
+package bandit
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSketchBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		epsilon float64
+		width   int
+		depth   int
+		err     error
+	}{
+		{0.1, 100, 5, nil},
+		{-0.1, 100, 5, ErrInvalidEpsilon},
+		{1.1, 100, 5, ErrInvalidEpsilon},
+		{0.1, 0, 5, ErrInvalidSize},
+		{0.1, 100, 0, ErrInvalidSize},
+	}
+
+	for _, tt := range tests {
+		b, err := NewSketchBandit(tt.epsilon, tt.width, tt.depth)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.width, b.Width, "width should be equal")
+			assert.Equal(tt.depth, b.Depth, "depth should be equal")
+		}
+	}
+}
+
+func TestSketchDimensions(t *testing.T) {
+	assert := assert.New(t)
+
+	width, depth := SketchDimensions(0.01, 0.01)
+	assert.Equal(272, width)
+	assert.Equal(5, depth)
+}
+
+func TestSketchBandit_Estimate(t *testing.T) {
+	assert := assert.New(t)
+
+	errorRate, failure := 0.01, 0.01
+	width, depth := SketchDimensions(errorRate, failure)
+	b, err := NewSketchBandit(0.1, width, depth)
+	assert.Nil(err)
+
+	nKeys := 2000
+	for i := 0; i < nKeys; i++ {
+		for j := 0; j <= i%10; j++ {
+			assert.Nil(b.UpdateKey(fmt.Sprintf("arm-%d", i), 1.0))
+		}
+	}
+	assert.Equal(ErrInvalidReward, b.UpdateKey("arm-0", -1.0), "should reject negative rewards")
+
+	bound := errorRate * float64(b.Total())
+	var exceeded int
+	for i := 0; i < nKeys; i++ {
+		count, mean := b.Estimate(fmt.Sprintf("arm-%d", i))
+		actual := i%10 + 1
+		assert.GreaterOrEqual(count, actual, "should never underestimate")
+		assert.Equal(1.0, mean, "mean should be exact for equal rewards")
+		if float64(count-actual) > bound {
+			exceeded++
+		}
+	}
+	assert.LessOrEqual(float64(exceeded), failure*float64(nKeys), "should stay within the error bound")
+
+	count, mean := b.Estimate("unknown")
+	assert.LessOrEqual(float64(count), bound)
+	assert.True(count == 0 || mean == 1.0)
+}
+
+func TestSketchBandit_SelectKey(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewSketchBandit(0.5, 272, 5)
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	_, err = b.SelectKey(nil, 1.0)
+	assert.Equal(ErrInvalidArms, err)
+
+	keys := []string{"a", "b", "c"}
+	for i, key := range keys {
+		for j := 0; j < 10; j++ {
+			assert.Nil(b.UpdateKey(key, float64(i%2)))
+		}
+	}
+
+	key, err := b.SelectKey(keys, 0.9)
+	assert.Nil(err)
+	assert.Equal("b", key, "should exploit the best key")
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		key, err := b.SelectKey(keys, 0.1)
+		assert.Nil(err)
+		seen[key] = true
+	}
+	assert.Equal(3, len(seen), "should explore every key")
+}
+
+func TestSketchBandit_SelectKeyConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewSketchBandit(0.5, 272, 5)
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	keys := []string{"a", "b", "c"}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, err := b.SelectKey(keys, 0.1)
+				assert.Nil(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSketchBandit_ZeroValue(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &SketchBandit{Epsilon: 0.1, Width: 16, Depth: 3}
+	count, mean := b.Estimate("a")
+	assert.Equal(0, count)
+	assert.Equal(0.0, mean)
+	assert.Nil(b.UpdateKey("a", 1), "should allocate the rows on first use")
+	count, _ = b.Estimate("a")
+	assert.Equal(1, count)
+	key, err := b.SelectKey([]string{"a", "b"}, 0.9)
+	assert.Nil(err)
+	assert.Equal("a", key)
+
+	for _, b := range []*SketchBandit{{Width: 0, Depth: 3}, {Width: 16, Depth: 0}, {}} {
+		assert.Equal(ErrInvalidSize, b.UpdateKey("a", 1), "should reject a dimension below one")
+		_, err := b.SelectKey([]string{"a"}, 0.9)
+		assert.Equal(ErrInvalidSize, err)
+		count, _ := b.Estimate("a")
+		assert.Equal(0, count)
+	}
+}
+
+func TestSketchBandit_UpdateKeyInvalidReward(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewSketchBandit(0.1, 16, 3)
+	assert.Nil(err)
+	for _, reward := range []float64{-1, math.NaN(), math.Inf(1)} {
+		assert.Equal(ErrInvalidReward, b.UpdateKey("a", reward))
+	}
+	assert.Equal(0, b.Total())
+}
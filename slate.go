@@ -0,0 +1,75 @@
+package bandit
+
+import "sort"
+
+// SelectSlate chooses n distinct arms for a multi-slot page. Each slot
+// explores with probability epsilon by taking a random arm not yet in the
+// slate, and otherwise exploits the best arm not yet in the slate, ranked by
+// the score SelectArm exploits, so with the Costs, Laplace smoothing,
+// ExploitBonus and TieBreak. The slate is capped at the number of eligible
+// arms, and explored is the number of slots filled by exploration.
+func (b *EpsilonGreedy) SelectSlate(n int) (slate []int, explored int, err error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.initialized() {
+		return nil, 0, ErrNotInitialized
+	}
+	if n < 1 {
+		return nil, 0, ErrInvalidSize
+	}
+	eligible := b.eligibleArms()
+	if len(eligible) == 0 {
+		return nil, 0, ErrNoEligibleArms
+	}
+	if n > len(eligible) {
+		n = len(eligible)
+	}
+
+	// Rank the arms by score, which puts the unplayed arms without a bonus
+	// last, and the ties by index or by pulls like bestArm
+	rewards := b.exploitRewards()
+	scores := make([]float64, len(b.Rewards))
+	for _, i := range eligible {
+		scores[i] = b.score(rewards, i)
+	}
+	ranked := make([]int, len(eligible))
+	copy(ranked, eligible)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, c := ranked[i], ranked[j]
+		if scores[a] != scores[c] {
+			return scores[a] > scores[c]
+		}
+		return b.TieBreak == TieMostPulled && b.Counts[a] > b.Counts[c]
+	})
+
+	epsilon := b.epsilon()
+	slate = make([]int, 0, n)
+	for len(slate) < n {
+		// ranked only holds the arms not yet in the slate
+		k := 0
+		if epsilon > 0 && b.float64() < epsilon {
+			k = b.intn(len(ranked))
+			explored++
+		} else if b.TieBreak == TieRandom {
+			k = b.intn(tiedLead(ranked, scores))
+		}
+		slate = append(slate, ranked[k])
+		ranked = append(ranked[:k], ranked[k+1:]...)
+	}
+
+	for _, arm := range slate {
+		b.startCooldown(arm)
+	}
+	b.SelectionCount += len(slate)
+	return slate, explored, nil
+}
+
+// tiedLead returns the number of ranked arms tied with the first one
+func tiedLead(ranked []int, scores []float64) int {
+	n := 1
+	for n < len(ranked) && scores[ranked[n]] == scores[ranked[0]] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SelectSlate(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1, 1, 1, 0}, []float64{0.2, 0.9, 0.5, 0.7, 0.0})
+	assert.Nil(err)
+
+	tests := []struct {
+		n        int
+		expected []int
+		err      error
+	}{
+		{0, nil, ErrInvalidSize},
+		{1, []int{1}, nil},
+		{3, []int{1, 3, 2}, nil},
+		{5, []int{1, 3, 2, 0, 4}, nil},
+		{10, []int{1, 3, 2, 0, 4}, nil},
+	}
+
+	for i, tt := range tests {
+		slate, explored, err := b.SelectSlate(tt.n)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+		assert.Equal(tt.expected, slate, "should rank the arms by mean for test %d", i+1)
+		assert.Equal(0, explored, "should not explore for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_SelectSlateIsDistinct(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.5, nil, nil)
+	assert.Nil(err)
+
+	err = b.Init(10)
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+	assert.Nil(b.Disable(9))
+
+	var explored int
+	for i := 0; i < 100; i++ {
+		slate, e, err := b.SelectSlate(4)
+		assert.Nil(err)
+		assert.Equal(4, len(slate), "should fill every slot")
+		explored += e
+
+		seen := make(map[int]bool)
+		for _, arm := range slate {
+			assert.False(seen[arm], "should not repeat arm %d", arm)
+			assert.NotEqual(9, arm, "should not select a disabled arm")
+			seen[arm] = true
+		}
+		for j, arm := range slate {
+			assert.Nil(b.Update(arm, float64(j%2)))
+		}
+	}
+	assert.InDelta(200, explored, 40, "should explore about epsilon of the slots")
+	assert.Equal(400, b.GetSelectionCount(), "should count every slot as a selection")
+}
+
+func TestEpsilonGreedy_SelectSlateExploitScore(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name     string
+		setup    func(b *EpsilonGreedy)
+		expected []int
+	}{
+		{"costs", func(b *EpsilonGreedy) { assert.Nil(b.SetCosts([]float64{1, 4, 1, 1})) }, []int{2, 0, 1, 3}},
+		{"exploit bonus", func(b *EpsilonGreedy) { b.ExploitBonus = 1 }, []int{3, 1, 0, 2}},
+		{"tie most pulled", func(b *EpsilonGreedy) {
+			b.TieBreak = TieMostPulled
+			assert.Nil(b.SetRewards([]float64{0.5, 0.9, 0.9, 0}))
+		}, []int{2, 1, 0, 3}},
+	}
+
+	for _, tt := range tests {
+		b, err := NewEpsilonGreedy(0.0, []int{4, 4, 8, 0}, []float64{0.5, 0.9, 0.6, 0})
+		assert.Nil(err)
+		tt.setup(b)
+
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		slate, _, err := b.SelectSlate(4)
+		assert.Nil(err)
+		assert.Equal(tt.expected, slate, "should rank the arms by the exploit score with %s", tt.name)
+		assert.Equal(arm, slate[0], "should exploit the arm of SelectArm first with %s", tt.name)
+	}
+}
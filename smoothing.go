@@ -0,0 +1,77 @@
+package bandit
+
+// SmoothedProbabilities returns the smoothed selection probabilities of each
+// arm as of the last selection round
+func (b *EpsilonGreedy) SmoothedProbabilities() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Smoothed))
+	copy(sCopy, b.Smoothed)
+	return sCopy
+}
+
+// selectSmoothed moves the smoothed probabilities towards the current policy
+// and samples an arm from them, where probability is uniform in the range of
+// 0 to 1
+func (b *EpsilonGreedy) selectSmoothed(probability float64) (decision, error) {
+	probs, best, err := b.policyProbabilities()
+	if err != nil {
+		return decision{}, err
+	}
+
+	if len(b.Smoothed) != len(probs) {
+		b.Smoothed = probs
+	} else {
+		b.blendSmoothed(b.Smoothed, probs)
+	}
+
+	arm := categoricalProb(probability, b.Smoothed...)
+	return decision{arm: arm, explored: arm != best, epsilon: b.epsilon(), propensity: b.Smoothed[arm]}, nil
+}
+
+// blendSmoothed writes the smoothed probabilities moved towards the policy
+// probabilities into dst, which may be the smoothed probabilities themselves.
+// The arms that are disabled, cooling down or aliased since the last round
+// are dropped and the others renormalised, falling back to the policy when
+// none of them has any left.
+func (b *EpsilonGreedy) blendSmoothed(dst, probs []float64) {
+	eligible := b.eligibleArms()
+	total := 0.0
+	next := 0
+	for i, p := range probs {
+		// NOTE: The eligible arms are in index order
+		if next < len(eligible) && eligible[next] == i {
+			dst[i] = b.Smoothing*p + (1-b.Smoothing)*b.Smoothed[i]
+			total += dst[i]
+			next++
+		} else {
+			dst[i] = 0
+		}
+	}
+	if !(total > 0) {
+		copy(dst, probs)
+		return
+	}
+	for i := range dst {
+		dst[i] /= total
+	}
+}
+
+// policyProbabilities returns the probability of the unsmoothed policy
+// selecting each arm, and the arm it exploits
+func (b *EpsilonGreedy) policyProbabilities() ([]float64, int, error) {
+	enabled := b.eligibleArms()
+	if len(enabled) == 0 {
+		return nil, -1, ErrNoEligibleArms
+	}
+
+	epsilon := b.epsilon()
+	probs := make([]float64, len(b.Rewards))
+	best := b.bestArm(enabled)
+	ties := b.ties(best, enabled)
+	for _, i := range enabled {
+		probs[i] = propensity(exploitShare(i, best, ties), b.exploreShare(i, enabled), epsilon)
+	}
+	return probs, best, nil
+}
@@ -0,0 +1,84 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SelectArmWithSmoothing(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1}, []float64{1.0, 0.0})
+	assert.Nil(err)
+	b.Smoothing = 0.5
+
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should select the best arm")
+	assert.Equal([]float64{1.0, 0.0}, b.SmoothedProbabilities(), "should start from the policy")
+
+	// Abruptly switch the best arm
+	b.Rewards = []float64{0.0, 1.0}
+
+	tests := []struct {
+		expected []float64
+	}{
+		{[]float64{0.5, 0.5}},
+		{[]float64{0.25, 0.75}},
+		{[]float64{0.125, 0.875}},
+	}
+
+	for i, tt := range tests {
+		_, err = b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.InDeltaSlice(tt.expected, b.SmoothedProbabilities(), 1e-9, "should shift gradually for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_SelectArmWithSmoothingAndDisabledArms(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.5, []int{1, 1, 1}, []float64{1.0, 0.0, 0.0})
+	assert.Nil(err)
+	b.Smoothing = 1.0
+
+	assert.Nil(b.Disable(0))
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.0, 0.75, 0.25}, b.SmoothedProbabilities(), 1e-9, "should exclude disabled arms")
+
+	assert.Nil(b.Disable(1))
+	assert.Nil(b.Disable(2))
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrNoEligibleArms, err, "should throw error when all arms are disabled")
+}
+
+func TestEpsilonGreedy_SmoothingDropsIneligibleArms(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.0, []int{1, 1, 1}, []float64{1.0, 0.5, 0.0})
+	assert.Nil(err)
+	b.Smoothing = 0.1
+
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal([]float64{1.0, 0.0, 0.0}, b.SmoothedProbabilities())
+
+	assert.Nil(b.Disable(0))
+	for i := 0; i < 100; i++ {
+		arm, err := b.SelectArm(float64(i) / 100)
+		assert.Nil(err)
+		assert.NotEqual(0, arm, "should not select the disabled arm")
+	}
+	probs := b.SmoothedProbabilities()
+	assert.Equal(0.0, probs[0], "should zero the disabled arm")
+	assert.InDelta(1.0, probs[0]+probs[1]+probs[2], 1e-9, "should renormalise the eligible arms")
+
+	b.Cooldown = 1
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	next, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.NotEqual(arm, next, "should not select the cooling arm")
+}
@@ -11,6 +11,18 @@ type Softmax struct {
 	Temperature float64
 	Counts      []int
 	Rewards     []float64
+
+	// Guard, when set, warns about rewards outside the expected range
+	Guard *RewardGuard
+
+	// SignedRewards accepts negative rewards, e.g. penalties. The
+	// probabilities are offset by the highest mean, which leaves them
+	// unchanged, so that very negative means do not underflow.
+	SignedRewards bool
+
+	// Tuner, when set, replaces the Temperature after every update with one
+	// tuned from the trend of the rewards
+	Tuner *TemperatureTuner
 }
 
 // Init will initialise the counts and rewards with the provided number of arms
@@ -28,11 +40,17 @@ func (b *Softmax) Init(nArms int) error {
 
 // SelectArm chooses an arm that exploits if the value is more than the epsilon
 // threshold, and explore if the value is less than epsilon
-func (b *Softmax) SelectArm(probability float64) int {
+func (b *Softmax) SelectArm(probability float64) (int, error) {
 	b.RLock()
 	defer b.RUnlock()
 
 	nArms := len(b.Rewards)
+	if nArms == 1 {
+		return 0, nil
+	}
+	if b.SignedRewards {
+		return categoricalProb(probability, softmax(b.Rewards, b.Temperature)...), nil
+	}
 	var z float64
 	for i := 0; i < nArms; i++ {
 		reward := b.Rewards[i]
@@ -44,7 +62,7 @@ func (b *Softmax) SelectArm(probability float64) int {
 		reward := b.Rewards[i]
 		probs[i] = math.Exp(reward/b.Temperature) / z
 	}
-	return categoricalProb(probability, probs...)
+	return categoricalProb(probability, probs...), nil
 }
 
 // GetCounts returns the counts
@@ -67,18 +85,50 @@ func (b *Softmax) GetRewards() []float64 {
 	return sCopy
 }
 
+// CopyCounts copies the counts into dst without allocating, and returns the
+// number of arms copied
+func (b *Softmax) CopyCounts(dst []int) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Counts) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Counts), nil
+}
+
+// CopyRewards copies the rewards into dst without allocating, and returns the
+// number of arms copied
+func (b *Softmax) CopyRewards(dst []float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Rewards) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Rewards), nil
+}
+
 // Update will update an arm with some reward value,
 // e.g. click = 1, no click = 0
 func (b *Softmax) Update(chosenArm int, reward float64) error {
 	b.Lock()
-	defer b.Unlock()
+	warn, err := b.update(chosenArm, reward)
+	b.Unlock()
+
+	if warn != nil {
+		warn()
+	}
+	return err
+}
 
+func (b *Softmax) update(chosenArm int, reward float64) (func(), error) {
 	// NOTE: Lock is required is when reading the len
 	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
-		return ErrArmsIndexOutOfRange
+		return nil, &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
 	}
-	if reward < 0 {
-		return ErrInvalidReward
+	if err := validateReward(reward, b.SignedRewards); err != nil {
+		return nil, err
 	}
 
 	b.Counts[chosenArm]++
@@ -87,7 +137,35 @@ func (b *Softmax) Update(chosenArm int, reward float64) error {
 	oldRewards := b.Rewards[chosenArm]
 	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
 
-	return nil
+	if b.Tuner != nil {
+		b.Temperature = b.Tuner.observe(reward)
+	}
+	return b.Guard.observe(chosenArm, reward), nil
+}
+
+// RewardRange returns the range of the rewards accepted by Update
+func (b *Softmax) RewardRange() (min, max float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return rewardRange(b.SignedRewards)
+}
+
+// GetTemperature returns the temperature currently in use
+func (b *Softmax) GetTemperature() float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Temperature
+}
+
+// ObservedRewardRange returns the smallest and largest reward observed by the
+// guard, or an empty range of +Inf to -Inf without a guard or observations
+func (b *Softmax) ObservedRewardRange() (min, max float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Guard.observedRange()
 }
 
 // NewSoftmax returns a pointer to the Softmax struct
@@ -1,6 +1,7 @@
 package bandit
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,7 +69,8 @@ func TestSoftmax_SelectArm(t *testing.T) {
 	assert.Nil(err)
 	b.Init(3)
 
-	arm := b.SelectArm(0.1)
+	arm, err := b.SelectArm(0.1)
+	assert.Nil(err)
 	assert.Equal(arm, 0, "should select the unplayed arm")
 	b.Update(arm, 1.0)
 }
@@ -124,9 +126,74 @@ func TestSoftmax_UpdateArmWithInvalidParams(t *testing.T) {
 
 		err = b.Update(tt.chosenArm, tt.reward)
 		if tt.err != nil {
-			assert.Equal(tt.err, err, "should throw the correct error")
+			assert.True(errors.Is(err, tt.err), "should throw the correct error")
 		} else {
 			assert.Nil(err)
 		}
 	}
 }
+
+func TestNewTemperatureTuner(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		min, max    float64
+		short, long int
+		err         error
+	}{
+		{0.01, 1, 5, 50, nil},
+		{0.5, 0.5, 1, 2, nil},
+		{0, 1, 5, 50, ErrInvalidTemperature},
+		{1, 0.5, 5, 50, ErrInvalidTemperature},
+		{0.01, 1, 0, 50, ErrInvalidSize},
+		{0.01, 1, 50, 50, ErrInvalidSize},
+	}
+	for _, tt := range tests {
+		_, err := NewTemperatureTuner(tt.min, tt.max, tt.short, tt.long)
+		assert.Equal(tt.err, err)
+	}
+}
+
+func TestSoftmax_Tuner(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewSoftmax(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	tuner, err := NewTemperatureTuner(0.01, 1, 10, 50)
+	assert.Nil(err)
+	b.Tuner = tuner
+
+	assert.Nil(b.Update(0, 0.1))
+	assert.Equal(1.0, b.GetTemperature(), "should explore until the long window fills")
+
+	// The rewards climb, then plateau
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(0, float64(i)/100))
+	}
+	climbing := b.GetTemperature()
+	assert.Greater(climbing, 0.2, "should keep exploring while rewards climb")
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(0, 1.0))
+	}
+	assert.Less(b.GetTemperature(), climbing, "should cool down once rewards plateau")
+	assert.InDelta(0.01, b.GetTemperature(), 1e-12)
+}
+
+func TestSoftmax_TunerInvalidWindows(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, tuner := range []*TemperatureTuner{
+		{MinTemperature: 0.01, MaxTemperature: 1, ShortWindow: 1},
+		{MinTemperature: 0.01, MaxTemperature: 1, ShortWindow: 5, LongWindow: 5},
+		{MinTemperature: 0.01, MaxTemperature: 1, LongWindow: 5},
+	} {
+		b, _ := NewSoftmax(0.1, nil, nil)
+		assert.Nil(b.Init(2))
+		b.Tuner = tuner
+		for i := 0; i < 20; i++ {
+			assert.Nil(b.Update(0, 1.0))
+		}
+		assert.Equal(1.0, b.GetTemperature(), "should keep the max temperature with invalid windows")
+	}
+}
@@ -0,0 +1,24 @@
+package bandit
+
+// ArmsByMean is a snapshot of the arms implementing sort.Interface, ordering
+// them by mean from the lowest to the highest, and by index among ties, e.g.
+// sort.Sort(sort.Reverse(b.ArmsByMean())) to rank the arms
+type ArmsByMean []ArmMetrics
+
+func (a ArmsByMean) Len() int      { return len(a) }
+func (a ArmsByMean) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a ArmsByMean) Less(i, j int) bool {
+	if a[i].Mean != a[j].Mean {
+		return a[i].Mean < a[j].Mean
+	}
+	return a[i].Index < a[j].Index
+}
+
+// ArmsByMean returns a consistent snapshot of the arm stats to sort, which is
+// not affected by later updates
+func (b *EpsilonGreedy) ArmsByMean() ArmsByMean {
+	b.RLock()
+	defer b.RUnlock()
+
+	return ArmsByMean(b.metrics().Arms)
+}
@@ -0,0 +1,35 @@
+package bandit
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArmsByMean(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, []int{1, 2, 3, 4}, []float64{0.5, 0.2, 0.9, 0.2})
+	assert.Nil(err)
+
+	arms := b.ArmsByMean()
+	sort.Sort(arms)
+	indices := make([]int, len(arms))
+	for i, arm := range arms {
+		indices[i] = arm.Index
+	}
+	assert.Equal([]int{1, 3, 0, 2}, indices, "should sort the arms by mean")
+	assert.Equal(0.9, arms[3].Mean)
+	assert.Equal(3, arms[3].Count)
+
+	arms = b.ArmsByMean()
+	sort.Sort(sort.Reverse(arms))
+	for i, arm := range arms {
+		indices[i] = arm.Index
+	}
+	assert.Equal([]int{2, 0, 3, 1}, indices, "should rank the arms by mean")
+
+	assert.Nil(b.Update(1, 1))
+	assert.Equal(0.2, arms[3].Mean, "should not change the snapshot with updates")
+}
@@ -0,0 +1,98 @@
+package bandit
+
+// ArmReason is the reason an arm is excluded from selection
+type ArmReason int
+
+const (
+	// ArmEligible is not excluded
+	ArmEligible ArmReason = iota
+	// ArmDisabled was disabled with Disable
+	ArmDisabled
+	// ArmRetired reached its target of SetTargets, and was disabled
+	ArmRetired
+	// ArmAliased is pooled into another arm by AliasArm
+	ArmAliased
+	// ArmCooling was selected within the last Cooldown selections
+	ArmCooling
+)
+
+// String returns the name of the reason
+func (r ArmReason) String() string {
+	switch r {
+	case ArmEligible:
+		return "eligible"
+	case ArmDisabled:
+		return "disabled"
+	case ArmRetired:
+		return "retired"
+	case ArmAliased:
+		return "aliased"
+	case ArmCooling:
+		return "cooling"
+	}
+	return "unknown"
+}
+
+// ArmState describes whether an arm can be selected, and otherwise why not.
+// CooldownRemaining is the number of selections until a cooling arm is
+// eligible again, and Canonical the arm an aliased arm is pooled into, or the
+// arm itself.
+type ArmState struct {
+	Eligible          bool
+	Reason            ArmReason
+	CooldownRemaining int
+	Canonical         int
+}
+
+// ArmStatus returns whether the arm can be selected next, and otherwise the
+// reason it is excluded. An arm that is both aliased and disabled reports the
+// alias, and a retired arm is reported as retired rather than disabled. A
+// cooling arm is eligible when every enabled arm is cooling and it is the
+// least recently selected one.
+func (b *EpsilonGreedy) ArmStatus(arm int) (ArmState, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.initialized() {
+		return ArmState{}, ErrNotInitialized
+	}
+	if arm < 0 || arm >= len(b.Rewards) {
+		return ArmState{}, &ArmIndexError{Index: arm, NumArms: len(b.Rewards)}
+	}
+
+	state := ArmState{Canonical: b.canonical(arm)}
+	switch {
+	case b.isAlias(arm):
+		state.Reason = ArmAliased
+	case b.hasTargets() && b.reachedTarget(arm) && b.isDisabled(arm):
+		state.Reason = ArmRetired
+	case b.isDisabled(arm):
+		state.Reason = ArmDisabled
+	case b.isCooling(arm) && !b.oldestCooling(arm):
+		state.Reason = ArmCooling
+		state.CooldownRemaining = b.CooldownUntil[arm] - b.SelectionCount
+	default:
+		state.Eligible = true
+	}
+	return state, nil
+}
+
+// isDisabled returns whether the arm is disabled
+func (b *EpsilonGreedy) isDisabled(arm int) bool {
+	return len(b.Disabled) == len(b.Rewards) && b.Disabled[arm]
+}
+
+// oldestCooling returns whether the cooling arm is eligible anyway, since
+// every enabled arm is cooling and it is the least recently selected, like
+// with eligibleArms
+func (b *EpsilonGreedy) oldestCooling(arm int) bool {
+	for i := range b.Rewards {
+		if b.isDisabled(i) || b.isAlias(i) {
+			continue
+		}
+		if !b.isCooling(i) || b.CooldownUntil[i] < b.CooldownUntil[arm] || b.CooldownUntil[i] == b.CooldownUntil[arm] && i < arm {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package bandit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ArmStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	var empty EpsilonGreedy
+	_, err := empty.ArmStatus(0)
+	assert.Equal(ErrNotInitialized, err)
+
+	b, err := NewEpsilonGreedy(0, []int{1, 1, 1, 1, 1}, []float64{0.1, 0.2, 0.3, 0.4, 0.5})
+	assert.Nil(err)
+	assert.Nil(b.Disable(0))
+	assert.Nil(b.SetTargets([]float64{0, 1, 0, 0, 0}))
+	assert.Nil(b.Update(1, 1))
+	assert.Nil(b.AliasArm(2, 3))
+	b.Cooldown = 2
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(4, arm)
+
+	tests := []struct {
+		arm      int
+		expected ArmState
+	}{
+		{0, ArmState{Reason: ArmDisabled, Canonical: 0}},
+		{1, ArmState{Reason: ArmRetired, Canonical: 1}},
+		{2, ArmState{Reason: ArmAliased, Canonical: 3}},
+		{3, ArmState{Eligible: true, Reason: ArmEligible, Canonical: 3}},
+		{4, ArmState{Reason: ArmCooling, CooldownRemaining: 2, Canonical: 4}},
+	}
+
+	for _, tt := range tests {
+		state, err := b.ArmStatus(tt.arm)
+		assert.Nil(err)
+		assert.Equal(tt.expected, state, "should report the %s arm %d", tt.expected.Reason, tt.arm)
+	}
+
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	state, _ := b.ArmStatus(4)
+	assert.True(state.Eligible, "should report the least recently selected arm eligible once all arms cool")
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(4, arm, "should agree with the selection")
+
+	_, err = b.ArmStatus(5)
+	assert.True(errors.Is(err, ErrArmsIndexOutOfRange))
+	assert.Equal("cooling", ArmCooling.String())
+}
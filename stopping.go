@@ -0,0 +1,86 @@
+package bandit
+
+import "math"
+
+// ShouldStop returns whether the best arm is identified with confidence
+// 1-delta, following the stopping rule of LUCB: the lower confidence bound of
+// the arm with the highest mean must clear the upper confidence bounds of all
+// the other enabled arms. The confidence radius of an arm with n rewards, out
+// of t rewards over K arms, is
+//
+//	sqrt(2·0.25·ln(5·K·t⁴/(4·delta))/n)
+//
+// the Hoeffding bound of LUCB for rewards in range 0 to 1, with 0.25 the
+// largest variance in that range. The bound holds however small the spread of
+// the rewards seen so far, e.g. for arms whose few rewards are all equal. The
+// guarantee assumes independent rewards from stationary arms. Unplayed arms
+// never stop the experiment. The best arm is -1 for an invalid delta.
+func (b *EpsilonGreedy) ShouldStop(delta float64) (stop bool, bestArm int) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !(delta > 0 && delta < 1) || !b.initialized() {
+		return false, -1
+	}
+	arms := b.enabledArms()
+	if len(arms) == 0 {
+		return false, -1
+	}
+
+	best, challenger := b.contenders(arms, delta)
+	if challenger < 0 {
+		return true, best
+	}
+	lower := b.Rewards[best] - b.confidenceRadius(best, len(arms), delta)
+	upper := b.Rewards[challenger] + b.confidenceRadius(challenger, len(arms), delta)
+	return lower > upper, best
+}
+
+// contenders returns the enabled arm with the highest mean, and the other arm
+// with the highest upper confidence bound, which is -1 when there is none
+func (b *EpsilonGreedy) contenders(arms []int, delta float64) (best, challenger int) {
+	best = arms[0]
+	for _, i := range arms {
+		if b.Rewards[i] > b.Rewards[best] {
+			best = i
+		}
+	}
+
+	challenger = -1
+	upper := math.Inf(-1)
+	for _, i := range arms {
+		if i == best {
+			continue
+		}
+		if u := b.Rewards[i] + b.confidenceRadius(i, len(arms), delta); challenger < 0 || u > upper {
+			challenger, upper = i, u
+		}
+	}
+	return best, challenger
+}
+
+// confidenceRadius returns the radius of the anytime confidence interval of
+// the mean of an arm, out of nArms arms, which is infinite for unplayed arms.
+// It counts the effective number of rewards, so that rewards of a low
+// confidence narrow it less.
+func (b *EpsilonGreedy) confidenceRadius(arm, nArms int, delta float64) float64 {
+	n := b.effectiveObservations(arm)
+	if n == 0 {
+		return math.Inf(1)
+	}
+	var t float64
+	for i := range b.Rewards {
+		t += b.effectiveObservations(i)
+	}
+	return lucbRadius(n, hoeffdingVariance, t, nArms, delta)
+}
+
+// hoeffdingVariance is the largest variance of rewards in range 0 to 1, with
+// which the Hoeffding bound holds without estimating the variance
+const hoeffdingVariance = 0.25
+
+// lucbRadius returns the confidence radius of LUCB for an arm with n rewards
+// of the variance, out of t rewards over nArms arms
+func lucbRadius(n, variance, t float64, nArms int, delta float64) float64 {
+	return math.Sqrt(2 * variance * math.Log(5*float64(nArms)*math.Pow(t, 4)/(4*delta)) / n)
+}
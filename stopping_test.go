@@ -0,0 +1,107 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_ShouldStop(t *testing.T) {
+	assert := assert.New(t)
+
+	play := func(probabilities []float64, pulls int) *EpsilonGreedy {
+		b, _ := NewEpsilonGreedy(0.1, nil, nil)
+		assert.Nil(b.Init(len(probabilities)))
+		b.StableMean = true
+		env, err := NewBernoulliEnv(probabilities, rand.New(rand.NewSource(1)))
+		assert.Nil(err)
+		for i := 0; i < pulls; i++ {
+			for arm := range probabilities {
+				assert.Nil(b.Update(arm, env.Pull(arm)))
+			}
+		}
+		return b
+	}
+
+	separated := play([]float64{0.2, 0.9, 0.3}, 200)
+	stop, best := separated.ShouldStop(0.05)
+	assert.True(stop, "should stop early on a clearly separated instance")
+	assert.Equal(1, best)
+
+	tied := play([]float64{0.5, 0.52, 0.3}, 1000)
+	stop, best = tied.ShouldStop(0.05)
+	assert.False(stop, "should keep running on a near-tied instance")
+	assert.Contains([]int{0, 1}, best)
+
+	stop, _ = separated.ShouldStop(1e-300)
+	assert.False(stop, "should need more evidence for a higher confidence")
+}
+
+func TestEpsilonGreedy_ShouldStopEdgeCases(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &EpsilonGreedy{}
+	stop, best := b.ShouldStop(0.05)
+	assert.False(stop)
+	assert.Equal(-1, best)
+
+	assert.Nil(b.Init(2))
+	for _, delta := range []float64{0, 1, -0.1} {
+		stop, best = b.ShouldStop(delta)
+		assert.False(stop)
+		assert.Equal(-1, best, "should reject delta %v", delta)
+	}
+
+	assert.Nil(b.Update(0, 1))
+	stop, _ = b.ShouldStop(0.05)
+	assert.False(stop, "should not stop with unplayed arms")
+
+	assert.Nil(b.Disable(1))
+	stop, best = b.ShouldStop(0.05)
+	assert.True(stop, "should stop with a single enabled arm")
+	assert.Equal(0, best)
+}
+
+func TestEpsilonGreedy_ShouldStopZeroVariance(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+	b.StableMean = true
+	for i := 0; i < 2; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0))
+	}
+	stop, best := b.ShouldStop(0.01)
+	assert.False(stop, "should not trust a zero spread of a few rewards")
+	assert.Equal(0, best)
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0))
+	}
+	stop, _ = b.ShouldStop(0.01)
+	assert.True(stop, "should stop once the Hoeffding bounds separate")
+}
+
+func TestEpsilonGreedy_ShouldStopWeighted(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+	b.StableMean = true
+	for i := 0; i < 2; i++ {
+		assert.Nil(b.Update(0, 1))
+		assert.Nil(b.Update(1, 0))
+	}
+	for i := 0; i < 100; i++ {
+		assert.Nil(b.UpdateWithConfidence(0, 1, 0.001))
+		assert.Nil(b.UpdateWithConfidence(1, 0, 0.001))
+	}
+	stop, best := b.ShouldStop(0.01)
+	assert.False(stop, "should count rewards of a low confidence by their effective number")
+	assert.Equal(0, best)
+}
@@ -0,0 +1,211 @@
+package bandit
+
+import "sync"
+
+// ArmStats holds the accumulated stats of the arms, which a SelectionStrategy
+// chooses an arm from
+type ArmStats struct {
+	Counts  []int     `json:"counts"`
+	Rewards []float64 `json:"rewards"`
+
+	// M2 holds the running sum of squared deviations from the mean of each
+	// arm, used to estimate the reward variance
+	M2 []float64 `json:"m2,omitempty"`
+}
+
+// Total returns the total number of rewards
+func (s *ArmStats) Total() int64 {
+	return totalCounts(s.Counts)
+}
+
+// Variance returns the sample variance of the rewards of an arm, which is
+// zero for arms with fewer than two rewards
+func (s *ArmStats) Variance(arm int) float64 {
+	n := s.Counts[arm]
+	if n < 2 || len(s.M2) != len(s.Rewards) {
+		return 0
+	}
+	return s.M2[arm] / float64(n-1)
+}
+
+// update adds the reward of an arm to the running mean and variance
+func (s *ArmStats) update(arm int, reward float64) {
+	// NOTE: M2 may be missing when the stats were created from counts and
+	// rewards alone
+	if len(s.M2) != len(s.Rewards) {
+		s.M2 = make([]float64, len(s.Rewards))
+	}
+	s.Counts[arm]++
+	n := float64(s.Counts[arm])
+	oldRewards := s.Rewards[arm]
+	s.Rewards[arm] += (reward - oldRewards) / n
+	s.M2[arm] += (reward - oldRewards) * (reward - s.Rewards[arm])
+}
+
+// SelectionStrategy chooses an arm from the stats, drawing random numbers from
+// r. The stats must not be modified, and have at least one arm.
+type SelectionStrategy interface {
+	Select(stats *ArmStats, r Rand) (int, error)
+}
+
+// EpsilonGreedyStrategy exploits the played arm with the highest mean like
+// EpsilonGreedy, and explores a random arm with probability Epsilon
+type EpsilonGreedyStrategy struct {
+	Epsilon float64
+}
+
+// Select chooses an arm from the stats
+func (s EpsilonGreedyStrategy) Select(stats *ArmStats, r Rand) (int, error) {
+	if s.Epsilon < 0 || s.Epsilon > 1 {
+		return -1, ErrInvalidEpsilon
+	}
+	if randFloat64(r) < s.Epsilon {
+		return randIntn(r, len(stats.Rewards)), nil
+	}
+	return maxMean(stats.Counts, stats.Rewards), nil
+}
+
+// UCB1Strategy selects the unplayed arms first, and then the arm with the
+// highest upper confidence bound of UCB1
+type UCB1Strategy struct{}
+
+// Select chooses an arm from the stats
+func (s UCB1Strategy) Select(stats *ArmStats, r Rand) (int, error) {
+	for i, count := range stats.Counts {
+		if count == 0 {
+			return i, nil
+		}
+	}
+
+	total := timeStep(0, stats.Counts)
+	ucbValues := make([]float64, len(stats.Rewards))
+	for i, reward := range stats.Rewards {
+		ucbValues[i] = reward + UCB1Bonus(stats.Counts[i], total)
+	}
+	return max(ucbValues...), nil
+}
+
+// SoftmaxStrategy samples an arm with probability proportional to the
+// exponential of its mean over Temperature
+type SoftmaxStrategy struct {
+	Temperature float64
+}
+
+// Select chooses an arm from the stats
+func (s SoftmaxStrategy) Select(stats *ArmStats, r Rand) (int, error) {
+	if !(s.Temperature > 0) {
+		return -1, ErrInvalidTemperature
+	}
+
+	return categoricalProb(randFloat64(r), softmax(stats.Rewards, s.Temperature)...), nil
+}
+
+// StrategyBandit pairs the stats of the arms with a pluggable selection
+// strategy, so the strategy can be swapped over the same stats. It is a bandit
+// of its own: EpsilonGreedy, UCB and Softmax keep their built-in selection
+// rules, and the strategies have none of their options, e.g. cooldowns or
+// costs.
+type StrategyBandit struct {
+	sync.RWMutex
+	ArmStats
+	Strategy SelectionStrategy
+
+	// Rand, when set, is the source of the random numbers of the strategy,
+	// e.g. rand.New(rand.NewSource(seed)) for reproducible selections
+	Rand Rand
+}
+
+// Init will initialise the counts and rewards with the provided number of arms
+func (b *StrategyBandit) Init(nArms int) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if nArms < 1 {
+		return ErrInvalidArms
+	}
+	b.Counts = make([]int, nArms)
+	b.Rewards = make([]float64, nArms)
+	b.M2 = make([]float64, nArms)
+	return nil
+}
+
+// SelectArm returns the arm chosen by the strategy. The probability is
+// ignored, since the strategy draws from Rand. It holds the write lock, since
+// a Rand such as *rand.Rand is not safe for concurrent use.
+func (b *StrategyBandit) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if len(b.Rewards) == 0 {
+		return -1, ErrNotInitialized
+	}
+	if b.Strategy == nil {
+		return -1, ErrInvalidStrategy
+	}
+	return b.Strategy.Select(&b.ArmStats, b.Rand)
+}
+
+// SetStrategy swaps the selection strategy, keeping the stats
+func (b *StrategyBandit) SetStrategy(strategy SelectionStrategy) error {
+	if strategy == nil {
+		return ErrInvalidStrategy
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.Strategy = strategy
+	return nil
+}
+
+// Update will update an arm with some reward value,
+// e.g. click = 1, no click = 0
+func (b *StrategyBandit) Update(chosenArm int, reward float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
+		return &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
+	}
+	if reward < 0 {
+		return ErrInvalidReward
+	}
+	b.update(chosenArm, reward)
+	return nil
+}
+
+// GetCounts returns the counts
+func (b *StrategyBandit) GetCounts() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]int, len(b.Counts))
+	copy(sCopy, b.Counts)
+	return sCopy
+}
+
+// GetRewards returns the rewards
+func (b *StrategyBandit) GetRewards() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	sCopy := make([]float64, len(b.Rewards))
+	copy(sCopy, b.Rewards)
+	return sCopy
+}
+
+// NewStrategyBandit returns a pointer to the StrategyBandit struct selecting
+// with the strategy
+func NewStrategyBandit(strategy SelectionStrategy, counts []int, rewards []float64) (*StrategyBandit, error) {
+	if strategy == nil {
+		return nil, ErrInvalidStrategy
+	}
+	if len(counts) != len(rewards) {
+		return nil, ErrInvalidLength
+	}
+
+	return &StrategyBandit{
+		ArmStats: ArmStats{Counts: counts, Rewards: rewards},
+		Strategy: strategy,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package bandit
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategyBandit(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewStrategyBandit(nil, nil, nil)
+	assert.Equal(ErrInvalidStrategy, err)
+	_, err = NewStrategyBandit(UCB1Strategy{}, []int{1}, nil)
+	assert.Equal(ErrInvalidLength, err)
+
+	b, err := NewStrategyBandit(UCB1Strategy{}, nil, nil)
+	assert.Nil(err)
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrNotInitialized, err)
+	assert.Equal(ErrInvalidArms, b.Init(0))
+	assert.Nil(b.Init(2))
+	assert.True(errors.Is(b.Update(2, 1), ErrArmsIndexOutOfRange))
+	assert.Equal(ErrInvalidReward, b.Update(0, -1))
+	assert.Equal(ErrInvalidStrategy, b.SetStrategy(nil))
+}
+
+func TestArmStats(t *testing.T) {
+	assert := assert.New(t)
+
+	stats := ArmStats{Counts: []int{0, 0}, Rewards: []float64{0, 0}}
+	stats.update(0, 1)
+	assert.Equal(0.0, stats.Variance(0), "should have no variance with a single reward")
+	stats.update(0, 0)
+	stats.update(1, 0.5)
+	assert.Equal(int64(3), stats.Total())
+	assert.Equal([]float64{0.5, 0.5}, stats.Rewards)
+	assert.Equal(0.5, stats.Variance(0))
+}
+
+func TestStrategyBandit_Strategies(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewStrategyBandit(EpsilonGreedyStrategy{Epsilon: 0}, []int{10, 10, 1}, []float64{0.2, 0.8, 0.5})
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should exploit the highest mean with epsilon greedy")
+
+	assert.Nil(b.SetStrategy(UCB1Strategy{}))
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(2, arm, "should explore the rarely pulled arm with UCB1")
+
+	assert.Nil(b.SetStrategy(SoftmaxStrategy{Temperature: 0.1}))
+	counts := make([]int, 3)
+	for i := 0; i < 1000; i++ {
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		counts[arm]++
+	}
+	assert.Greater(counts[1], counts[2], "should favor the highest mean with softmax")
+	assert.Greater(counts[2], counts[0], "should favor the higher means with softmax")
+
+	assert.Nil(b.SetStrategy(SoftmaxStrategy{}))
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrInvalidTemperature, err)
+	assert.Nil(b.SetStrategy(EpsilonGreedyStrategy{Epsilon: 2}))
+	_, err = b.SelectArm(0.5)
+	assert.Equal(ErrInvalidEpsilon, err)
+
+	assert.Equal([]int{10, 10, 1}, b.GetCounts(), "should keep the stats across strategies")
+}
+
+func TestStrategyBandit_Simulate(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, strategy := range []SelectionStrategy{
+		EpsilonGreedyStrategy{Epsilon: 0.1},
+		UCB1Strategy{},
+		SoftmaxStrategy{Temperature: 0.1},
+	} {
+		b, _ := NewStrategyBandit(strategy, nil, nil)
+		b.Rand = rand.New(rand.NewSource(1))
+		assert.Nil(b.Init(3))
+		env, err := NewBernoulliEnv([]float64{0.1, 0.2, 0.8}, rand.New(rand.NewSource(1)))
+		assert.Nil(err)
+
+		pulls := 2000
+		for i := 0; i < pulls; i++ {
+			arm, err := b.SelectArm(0.5)
+			assert.Nil(err)
+			assert.Nil(b.Update(arm, env.Pull(arm)))
+		}
+		assert.Greater(b.GetCounts()[2], pulls/2, "should favor the best arm with %T", strategy)
+	}
+}
+
+func TestStrategyBandit_SelectArmConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewStrategyBandit(EpsilonGreedyStrategy{Epsilon: 0.5}, []int{1, 1}, []float64{0.2, 0.8})
+	assert.Nil(err)
+	b.Rand = rand.New(rand.NewSource(1))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, err := b.SelectArm(0.5)
+				assert.Nil(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	zero := &StrategyBandit{}
+	assert.Nil(zero.Init(2))
+	_, err = zero.SelectArm(0.5)
+	assert.Equal(ErrInvalidStrategy, err, "should throw an error without a strategy")
+}
+
+func TestEpsilonGreedyStrategy_UnplayedArms(t *testing.T) {
+	assert := assert.New(t)
+
+	stats := &ArmStats{Counts: []int{4, 0}, Rewards: []float64{0.2, 0.9}}
+	arm, err := EpsilonGreedyStrategy{}.Select(stats, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	assert.Equal(0, arm, "should exploit the played arm like EpsilonGreedy")
+}
@@ -0,0 +1,101 @@
+package bandit
+
+import "math"
+
+// SetTargets sets the cumulative reward targets of each arm, which must not be
+// negative, where zero is no target. An arm retires from selection once the
+// rewards earned from then on reach its target, by being disabled, e.g. to
+// stop serving a capped campaign. Passing nil removes the targets.
+func (b *EpsilonGreedy) SetTargets(targets []float64) error {
+	b.Lock()
+	defer b.Unlock()
+
+	if targets == nil {
+		b.Targets = nil
+		b.Earned = nil
+		return nil
+	}
+	if len(targets) != len(b.Rewards) {
+		return ErrInvalidLength
+	}
+	for _, target := range targets {
+		if !(target >= 0) {
+			return ErrInvalidTarget
+		}
+	}
+
+	b.Targets = make([]float64, len(targets))
+	copy(b.Targets, targets)
+	b.Earned = make([]float64, len(b.Rewards))
+	return nil
+}
+
+// RemainingBudget returns the reward left until each arm reaches its target,
+// which is infinite for arms without a target, or nil when no targets are set
+func (b *EpsilonGreedy) RemainingBudget() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.hasTargets() {
+		return nil
+	}
+	remaining := make([]float64, len(b.Targets))
+	for i, target := range b.Targets {
+		if target == 0 {
+			remaining[i] = math.Inf(1)
+			continue
+		}
+		remaining[i] = math.Max(target-b.Earned[i], 0)
+	}
+	return remaining
+}
+
+// RetiredArms returns the indices of the arms that have reached their target
+func (b *EpsilonGreedy) RetiredArms() []int {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.hasTargets() {
+		return nil
+	}
+	var arms []int
+	for i := range b.Targets {
+		if b.reachedTarget(i) {
+			arms = append(arms, i)
+		}
+	}
+	return arms
+}
+
+func (b *EpsilonGreedy) hasTargets() bool {
+	return len(b.Targets) == len(b.Rewards) && len(b.Earned) == len(b.Rewards)
+}
+
+func (b *EpsilonGreedy) reachedTarget(arm int) bool {
+	return b.Targets[arm] > 0 && b.Earned[arm] >= b.Targets[arm]
+}
+
+// earn adds the reward to the cumulative reward of the arm, and retires the
+// arm when it reaches its target, returning the callback to run
+func (b *EpsilonGreedy) earn(arm int, reward float64) func() {
+	if !b.hasTargets() {
+		return nil
+	}
+	reached := b.reachedTarget(arm)
+	b.Earned[arm] += reward
+	if reached || !b.reachedTarget(arm) {
+		return nil
+	}
+
+	if len(b.Disabled) != len(b.Rewards) {
+		b.Disabled = make([]bool, len(b.Rewards))
+	}
+	b.Disabled[arm] = true
+	if b.OnTargetReached == nil {
+		return nil
+	}
+	onTargetReached, earned := b.OnTargetReached, b.Earned[arm]
+	return func() {
+		onTargetReached(arm, earned)
+	}
+}
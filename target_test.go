@@ -0,0 +1,72 @@
+package bandit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_SetTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+
+	tests := []struct {
+		targets []float64
+		err     error
+	}{
+		{[]float64{1}, ErrInvalidLength},
+		{[]float64{1, -1}, ErrInvalidTarget},
+		{[]float64{1, math.NaN()}, ErrInvalidTarget},
+		{[]float64{1, 0}, nil},
+		{nil, nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.err, b.SetTargets(tt.targets))
+	}
+	assert.Nil(b.RemainingBudget(), "should have no budget without targets")
+	assert.Nil(b.RetiredArms())
+}
+
+func TestEpsilonGreedy_Targets(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0, nil, nil)
+	assert.Nil(b.Init(3))
+	assert.Nil(b.SetTargets([]float64{3, 0, 10}))
+
+	var reached []int
+	b.OnTargetReached = func(arm int, earned float64) {
+		assert.Equal(3.0, earned)
+		reached = append(reached, arm)
+	}
+	assert.Nil(b.Update(1, 0.5))
+	assert.Nil(b.Update(2, 0.5))
+	for i := 0; i < 2; i++ {
+		assert.Nil(b.Update(0, 1))
+	}
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should exploit the best arm before its target")
+	assert.Nil(b.Update(arm, 1))
+
+	assert.Equal([]int{0}, reached, "should call back once the target is reached")
+	assert.Equal([]int{0}, b.RetiredArms())
+	assert.Equal([]float64{0, math.Inf(1), 9.5}, b.RemainingBudget())
+
+	for i := 0; i < 10; i++ {
+		arm, err := b.SelectArm(0.5)
+		assert.Nil(err)
+		assert.NotEqual(0, arm, "should not select the retired arm")
+	}
+
+	assert.Nil(b.Update(0, 1), "should still learn from late rewards")
+	assert.Equal([]int{0}, reached, "should not call back twice")
+
+	assert.Nil(b.SetTargets([]float64{3, 0, 10}))
+	assert.Equal([]float64{3, math.Inf(1), 10}, b.RemainingBudget(), "should count the earned rewards from then on")
+	assert.Nil(b.RetiredArms())
+}
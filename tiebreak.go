@@ -0,0 +1,92 @@
+package bandit
+
+import "slices"
+
+// TieBreak is the policy choosing among the arms tied for the highest mean
+// when exploiting
+type TieBreak int
+
+const (
+	// TieLowest exploits the tied arm with the lowest index, which is the
+	// default
+	TieLowest TieBreak = iota
+	// TieRandom exploits a tied arm uniformly at random, so that no arm is
+	// favoured by its index, and the propensities split the exploit share
+	// between the tied arms
+	TieRandom
+	// TieMostPulled exploits the tied arm with the most pulls, or the lowest
+	// index among those, which keeps the exploit arm stable
+	TieMostPulled
+)
+
+// tiedWith returns the arms among the provided arms, or all arms when nil,
+// with the same score as best, in index order. The result reuses the tied
+// buffer, so it is only valid under the lock until the next selection.
+func (b *EpsilonGreedy) tiedWith(rewards []float64, best int, arms []int) []int {
+	value := b.score(rewards, best)
+	tied := b.tied[:0]
+	check := func(i int) {
+		if b.score(rewards, i) == value {
+			tied = append(tied, i)
+		}
+	}
+
+	if arms == nil {
+		for i := range b.Rewards {
+			check(i)
+		}
+	} else {
+		for _, i := range arms {
+			check(i)
+		}
+	}
+	b.tied = tied
+	return tied
+}
+
+// mostPulled returns the tied arm with the most pulls
+func (b *EpsilonGreedy) mostPulled(tied []int) int {
+	best := tied[0]
+	for _, i := range tied[1:] {
+		if b.Counts[i] > b.Counts[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// ties returns the arms tied with best that TieRandom exploits among, or nil
+// when exploiting always selects best
+func (b *EpsilonGreedy) ties(best int, arms []int) []int {
+	if b.TieBreak != TieRandom {
+		return nil
+	}
+	tied := b.tiedWith(b.exploitRewards(), best, arms)
+	if len(tied) < 2 {
+		return nil
+	}
+	return tied
+}
+
+// exploitArm returns the arm exploiting selects among the ties of best
+func (b *EpsilonGreedy) exploitArm(best int, ties []int) int {
+	if ties == nil {
+		return best
+	}
+	return ties[b.intn(len(ties))]
+}
+
+// exploitShare returns the probability of exploiting selecting the arm among
+// the ties of best
+func exploitShare(arm, best int, ties []int) float64 {
+	if ties == nil {
+		if arm == best {
+			return 1
+		}
+		return 0
+	}
+	if slices.Contains(ties, arm) {
+		return 1 / float64(len(ties))
+	}
+	return 0
+}
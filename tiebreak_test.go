@@ -0,0 +1,64 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_TieBreak(t *testing.T) {
+	assert := assert.New(t)
+
+	// NOTE: Arms 1, 2 and 3 are tied with a mean of 1, and arm 3 has the most
+	// pulls
+	newTied := func(tieBreak TieBreak) *EpsilonGreedy {
+		b, err := NewEpsilonGreedy(0, []int{2, 2, 1, 4}, []float64{0.5, 1, 1, 1})
+		assert.Nil(err)
+		b.TieBreak = tieBreak
+		b.Rand = rand.New(rand.NewSource(1))
+		return b
+	}
+
+	tests := []struct {
+		tieBreak TieBreak
+		expected []int
+	}{
+		{TieLowest, []int{1}},
+		{TieRandom, []int{1, 2, 3}},
+		{TieMostPulled, []int{3}},
+	}
+
+	for i, tt := range tests {
+		b := newTied(tt.tieBreak)
+		seen := map[int]bool{}
+		for j := 0; j < 100; j++ {
+			arm, err := b.SelectArm(0.5)
+			assert.Nil(err)
+			seen[arm] = true
+		}
+		var arms []int
+		for arm := range seen {
+			arms = append(arms, arm)
+		}
+		assert.ElementsMatch(tt.expected, arms, "should exploit the tied arms for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_TieRandomPropensities(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.4, []int{1, 1, 1}, []float64{1, 1, 0})
+	assert.Nil(err)
+	b.TieBreak = TieRandom
+
+	probs, err := b.Propensities()
+	assert.Nil(err)
+	assert.InDeltaSlice([]float64{0.4/3 + 0.3, 0.4/3 + 0.3, 0.4 / 3}, probs, 1e-9, "should split the exploit share between the ties")
+
+	arm, err := b.SelectArm(0.9)
+	assert.Nil(err)
+	explanation, err := b.Explain()
+	assert.Nil(err)
+	assert.InDelta(probs[arm], explanation.Propensity, 1e-9, "should log the split propensity")
+}
@@ -0,0 +1,73 @@
+package bandit
+
+import "time"
+
+// Decision represents a single selection made by the bandit
+type Decision struct {
+	Timestamp time.Time `json:"timestamp"`
+	Arm       int       `json:"arm"`
+	Explored  bool      `json:"explored"`
+	Epsilon   float64   `json:"epsilon"`
+}
+
+// DecisionTrace keeps the last decisions in a ring buffer, e.g. to debug why
+// a user saw an arm. It is guarded by the lock of the bandit it belongs to.
+type DecisionTrace struct {
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	items []Decision
+	next  int
+	full  bool
+}
+
+// add records the decision, overwriting the oldest one once full
+func (t *DecisionTrace) add(d decision) {
+	now := time.Now
+	if t.Now != nil {
+		now = t.Now
+	}
+
+	t.items[t.next] = Decision{
+		Timestamp: now(),
+		Arm:       d.arm,
+		Explored:  d.explored,
+		Epsilon:   d.epsilon,
+	}
+	t.next = (t.next + 1) % len(t.items)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// NewDecisionTrace returns a pointer to the DecisionTrace struct holding the
+// last size decisions
+func NewDecisionTrace(size int) (*DecisionTrace, error) {
+	if size < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &DecisionTrace{
+		items: make([]Decision, size),
+	}, nil
+}
+
+// RecentDecisions returns a copy of the traced decisions from the oldest to
+// the newest, or nil when the trace is not enabled
+func (b *EpsilonGreedy) RecentDecisions() []Decision {
+	b.RLock()
+	defer b.RUnlock()
+
+	t := b.Trace
+	if t == nil {
+		return nil
+	}
+	if !t.full {
+		sCopy := make([]Decision, t.next)
+		copy(sCopy, t.items[:t.next])
+		return sCopy
+	}
+	sCopy := make([]Decision, 0, len(t.items))
+	sCopy = append(sCopy, t.items[t.next:]...)
+	return append(sCopy, t.items[:t.next]...)
+}
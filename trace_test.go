@@ -0,0 +1,63 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecisionTrace(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewDecisionTrace(0)
+	assert.Equal(ErrInvalidSize, err, "should throw error for invalid size")
+	_, err = NewDecisionTrace(1)
+	assert.Nil(err)
+}
+
+func TestEpsilonGreedy_RecentDecisions(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.5, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	b.Rand = rand.New(rand.NewSource(1))
+	assert.Nil(b.RecentDecisions(), "should not trace by default")
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	trace, err := NewDecisionTrace(3)
+	assert.Nil(err)
+	trace.Now = clock.Now
+	b.Trace = trace
+
+	var arms []int
+	for i := 0; i < 2; i++ {
+		arm, err := b.SelectArm(1.0)
+		assert.Nil(err)
+		arms = append(arms, arm)
+		clock.Advance(time.Second)
+	}
+	decisions := b.RecentDecisions()
+	assert.Equal(2, len(decisions), "should hold the decisions before it is full")
+	assert.Equal(arms[0], decisions[0].Arm)
+	assert.False(decisions[0].Explored)
+	assert.Equal(0.5, decisions[0].Epsilon)
+
+	for i := 0; i < 5; i++ {
+		arm, err := b.SelectArm(float64(i%2) * 0.4)
+		assert.Nil(err)
+		arms = append(arms, arm)
+		clock.Advance(time.Second)
+	}
+
+	decisions = b.RecentDecisions()
+	assert.Equal(3, len(decisions), "should only retain the last decisions")
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, d := range decisions {
+		assert.Equal(arms[4+i], d.Arm, "should retain the decisions in order")
+		assert.Equal(start.Add(time.Duration(4+i)*time.Second), d.Timestamp, "should retain the decisions in order")
+		assert.True(d.Explored, "should trace the exploration")
+	}
+}
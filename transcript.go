@@ -0,0 +1,43 @@
+package bandit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Transcript is the sequence of arms selected by a bandit replaying a
+// sequence of rewards, e.g. to pin the behavior of an algorithm against a
+// golden transcript across refactors
+type Transcript []int
+
+// RecordTranscript replays the rewards against the bandit, selecting an arm
+// with a probability drawn from r and updating it with the next reward for
+// each of them. The bandit must be initialised, and seeded when it draws
+// random numbers of its own, for the transcript to be reproducible.
+func RecordTranscript(b Bandit, r Rand, rewards []float64) (Transcript, error) {
+	transcript := make(Transcript, 0, len(rewards))
+	for _, reward := range rewards {
+		arm, err := b.SelectArm(randFloat64(r))
+		if err != nil {
+			return transcript, err
+		}
+		if err := b.Update(arm, reward); err != nil {
+			return transcript, err
+		}
+		transcript = append(transcript, arm)
+	}
+	return transcript, nil
+}
+
+// String returns the arms separated by spaces, compact enough to paste as the
+// golden transcript of a test
+func (t Transcript) String() string {
+	var sb strings.Builder
+	for i, arm := range t {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.Itoa(arm))
+	}
+	return sb.String()
+}
@@ -0,0 +1,36 @@
+package bandit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordTranscript(t *testing.T) {
+	assert := assert.New(t)
+
+	record := func() Transcript {
+		b, _ := NewEpsilonGreedy(0.5, nil, nil)
+		assert.Nil(b.Init(3))
+		b.Rand = rand.New(rand.NewSource(1))
+
+		transcript, err := RecordTranscript(b, rand.New(rand.NewSource(2)), []float64{1, 0, 1, 1, 0, 0, 1, 0})
+		assert.Nil(err)
+		return transcript
+	}
+	assert.Equal(record(), record(), "should reproduce the transcript under fixed seeds")
+	assert.Equal(8, len(record()))
+
+	b, _ := NewEpsilonGreedy(0.5, nil, nil)
+	transcript, err := RecordTranscript(b, nil, []float64{1})
+	assert.Equal(ErrNotInitialized, err)
+	assert.Empty(transcript)
+
+	assert.Nil(b.Init(1))
+	transcript, err = RecordTranscript(b, nil, []float64{1, -1})
+	assert.Equal(ErrInvalidReward, err)
+	assert.Equal(Transcript{0}, transcript, "should keep the arms before the error")
+	assert.Equal("0 1 12", Transcript{0, 1, 12}.String())
+	assert.Equal("", Transcript{}.String())
+}
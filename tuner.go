@@ -0,0 +1,73 @@
+package bandit
+
+import "math"
+
+// TemperatureTuner adjusts the temperature of softmax from the trend of the
+// realized rewards. While the mean of the last ShortWindow rewards is above
+// the mean of the last LongWindow rewards, the rewards are still climbing and
+// the temperature stays high, and once they plateau it cools down to
+// MinTemperature:
+//
+//	temperature = min + (max-min)·clamp(1 - long/short, 0, 1)
+//
+// The temperature is MaxTemperature until LongWindow rewards are observed, and
+// throughout when the ShortWindow is below one or not shorter than the
+// LongWindow. It is guarded by the lock of the bandit it belongs to.
+type TemperatureTuner struct {
+	MinTemperature float64
+	MaxTemperature float64
+	ShortWindow    int
+	LongWindow     int
+
+	recent []float64
+	next   int
+}
+
+// observe records the reward, and returns the tuned temperature
+func (t *TemperatureTuner) observe(reward float64) float64 {
+	if t.ShortWindow < 1 || t.LongWindow <= t.ShortWindow {
+		return t.MaxTemperature
+	}
+	if len(t.recent) < t.LongWindow {
+		t.recent = append(t.recent, reward)
+	} else {
+		t.recent[t.next] = reward
+	}
+	t.next = (t.next + 1) % t.LongWindow
+	if len(t.recent) < t.LongWindow {
+		return t.MaxTemperature
+	}
+
+	// The ring buffer is full, so the latest rewards are just before next
+	var short float64
+	for i := 1; i <= t.ShortWindow; i++ {
+		short += t.recent[(t.next-i+t.LongWindow)%t.LongWindow]
+	}
+	short /= float64(t.ShortWindow)
+	long := sumFloat64(t.recent...) / float64(t.LongWindow)
+
+	climb := 0.0
+	if short > 0 {
+		climb = math.Max(0, math.Min(1, 1-long/short))
+	}
+	return t.MinTemperature + (t.MaxTemperature-t.MinTemperature)*climb
+}
+
+// NewTemperatureTuner returns a pointer to the TemperatureTuner struct, where
+// the temperatures must be in range 0 < min <= max and the short window must
+// be shorter than the long window
+func NewTemperatureTuner(min, max float64, shortWindow, longWindow int) (*TemperatureTuner, error) {
+	if !(min > 0) || max < min {
+		return nil, ErrInvalidTemperature
+	}
+	if shortWindow < 1 || longWindow <= shortWindow {
+		return nil, ErrInvalidSize
+	}
+
+	return &TemperatureTuner{
+		MinTemperature: min,
+		MaxTemperature: max,
+		ShortWindow:    shortWindow,
+		LongWindow:     longWindow,
+	}, nil
+}
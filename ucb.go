@@ -10,6 +10,38 @@ type UCB struct {
 	sync.RWMutex
 	Counts  []int
 	Rewards []float64
+
+	// Costs holds the cost of serving each arm. When set, the upper
+	// confidence bound is taken per unit cost.
+	Costs []float64
+
+	// SelectionCount is the number of selections made, which is the time step
+	// of the exploration bonus
+	SelectionCount int
+
+	// Guard, when set, warns about rewards outside the expected range
+	Guard *RewardGuard
+
+	// Normalize accepts any non-negative reward, and divides the mean rewards
+	// by MaxReward, the largest reward observed, before adding the bonus
+	Normalize bool
+	MaxReward float64
+
+	// SignedRewards accepts any finite reward, e.g. negative penalties, and
+	// rescales the mean rewards from MinReward to MaxReward, the range
+	// observed, into the range 0 to 1 before adding the bonus
+	SignedRewards bool
+	MinReward     float64
+
+	// BonusFunc returns the exploration bonus of an arm with count pulls out
+	// of total pulls, which is added to its mean. It defaults to UCB1Bonus.
+	BonusFunc func(count int, total int) float64
+
+	// Temperature, when greater than zero, samples the arms from a softmax
+	// over the upper confidence bounds instead of taking the highest one, so
+	// that every played arm keeps a positive propensity, e.g. for off-policy
+	// evaluation
+	Temperature float64
 }
 
 // Init will initialise the counts and rewards with the provided number of arms
@@ -22,16 +54,28 @@ func (b *UCB) Init(nArms int) error {
 	}
 	b.Counts = make([]int, nArms)
 	b.Rewards = make([]float64, nArms)
+	b.SelectionCount = 0
 	return nil
 }
 
 // SelectArm chooses an arm that exploits if the value is more than the epsilon
-// threshold, and explore if the value is less than epsilon
-func (b *UCB) SelectArm(probability float64) int {
-	b.RLock()
-	defer b.RUnlock()
+// threshold, and explore if the value is less than epsilon. With a Temperature
+// the probability, in range 0 to 1, samples an arm from the softmax over the
+// upper confidence bounds.
+func (b *UCB) SelectArm(probability float64) (int, error) {
+	b.Lock()
+	defer b.Unlock()
 
+	arm := b.selectArm(probability)
+	b.SelectionCount++
+	return arm, nil
+}
+
+func (b *UCB) selectArm(probability float64) int {
 	nArms := len(b.Counts)
+	if nArms == 1 {
+		return 0
+	}
 
 	// Select unplayed arms
 	for i := 0; i < nArms; i++ {
@@ -40,30 +84,107 @@ func (b *UCB) SelectArm(probability float64) int {
 		}
 	}
 
-	totalCounts := sum(b.Counts...)
+	ucbValues := b.ucbValues()
+	if b.Temperature > 0 {
+		return categoricalProb(probability, softmax(ucbValues, b.Temperature)...)
+	}
+	return max(ucbValues...)
+}
+
+// SelectionProbabilities returns the probability of the next selection
+// choosing each arm, which is one for the unplayed arm selected first, or for
+// the highest upper confidence bound without a Temperature
+func (b *UCB) SelectionProbabilities() []float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	probs := make([]float64, len(b.Counts))
+	if len(probs) == 0 {
+		return probs
+	}
+	for i, count := range b.Counts {
+		if count == 0 || len(probs) == 1 {
+			probs[i] = 1
+			return probs
+		}
+	}
+
+	ucbValues := b.ucbValues()
+	if b.Temperature > 0 {
+		return softmax(ucbValues, b.Temperature)
+	}
+	probs[max(ucbValues...)] = 1
+	return probs
+}
+
+// ucbValues returns the upper confidence bound of each arm, per unit cost
+// when costs are set
+func (b *UCB) ucbValues() []float64 {
+	nArms := len(b.Counts)
+	totalCounts := timeStep(b.SelectionCount, b.Counts)
+	bonus := UCB1Bonus
+	if b.BonusFunc != nil {
+		bonus = b.BonusFunc
+	}
 	ucbValues := make([]float64, nArms)
 
 	for i := 0; i < nArms; i++ {
 		count := b.Counts[i]
 		reward := b.Rewards[i]
-		bonus := math.Sqrt((2.0 * math.Log(float64(totalCounts))) / float64(count))
-		ucbValues[i] = bonus + reward
+		if b.SignedRewards {
+			if b.MaxReward > b.MinReward {
+				reward = (reward - b.MinReward) / (b.MaxReward - b.MinReward)
+			}
+		} else if b.Normalize && b.MaxReward > 0 {
+			reward /= b.MaxReward
+		}
+		ucbValues[i] = reward + bonus(count, totalCounts)
+		if len(b.Costs) == nArms {
+			ucbValues[i] /= b.Costs[i]
+		}
 	}
+	return ucbValues
+}
 
-	return max(ucbValues...)
+// GetSelectionCount returns the number of selections made
+func (b *UCB) GetSelectionCount() int {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.SelectionCount
 }
 
-// Update will update an arm with some reward value,
+// Update will update an arm with some reward value in range 0 to 1, any
+// non-negative value with Normalize, or any finite value with SignedRewards,
 // e.g. click = 1, no click = 0
 func (b *UCB) Update(chosenArm int, reward float64) error {
 	b.Lock()
-	defer b.Unlock()
+	warn, err := b.update(chosenArm, reward)
+	b.Unlock()
+
+	if warn != nil {
+		warn()
+	}
+	return err
+}
 
+func (b *UCB) update(chosenArm int, reward float64) (func(), error) {
 	if chosenArm < 0 || chosenArm >= len(b.Rewards) {
-		return ErrArmsIndexOutOfRange
+		return nil, &ArmIndexError{Index: chosenArm, NumArms: len(b.Rewards)}
 	}
-	if reward < 0 {
-		return ErrInvalidReward
+	if b.SignedRewards {
+		if err := validateReward(reward, true); err != nil {
+			return nil, err
+		}
+		b.MinReward = math.Min(b.MinReward, reward)
+		b.MaxReward = math.Max(b.MaxReward, reward)
+	} else if b.Normalize {
+		if !(reward >= 0) || math.IsInf(reward, 1) {
+			return nil, ErrInvalidReward
+		}
+		b.MaxReward = math.Max(b.MaxReward, reward)
+	} else if err := validateUnitReward(reward); err != nil {
+		return nil, err
 	}
 
 	b.Counts[chosenArm]++
@@ -72,7 +193,40 @@ func (b *UCB) Update(chosenArm int, reward float64) error {
 	oldRewards := b.Rewards[chosenArm]
 	b.Rewards[chosenArm] = (oldRewards*(n-1) + reward) / n
 
-	return nil
+	return b.Guard.observe(chosenArm, reward), nil
+}
+
+// ObservedRewardRange returns the smallest and largest reward observed by the
+// guard, or an empty range of +Inf to -Inf without a guard or observations
+func (b *UCB) ObservedRewardRange() (min, max float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Guard.observedRange()
+}
+
+// RewardRange returns the range the mean rewards are scaled from before adding
+// the bonus, which is the range observed with SignedRewards, 0 to MaxReward
+// with Normalize, and 0 to 1 otherwise
+func (b *UCB) RewardRange() (min, max float64) {
+	b.RLock()
+	defer b.RUnlock()
+
+	switch {
+	case b.SignedRewards:
+		return b.MinReward, b.MaxReward
+	case b.Normalize:
+		return 0, b.MaxReward
+	}
+	return 0, 1
+}
+
+// GetMaxReward returns the largest reward observed with Normalize
+func (b *UCB) GetMaxReward() float64 {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.MaxReward
 }
 
 // GetCounts returns the counts
@@ -95,6 +249,30 @@ func (b *UCB) GetRewards() []float64 {
 	return sCopy
 }
 
+// CopyCounts copies the counts into dst without allocating, and returns the
+// number of arms copied
+func (b *UCB) CopyCounts(dst []int) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Counts) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Counts), nil
+}
+
+// CopyRewards copies the rewards into dst without allocating, and returns the
+// number of arms copied
+func (b *UCB) CopyRewards(dst []float64) (int, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if len(dst) < len(b.Rewards) {
+		return 0, ErrBufferTooSmall
+	}
+	return copy(dst, b.Rewards), nil
+}
+
 // NewUCB returns a pointer to the UCB struct
 func NewUCB(counts []int, rewards []float64) (*UCB, error) {
 	if len(counts) != len(rewards) {
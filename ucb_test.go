@@ -1,6 +1,8 @@
 package bandit
 
 import (
+	"errors"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,19 +70,23 @@ func TestUCB_SelectArm(t *testing.T) {
 	assert.Nil(err)
 	b.Init(3)
 
-	arm := b.SelectArm(0.1)
+	arm, err := b.SelectArm(0.1)
+	assert.Nil(err)
 	assert.Equal(arm, 0, "should select the unplayed arm")
 	b.Update(arm, 1.0)
 
-	arm = b.SelectArm(0.1)
+	arm, err = b.SelectArm(0.1)
+	assert.Nil(err)
 	assert.Equal(arm, 1, "should select the next unplayed arm")
 	b.Update(arm, 0.0)
 
-	arm = b.SelectArm(0.1)
+	arm, err = b.SelectArm(0.1)
+	assert.Nil(err)
 	assert.Equal(arm, 2, "should select the next unplayed arm")
 	b.Update(arm, 1.0)
 
-	arm = b.SelectArm(0.1)
+	arm, err = b.SelectArm(0.1)
+	assert.Nil(err)
 	assert.Equal(arm, 0, "should select the correct arm")
 }
 
@@ -109,7 +115,7 @@ func TestUCBUpdate_ValidParams(t *testing.T) {
 		b.Init(tt.arms)
 		err := b.Update(tt.chosenArm, tt.reward)
 		if tt.err != nil {
-			assert.Equal(tt.err, err, "should throw error for invalid params")
+			assert.True(errors.Is(err, tt.err), "should throw error for invalid params")
 		} else {
 			assert.Nil(err)
 		}
@@ -159,3 +165,137 @@ func TestUCBUpdate_ValidParams(t *testing.T) {
 // 		}
 // 	}
 // }
+
+func TestUCB_CopyCountsAndRewards(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewUCB([]int{1, 2}, []float64{0.1, 0.2})
+	assert.Nil(err)
+
+	_, err = b.CopyCounts(make([]int, 1))
+	assert.Equal(ErrBufferTooSmall, err, "should throw error when buffer is too small")
+	_, err = b.CopyRewards(make([]float64, 1))
+	assert.Equal(ErrBufferTooSmall, err, "should throw error when buffer is too small")
+
+	counts := make([]int, 2)
+	n, err := b.CopyCounts(counts)
+	assert.Nil(err)
+	assert.Equal(2, n)
+	assert.Equal([]int{1, 2}, counts, "counts should be equal")
+
+	rewards := make([]float64, 2)
+	n, err = b.CopyRewards(rewards)
+	assert.Nil(err)
+	assert.Equal(2, n)
+	assert.Equal([]float64{0.1, 0.2}, rewards, "rewards should be equal")
+}
+
+func TestUCB_SelectArmWithDelayedUpdates(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewUCB([]int{1, 4}, []float64{0.0, 1.0})
+	assert.Nil(err)
+
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should select the best arm while the bonus is small")
+
+	// Selections run ahead of the updates, which are never delivered
+	for i := 0; i < 9; i++ {
+		arm, err = b.SelectArm(0.5)
+		assert.Nil(err)
+	}
+	assert.Equal(10, b.GetSelectionCount(), "should count the selections")
+	assert.Equal(5, sum(b.GetCounts()...), "should not count the selections as updates")
+	assert.Equal(0, arm, "should grow the bonus with the selections")
+}
+
+func TestUCB_Normalize(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewUCB(nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+	assert.Equal(ErrInvalidReward, b.Update(0, 1500), "should reject large rewards without normalization")
+
+	b.Normalize = true
+	assert.Equal(ErrInvalidReward, b.Update(0, -1), "should reject negative rewards")
+	assert.Equal(0.0, b.GetMaxReward())
+
+	env, err := NewGaussianEnv([]float64{1000, 1500, 3000}, []float64{200, 200, 200}, rand.New(rand.NewSource(1)))
+	assert.Nil(err)
+	pulls := 2000
+	for i := 0; i < pulls; i++ {
+		arm, err := b.SelectArm(0)
+		assert.Nil(err)
+		assert.Nil(b.Update(arm, env.Pull(arm)))
+	}
+
+	counts := b.GetCounts()
+	assert.Greater(b.GetMaxReward(), 3000.0, "should track the largest reward")
+	assert.Greater(counts[0], 10, "should keep exploring the worst arm")
+	assert.Greater(counts[1], 10, "should keep exploring the other arm")
+	assert.Greater(counts[2], pulls*3/4, "should favor the best arm")
+}
+
+func TestUCB_BonusFunc(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewUCB([]int{1, 100}, []float64{0.5, 0.6})
+	assert.Nil(err)
+	arm, err := b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(0, arm, "should explore the rarely pulled arm with the UCB1 bonus")
+
+	var totals []int
+	b, _ = NewUCB([]int{1, 100}, []float64{0.5, 0.6})
+	b.BonusFunc = func(count, total int) float64 {
+		totals = append(totals, total)
+		return 0
+	}
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should exploit without a bonus")
+	assert.Equal([]int{101, 101}, totals, "should pass the total pulls")
+
+	b, _ = NewUCB([]int{1, 100}, []float64{0.5, 0.6})
+	b.BonusFunc = func(count, total int) float64 {
+		return 0.01 * UCB1Bonus(count, total)
+	}
+	arm, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Equal(1, arm, "should exploit with a scaled down bonus")
+	assert.NotNil(b.Clone().BonusFunc, "should share the bonus with clones")
+}
+
+func TestUCB_Temperature(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewUCB([]int{50, 50, 50}, []float64{0.2, 0.5, 0.8})
+	assert.Nil(err)
+	assert.Equal([]float64{0, 0, 1}, b.SelectionProbabilities(), "should take the highest bound without a temperature")
+
+	b.Temperature = 0.1
+	probs := b.SelectionProbabilities()
+	assert.InDelta(1, sumFloat64(probs...), 1e-9)
+	for i, p := range probs {
+		assert.Greater(p, 0.0, "should give arm %d a positive probability", i)
+	}
+	assert.Greater(probs[2], probs[1])
+	assert.Greater(probs[1], probs[0])
+	assert.Greater(probs[2], 0.9, "should favor the highest bound")
+
+	counts := make([]int, 3)
+	for i := 0; i < 1000; i++ {
+		arm, err := b.SelectArm(float64(i) / 1000)
+		assert.Nil(err)
+		counts[arm]++
+	}
+	assert.Greater(counts[2], 900, "should mostly select the highest bound")
+	assert.Greater(counts[1], 0, "should sometimes select the other arms")
+	assert.Equal(0.1, b.Clone().Temperature)
+
+	b, _ = NewUCB([]int{1, 0}, []float64{1, 0})
+	b.Temperature = 0.1
+	assert.Equal([]float64{0, 1}, b.SelectionProbabilities(), "should select the unplayed arm first")
+}
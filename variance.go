@@ -0,0 +1,76 @@
+package bandit
+
+import (
+	"math"
+	"time"
+)
+
+// minVariance is the variance of rewards reported with a variance of zero, so
+// that their precision stays finite
+const minVariance = 1e-12
+
+// UpdateWithVariance will update an arm with some reward value like Update,
+// for a reward that is itself an estimate with a known variance, e.g. an
+// aggregate reported with its standard error. The reward is weighted by its
+// precision, 1/variance, and while every reward of the arm came with a
+// variance its standard error is 1/sqrt(Σ 1/variance) rather than estimated
+// from the spread of the rewards. Rewards from Update weigh one, as if they
+// had a variance of 1. The variance must not be negative.
+func (b *EpsilonGreedy) UpdateWithVariance(chosenArm int, reward float64, variance float64) error {
+	if !(variance >= 0) || math.IsInf(variance, 1) {
+		return ErrInvalidVariance
+	}
+	precision := 1 / math.Max(variance, minVariance)
+
+	b.Lock()
+	callbacks, err := b.updateAt(chosenArm, reward, precision, time.Time{})
+	if err == nil {
+		b.addPrecision(b.canonical(chosenArm), precision)
+	}
+	logger := b.logger
+	b.Unlock()
+
+	return b.updated(logger, chosenArm, reward, callbacks, err)
+}
+
+// ConfidenceInterval returns the interval mean ± z·se of the mean reward of an
+// arm, where se is its standard error, e.g. z of 1.96 for 95% confidence. Arms
+// with fewer than two rewards assume the variance of 0.25, unless the rewards
+// came with their variance from UpdateWithVariance.
+func (b *EpsilonGreedy) ConfidenceInterval(arm int, z float64) (float64, float64, error) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if !b.initialized() {
+		return 0, 0, ErrNotInitialized
+	}
+	if arm < 0 || arm >= len(b.Rewards) {
+		return 0, 0, &ArmIndexError{Index: arm, NumArms: len(b.Rewards)}
+	}
+	arm = b.canonical(arm)
+	margin := z * b.standardError(arm)
+	return b.Rewards[arm] - margin, b.Rewards[arm] + margin, nil
+}
+
+// addPrecision adds the precision of a reward to the arm
+func (b *EpsilonGreedy) addPrecision(arm int, precision float64) {
+	if len(b.Precisions) != len(b.Rewards) || len(b.PrecisionCounts) != len(b.Rewards) {
+		b.Precisions = make([]float64, len(b.Rewards))
+		b.PrecisionCounts = make([]int, len(b.Rewards))
+	}
+	b.Precisions[arm] += precision
+	b.PrecisionCounts[arm]++
+}
+
+// knownStandardError returns the standard error of the mean reward of the arm
+// from the reported variances, and whether every reward of the arm came with
+// one
+func (b *EpsilonGreedy) knownStandardError(arm int) (float64, bool) {
+	if len(b.Precisions) != len(b.Rewards) || len(b.PrecisionCounts) != len(b.Rewards) {
+		return 0, false
+	}
+	if n := b.PrecisionCounts[arm]; n == 0 || n != b.observations(arm) {
+		return 0, false
+	}
+	return 1 / math.Sqrt(b.Precisions[arm]), true
+}
@@ -0,0 +1,69 @@
+package bandit
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonGreedy_UpdateWithVariance(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(2))
+
+	tests := []struct {
+		variance float64
+		err      error
+	}{
+		{-0.1, ErrInvalidVariance},
+		{math.NaN(), ErrInvalidVariance},
+		{math.Inf(1), ErrInvalidVariance},
+		{0, nil},
+		{0.5, nil},
+	}
+
+	for i, tt := range tests {
+		assert.Equal(tt.err, b.UpdateWithVariance(0, 0.5, tt.variance), "should validate the variance for test %d", i+1)
+	}
+	assert.Equal([]int{2, 0}, b.GetCounts())
+	assert.True(errors.Is(b.UpdateWithVariance(2, 0.5, 0.1), ErrArmsIndexOutOfRange))
+}
+
+func TestEpsilonGreedy_ConfidenceIntervalWithVariance(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(err)
+	assert.Nil(b.Init(3))
+
+	rewards := []float64{0.4, 0.6, 0.5, 0.45, 0.55}
+	for _, reward := range rewards {
+		assert.Nil(b.UpdateWithVariance(0, reward, 0.01))
+		assert.Nil(b.UpdateWithVariance(1, reward, 1))
+		assert.Nil(b.Update(2, reward))
+	}
+
+	widths := make([]float64, 3)
+	for arm := range widths {
+		low, high, err := b.ConfidenceInterval(arm, 1.96)
+		assert.Nil(err)
+		assert.InDelta(0.5, (low+high)/2, 1e-9, "should center on the mean of arm %d", arm)
+		widths[arm] = high - low
+	}
+	assert.InDelta(2*1.96*math.Sqrt(0.01/5), widths[0], 1e-9, "should use the reported variance")
+	assert.InDelta(2*1.96*math.Sqrt(1.0/5), widths[1], 1e-9, "should use the reported variance")
+	assert.Less(widths[0], widths[1], "should narrow less with high variance rewards")
+
+	// NOTE: A reward without a variance falls back to the spread of the rewards
+	assert.Nil(b.Update(0, 0.5))
+	low, high, err := b.ConfidenceInterval(0, 1.96)
+	assert.Nil(err)
+	assert.NotEqual(widths[0], high-low)
+
+	_, _, err = b.ConfidenceInterval(3, 1.96)
+	assert.True(errors.Is(err, ErrArmsIndexOutOfRange))
+}
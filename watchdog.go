@@ -0,0 +1,80 @@
+package bandit
+
+import "time"
+
+// FeedWatchdog tracks the time since the last reward of the bandit it belongs
+// to, and calls OnStale once when SelectArm finds that no reward arrived for
+// Timeout, e.g. when the consumer feeding the updates broke while the
+// selections keep running on stale data. The next reward re-arms it. It is
+// guarded by the lock of the bandit it belongs to.
+type FeedWatchdog struct {
+	Timeout time.Duration
+	OnStale func(idle time.Duration)
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+
+	lastUpdate time.Time
+	stale      bool
+}
+
+// now returns the current time of the clock
+func (w *FeedWatchdog) now() time.Time {
+	if w.Now != nil {
+		return w.Now()
+	}
+	return time.Now()
+}
+
+// fed records a reward
+func (w *FeedWatchdog) fed() {
+	w.lastUpdate = w.now()
+	w.stale = false
+}
+
+// idle returns the time since the last reward, or since the watchdog was
+// created before any reward
+func (w *FeedWatchdog) idle() time.Duration {
+	return w.now().Sub(w.lastUpdate)
+}
+
+// check returns the callback to run when the feed just went stale
+func (w *FeedWatchdog) check() func() {
+	idle := w.idle()
+	if w.stale || idle < w.Timeout {
+		return nil
+	}
+	w.stale = true
+
+	if w.OnStale == nil {
+		return nil
+	}
+	onStale := w.OnStale
+	return func() { onStale(idle) }
+}
+
+// RewardFeedHealthy returns whether a reward arrived within the Timeout of
+// the Watchdog, which is always the case without a Watchdog
+func (b *EpsilonGreedy) RewardFeedHealthy() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.Watchdog == nil || b.Watchdog.idle() < b.Watchdog.Timeout
+}
+
+// NewFeedWatchdog returns a pointer to the FeedWatchdog struct, calling
+// onStale when no reward arrived for timeout on the provided clock, starting
+// from its current time. A nil clock defaults to time.Now.
+func NewFeedWatchdog(timeout time.Duration, onStale func(idle time.Duration), now func() time.Time) (*FeedWatchdog, error) {
+	if timeout <= 0 {
+		return nil, ErrInvalidDuration
+	}
+
+	w := &FeedWatchdog{
+		Timeout: timeout,
+		OnStale: onStale,
+		Now:     now,
+	}
+	w.lastUpdate = w.now()
+	return w, nil
+}
@@ -0,0 +1,66 @@
+package bandit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFeedWatchdog(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		timeout time.Duration
+		err     error
+	}{
+		{time.Minute, nil},
+		{0, ErrInvalidDuration},
+		{-time.Minute, ErrInvalidDuration},
+	}
+
+	for i, tt := range tests {
+		_, err := NewFeedWatchdog(tt.timeout, nil, nil)
+		assert.Equal(tt.err, err, "should throw the correct error for test %d", i+1)
+	}
+}
+
+func TestEpsilonGreedy_RewardFeedHealthy(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(2))
+	assert.True(b.RewardFeedHealthy(), "should be healthy without a watchdog")
+
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var stale []time.Duration
+	w, err := NewFeedWatchdog(10*time.Minute, func(idle time.Duration) {
+		assert.False(b.RewardFeedHealthy(), "should call back without the lock")
+		stale = append(stale, idle)
+	}, clock.Now)
+	assert.Nil(err)
+	b.Watchdog = w
+
+	clock.Advance(5 * time.Minute)
+	assert.Nil(b.Update(0, 1))
+	clock.Advance(9 * time.Minute)
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.True(b.RewardFeedHealthy(), "should be healthy within the timeout")
+	assert.Empty(stale)
+
+	clock.Advance(2 * time.Minute)
+	assert.False(b.RewardFeedHealthy(), "should be unhealthy past the timeout")
+	for i := 0; i < 3; i++ {
+		_, err = b.SelectArm(0.5)
+		assert.Nil(err)
+	}
+	assert.Equal([]time.Duration{11 * time.Minute}, stale, "should call back once")
+
+	assert.Nil(b.Update(1, 1))
+	assert.True(b.RewardFeedHealthy(), "should recover with the next reward")
+	clock.Advance(time.Hour)
+	_, err = b.SelectArm(0.5)
+	assert.Nil(err)
+	assert.Len(stale, 2, "should call back again once re-armed")
+}
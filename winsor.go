@@ -0,0 +1,73 @@
+package bandit
+
+import (
+	"math"
+	"sort"
+)
+
+// Winsorizer caps the rewards of each arm at a high percentile of its recent
+// rewards, e.g. the p99, so that an occasional huge reward moves the mean
+// less. It keeps the last Window raw rewards of each arm, and caps nothing
+// until the window of the arm is full, or at all with a Window below one. It
+// is guarded by the lock of the bandit it belongs to.
+type Winsorizer struct {
+	Percentile float64
+	Window     int
+
+	recent [][]float64
+	next   []int
+}
+
+// winsorize records the raw reward of an arm, and returns it capped at the
+// percentile of the rewards recorded before it
+func (w *Winsorizer) winsorize(arm, nArms int, reward float64) float64 {
+	if w.Window < 1 {
+		return reward
+	}
+	if len(w.recent) != nArms {
+		w.recent = make([][]float64, nArms)
+		w.next = make([]int, nArms)
+	}
+
+	capped := reward
+	if recent := w.recent[arm]; len(recent) == w.Window {
+		capped = math.Min(reward, percentile(recent, w.Percentile))
+	}
+
+	if len(w.recent[arm]) < w.Window {
+		w.recent[arm] = append(w.recent[arm], reward)
+	} else {
+		w.recent[arm][w.next[arm]] = reward
+	}
+	w.next[arm] = (w.next[arm] + 1) % w.Window
+	return capped
+}
+
+// percentile returns the nearest-rank percentile of the values, where p is in
+// the range 0 to 1
+func percentile(values []float64, p float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// NewWinsorizer returns a pointer to the Winsorizer struct
+func NewWinsorizer(percentile float64, window int) (*Winsorizer, error) {
+	if percentile <= 0 || percentile > 1 {
+		return nil, ErrInvalidFraction
+	}
+	if window < 1 {
+		return nil, ErrInvalidSize
+	}
+
+	return &Winsorizer{
+		Percentile: percentile,
+		Window:     window,
+	}, nil
+}
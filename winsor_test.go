@@ -0,0 +1,96 @@
+package bandit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWinsorizer(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		percentile float64
+		window     int
+		err        error
+	}{
+		{0.99, 100, nil},
+		{1, 1, nil},
+		{0, 100, ErrInvalidFraction},
+		{1.1, 100, ErrInvalidFraction},
+		{0.99, 0, ErrInvalidSize},
+	}
+
+	for _, tt := range tests {
+		w, err := NewWinsorizer(tt.percentile, tt.window)
+		if tt.err != nil {
+			assert.Equal(tt.err, err, "should throw errors")
+		} else {
+			assert.Nil(err)
+			assert.Equal(tt.percentile, w.Percentile, "percentile should be equal")
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	assert := assert.New(t)
+
+	values := []float64{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+	tests := []struct {
+		p        float64
+		expected float64
+	}{
+		{0.01, 1},
+		{0.5, 5},
+		{0.9, 9},
+		{0.99, 10},
+		{1, 10},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(tt.expected, percentile(values, tt.p), "p%v", tt.p*100)
+	}
+	assert.Equal(5.0, values[0], "should not sort the values in place")
+}
+
+func TestEpsilonGreedy_Winsor(t *testing.T) {
+	assert := assert.New(t)
+
+	raw, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(raw.Init(2))
+	winsorized, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(winsorized.Init(2))
+	w, err := NewWinsorizer(1, 10)
+	assert.Nil(err)
+	winsorized.Winsor = w
+
+	for i := 0; i < 50; i++ {
+		reward := float64(i % 10)
+		assert.Nil(raw.Update(0, reward))
+		assert.Nil(winsorized.Update(0, reward))
+	}
+	assert.Equal(raw.GetRewards(), winsorized.GetRewards(), "should not cap rewards within the percentile")
+
+	before := raw.GetRewards()[0]
+	assert.Nil(raw.Update(0, 10000))
+	assert.Nil(winsorized.Update(0, 10000))
+
+	rawShift := raw.GetRewards()[0] - before
+	winsorizedShift := winsorized.GetRewards()[0] - before
+	assert.InDelta((9-before)/51, winsorizedShift, 1e-9, "should cap the outlier at the largest recent reward")
+	assert.Less(winsorizedShift, rawShift/100, "outlier should move the mean less")
+	assert.Equal(51, winsorized.GetCounts()[0], "should keep the outlier in the counts")
+}
+
+func TestEpsilonGreedy_WinsorZeroWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	b, _ := NewEpsilonGreedy(0.1, nil, nil)
+	assert.Nil(b.Init(1))
+	b.Winsor = &Winsorizer{Percentile: 0.5}
+
+	for _, reward := range []float64{1, 2, 10000} {
+		assert.Nil(b.Update(0, reward))
+	}
+	assert.InDelta(10003.0/3, b.GetRewards()[0], 1e-9, "should cap nothing without a window")
+}